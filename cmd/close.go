@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+type stateOptions struct {
+	reason      string
+	duplicateOf string
+	status      string
+	recursive   bool
+	depth       int
+	dryRun      bool
+	yes         bool // skip confirmation
+}
+
+// stateClient is the part of the API client used by close and reopen
+type stateClient interface {
+	moveClient
+	CloseIssue(issueID, reason, duplicateIssueID string) error
+	ReopenIssue(issueID string) error
+}
+
+// closeReasons maps --reason values to closeIssue state reasons
+var closeReasons = map[string]string{
+	"completed":   api.CloseReasonCompleted,
+	"not_planned": api.CloseReasonNotPlanned,
+	"duplicate":   api.CloseReasonDuplicate,
+}
+
+func newCloseCommand() *cobra.Command {
+	opts := &stateOptions{depth: 10}
+
+	cmd := &cobra.Command{
+		Use:   "close <issue>",
+		Short: "Close an issue and set its project status",
+		Long: `Close an issue and move it to the closed status on the project board.
+
+The status is defaults.closed_status from the config, or else the "done"
+status alias, or Done; --status overrides it. Issues that are already
+closed keep their state and only have their status set.
+
+Use --reason to record why the issue was closed: completed (the default),
+not_planned, or duplicate. Duplicates name the issue they duplicate with
+--duplicate-of, which implies --reason duplicate.
+
+Use --recursive to close all sub-issues as well. Sub-issues are closed with
+the same reason, except that sub-issues of a duplicate are closed as not
+planned.`,
+		Example: `  # Close an issue as completed and move it to Done
+  gh pmu close 42
+
+  # Close a duplicate of another issue
+  gh pmu close 42 --duplicate-of 17
+
+  # Close an epic and its sub-issues as not planned, previewing first
+  gh pmu close 10 --reason not_planned --recursive --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runIssueState(cmd, args, opts, true)
+		},
+	}
+	needsConfig(cmd)
+
+	cmd.Flags().StringVar(&opts.reason, "reason", "", "Reason for closing: completed, not_planned, or duplicate")
+	cmd.Flags().StringVar(&opts.duplicateOf, "duplicate-of", "", "Close as a duplicate of this `issue`")
+	addStateFlags(cmd, opts, "closed")
+
+	return cmd
+}
+
+func newReopenCommand() *cobra.Command {
+	opts := &stateOptions{depth: 10}
+
+	cmd := &cobra.Command{
+		Use:   "reopen <issue>",
+		Short: "Reopen an issue and set its project status",
+		Long: `Reopen a closed issue and move it back on the project board.
+
+The status is defaults.reopened_status from the config, or else the status
+new issues get (defaults.status); --status overrides it. Without any of
+these the status is left unchanged. Issues that are already open keep their
+state and only have their status set.
+
+Use --recursive to reopen all sub-issues as well.`,
+		Example: `  # Reopen an issue
+  gh pmu reopen 42
+
+  # Reopen an epic and its sub-issues into the backlog
+  gh pmu reopen 10 --recursive --status backlog`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runIssueState(cmd, args, opts, false)
+		},
+	}
+	needsConfig(cmd)
+
+	addStateFlags(cmd, opts, "reopened")
+
+	return cmd
+}
+
+// addStateFlags adds the flags close and reopen share
+func addStateFlags(cmd *cobra.Command, opts *stateOptions, state string) {
+	cmd.Flags().StringVarP(&opts.status, "status", "s", "", fmt.Sprintf("Set this project status instead of the configured %s status", state))
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "Apply to all sub-issues recursively")
+	cmd.Flags().IntVar(&opts.depth, "depth", 10, "Maximum depth for recursive operations")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be changed without making changes")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation prompt for recursive operations")
+}
+
+func runIssueState(cmd *cobra.Command, args []string, opts *stateOptions, closing bool) error {
+	cc, err := commandContext(cmd)
+	if err != nil {
+		return err
+	}
+	return runIssueStateWithDeps(cmd, args, opts, cc.cfg, cc.client, closing)
+}
+
+// runIssueStateWithDeps closes or reopens an issue, and its sub-issues with
+// --recursive, setting the status of each one in the project
+func runIssueStateWithDeps(cmd *cobra.Command, args []string, opts *stateOptions, cfg *config.Config, client stateClient, closing bool) error {
+	out := cmd.OutOrStdout()
+
+	verb, done, targetState := "reopen", "Reopened", "OPEN"
+	if closing {
+		verb, done, targetState = "close", "Closed", "CLOSED"
+	}
+
+	// Validate the reason before looking anything up
+	reason := ""
+	if closing {
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(opts.reason)), " ", "_")
+		switch {
+		case name == "" && opts.duplicateOf != "":
+			name = "duplicate"
+		case name == "":
+			name = "completed"
+		}
+		var ok bool
+		if reason, ok = closeReasons[name]; !ok {
+			return fmt.Errorf("invalid --reason %q: expected completed, not_planned, or duplicate", opts.reason)
+		}
+		if reason == api.CloseReasonDuplicate && opts.duplicateOf == "" {
+			return fmt.Errorf("--reason duplicate requires --duplicate-of")
+		}
+		if opts.duplicateOf != "" && reason != api.CloseReasonDuplicate {
+			return fmt.Errorf("--duplicate-of cannot be used with --reason %s", opts.reason)
+		}
+	}
+
+	owner, repo, number, err := parseIssueReference(args[0])
+	if err != nil {
+		return err
+	}
+	if owner == "" || repo == "" {
+		if owner, repo, err = defaultRepository(cfg); err != nil {
+			return err
+		}
+	}
+
+	issue, err := client.GetIssue(owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	// Find the issue a duplicate duplicates
+	var duplicate *api.Issue
+	if opts.duplicateOf != "" {
+		dupOwner, dupRepo, dupNumber, err := parseIssueReference(opts.duplicateOf)
+		if err != nil {
+			return fmt.Errorf("--duplicate-of: %w", err)
+		}
+		if dupOwner == "" || dupRepo == "" {
+			dupOwner, dupRepo = owner, repo
+		}
+		if strings.EqualFold(dupOwner, owner) && strings.EqualFold(dupRepo, repo) && dupNumber == number {
+			return fmt.Errorf("--duplicate-of: an issue cannot be a duplicate of itself")
+		}
+		if duplicate, err = client.GetIssue(dupOwner, dupRepo, dupNumber); err != nil {
+			return fmt.Errorf("failed to get issue %s/%s#%d: %w", dupOwner, dupRepo, dupNumber, err)
+		}
+	}
+
+	project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	items, err := client.GetProjectItems(project.ID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get project items: %w", err)
+	}
+	itemIDMap := make(map[string]string) // "owner/repo#number" -> itemID
+	for _, item := range items {
+		if item.Issue != nil {
+			key := fmt.Sprintf("%s/%s#%d", item.Issue.Repository.Owner, item.Issue.Repository.Name, item.Issue.Number)
+			itemIDMap[key] = item.ID
+		}
+	}
+
+	issues := []issueInfo{{
+		ID:     issue.ID,
+		Owner:  owner,
+		Repo:   repo,
+		Number: number,
+		Title:  issue.Title,
+		State:  issue.State,
+		URL:    issue.URL,
+		ItemID: itemIDMap[fmt.Sprintf("%s/%s#%d", owner, repo, number)],
+	}}
+	if opts.recursive {
+		subIssues, err := collectSubIssuesRecursive(client, owner, repo, number, itemIDMap, 1, opts.depth)
+		switch {
+		case api.IsUnsupported(err):
+			// Older GitHub Enterprise Server: change the issue itself
+			fmt.Fprintf(os.Stderr, "Warning: %v; %sing #%d only\n", err, verb, number)
+		case err != nil:
+			return fmt.Errorf("failed to collect sub-issues: %w", err)
+		default:
+			issues = append(issues, subIssues...)
+		}
+	}
+
+	status := opts.status
+	if status != "" {
+		status = cfg.ResolveFieldValue("status", status)
+	} else if closing {
+		status = cfg.ClosedStatus()
+	} else {
+		status = cfg.ReopenedStatus()
+	}
+
+	var changes []string
+	if closing {
+		changes = append(changes, "State → closed ("+describeCloseReason(reason, duplicate)+")")
+	} else {
+		changes = append(changes, "State → open")
+	}
+	if status != "" {
+		changes = append(changes, fmt.Sprintf("Status → %s", status))
+	}
+
+	// Show what will change
+	if opts.recursive || opts.dryRun {
+		if opts.dryRun {
+			fmt.Fprintln(out, "Dry run - no changes will be made")
+			fmt.Fprintln(out)
+		}
+
+		fmt.Fprintf(out, "Issues to %s (%d):\n", verb, len(issues))
+		for _, info := range issues {
+			var notes []string
+			if strings.EqualFold(info.State, targetState) {
+				notes = append(notes, "already "+strings.ToLower(targetState))
+			}
+			if info.ItemID == "" {
+				notes = append(notes, "not in project")
+			}
+			line := fmt.Sprintf("%s• #%d - %s", strings.Repeat("  ", info.Depth), info.Number, info.Title)
+			if len(notes) > 0 {
+				line += " (" + strings.Join(notes, ", ") + ")"
+			}
+			fmt.Fprintln(out, line)
+		}
+
+		fmt.Fprintln(out, "\nChanges to apply:")
+		for _, desc := range changes {
+			fmt.Fprintf(out, "  • %s\n", desc)
+		}
+
+		if opts.dryRun {
+			return nil
+		}
+
+		// Prompt for confirmation unless --yes is provided
+		if !opts.yes {
+			fmt.Fprintln(out)
+			ok, err := newPrompter(cmd, ui.New(out)).confirm(fmt.Sprintf("Proceed with %sing %d issues?", verb, len(issues)), false)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(out, "Aborted.")
+				return nil
+			}
+		}
+		fmt.Fprintln(out)
+	}
+
+	// Apply the changes
+	changed, unchanged, notInProject := 0, 0, 0
+	for i, info := range issues {
+		if strings.EqualFold(info.State, targetState) {
+			unchanged++
+		} else {
+			var err error
+			if closing {
+				issueReason, duplicateID := reason, ""
+				if reason == api.CloseReasonDuplicate {
+					if i == 0 {
+						duplicateID = duplicate.ID
+					} else {
+						issueReason = api.CloseReasonNotPlanned
+					}
+				}
+				err = client.CloseIssue(info.ID, issueReason, duplicateID)
+			} else {
+				err = client.ReopenIssue(info.ID)
+			}
+			if err != nil {
+				if i == 0 {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "Warning: failed to %s #%d: %v\n", verb, info.Number, err)
+				continue
+			}
+			changed++
+		}
+
+		if info.ItemID == "" {
+			notInProject++
+			continue
+		}
+		if status != "" {
+			if err := client.SetProjectItemField(project.ID, info.ItemID, "Status", status); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to set status for #%d: %v\n", info.Number, err)
+			}
+		}
+	}
+
+	if opts.recursive {
+		fmt.Fprintf(out, "✓ %s %d issues", done, changed)
+		var notes []string
+		if unchanged > 0 {
+			notes = append(notes, fmt.Sprintf("%d already %s", unchanged, strings.ToLower(targetState)))
+		}
+		if notInProject > 0 {
+			notes = append(notes, fmt.Sprintf("%d not in project", notInProject))
+		}
+		if len(notes) > 0 {
+			fmt.Fprintf(out, " (%s)", strings.Join(notes, ", "))
+		}
+		fmt.Fprintln(out)
+		return nil
+	}
+
+	root := issues[0]
+	if unchanged > 0 {
+		fmt.Fprintf(out, "Issue #%d was already %s\n", number, strings.ToLower(targetState))
+		changes = changes[1:]
+	} else {
+		fmt.Fprintf(out, "✓ %s issue #%d: %s\n", done, number, root.Title)
+	}
+	if root.ItemID == "" {
+		if status != "" {
+			changes = changes[:len(changes)-1]
+		}
+		fmt.Fprintf(out, "Issue #%d is not in the project; its status was not set\n", number)
+	}
+	for _, desc := range changes {
+		fmt.Fprintf(out, "  • %s\n", desc)
+	}
+	link := root.URL
+	if link == "" {
+		link = issueURL(cfg.Host, owner, repo, number)
+	}
+	fmt.Fprintf(out, "🔗 %s\n", link)
+
+	return nil
+}
+
+// describeCloseReason describes the reason an issue is closed for output
+func describeCloseReason(reason string, duplicate *api.Issue) string {
+	switch reason {
+	case api.CloseReasonNotPlanned:
+		return "not planned"
+	case api.CloseReasonDuplicate:
+		return fmt.Sprintf("duplicate of #%d", duplicate.Number)
+	}
+	return "completed"
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+)
+
+// stateAPI is the fake API for close and reopen. Issue #1 has sub-issue #2,
+// which has sub-issue #3; #3 is closed and not in the project.
+type stateAPI struct {
+	*fakeAPI
+	calls  []string // "close ID REASON DUPLICATE" and "reopen ID"
+	fields []string // "itemID Field=value"
+}
+
+func newStateAPI() *stateAPI {
+	f := &stateAPI{fakeAPI: newFakeAPI()}
+	f.issues[3] = &api.Issue{ID: "I3", Number: 3, Title: "Untracked", State: "CLOSED", Repository: api.Repository{Owner: "acme", Name: "api"}}
+	return f
+}
+
+func (f *stateAPI) GetSubIssues(owner, repo string, number int) ([]api.SubIssue, error) {
+	var subs []api.SubIssue
+	if child := f.issues[number+1]; child != nil {
+		subs = append(subs, api.SubIssue{ID: child.ID, Number: child.Number, Title: child.Title, State: child.State, Repository: child.Repository})
+	}
+	return subs, nil
+}
+
+func (f *stateAPI) CloseIssue(issueID, reason, duplicateIssueID string) error {
+	f.calls = append(f.calls, strings.TrimSpace("close "+issueID+" "+reason+" "+duplicateIssueID))
+	return nil
+}
+
+func (f *stateAPI) ReopenIssue(issueID string) error {
+	f.calls = append(f.calls, "reopen "+issueID)
+	return nil
+}
+
+func (f *stateAPI) SetProjectItemField(projectID, itemID, fieldName, value string) error {
+	f.fields = append(f.fields, itemID+" "+fieldName+"="+value)
+	return nil
+}
+
+func TestClose_SetsClosedStatus(t *testing.T) {
+	client := newStateAPI()
+
+	out, err := runRootWith(t, client, "close", "1", "--reason", "not planned")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"close ILogin page NOT_PLANNED"}; !reflect.DeepEqual(client.calls, want) {
+		t.Errorf("Expected %v, got %v", want, client.calls)
+	}
+	if want := []string{"ITEM_1 Status=Done"}; !reflect.DeepEqual(client.fields, want) {
+		t.Errorf("Expected %v, got %v", want, client.fields)
+	}
+	for _, want := range []string{"✓ Closed issue #1: Login page", "State → closed (not planned)", "Status → Done"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestClose_DuplicateOf(t *testing.T) {
+	client := newStateAPI()
+
+	out, err := runRootWith(t, client, "close", "1", "--duplicate-of", "2", "--status", "todo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"close ILogin page DUPLICATE ISignup flow"}; !reflect.DeepEqual(client.calls, want) {
+		t.Errorf("Expected %v, got %v", want, client.calls)
+	}
+	if want := []string{"ITEM_1 Status=Todo"}; !reflect.DeepEqual(client.fields, want) {
+		t.Errorf("Expected %v, got %v", want, client.fields)
+	}
+	if !strings.Contains(out, "duplicate of #2") {
+		t.Errorf("Expected the duplicate in:\n%s", out)
+	}
+}
+
+func TestClose_Recursive(t *testing.T) {
+	client := newStateAPI()
+
+	out, err := runRootWith(t, client, "close", "1", "-r", "--yes")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"close ILogin page COMPLETED", "close ISignup flow COMPLETED"}; !reflect.DeepEqual(client.calls, want) {
+		t.Errorf("Expected %v, got %v", want, client.calls)
+	}
+	if want := []string{"ITEM_1 Status=Done", "ITEM_2 Status=Done"}; !reflect.DeepEqual(client.fields, want) {
+		t.Errorf("Expected %v, got %v", want, client.fields)
+	}
+	for _, want := range []string{"  • #2 - Signup flow", "    • #3 - Untracked (already closed, not in project)", "✓ Closed 2 issues (1 already closed, 1 not in project)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestClose_RecursiveDeclined(t *testing.T) {
+	client := newStateAPI()
+
+	out, err := runRootWithInput(t, client, "n\n", "close", "1", "--recursive")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Aborted.") || len(client.calls) != 0 || len(client.fields) != 0 {
+		t.Errorf("Expected nothing to change, got %v %v:\n%s", client.calls, client.fields, out)
+	}
+}
+
+func TestClose_DryRun(t *testing.T) {
+	client := newStateAPI()
+
+	out, err := runRootWith(t, client, "close", "1", "--dry-run")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.calls) != 0 || len(client.fields) != 0 {
+		t.Errorf("Expected no changes, got %v %v", client.calls, client.fields)
+	}
+	for _, want := range []string{"Dry run", "Issues to close (1):", "• #1 - Login page", "State → closed (completed)", "Status → Done"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestClose_AlreadyClosedSyncsStatus(t *testing.T) {
+	client := newStateAPI()
+	client.issues[2].State = "CLOSED"
+
+	out, err := runRootWith(t, client, "close", "2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("Expected the issue not to be closed again, got %v", client.calls)
+	}
+	if want := []string{"ITEM_2 Status=Done"}; !reflect.DeepEqual(client.fields, want) {
+		t.Errorf("Expected %v, got %v", want, client.fields)
+	}
+	if !strings.Contains(out, "Issue #2 was already closed") || strings.Contains(out, "State →") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	client := newStateAPI()
+
+	out, err := runRootWith(t, client, "reopen", "3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"reopen I3"}; !reflect.DeepEqual(client.calls, want) {
+		t.Errorf("Expected %v, got %v", want, client.calls)
+	}
+	if len(client.fields) != 0 {
+		t.Errorf("Expected no status for an issue outside the project, got %v", client.fields)
+	}
+	for _, want := range []string{"✓ Reopened issue #3: Untracked", "not in the project", "https://github.com/acme/api/issues/3"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+
+	client = newStateAPI()
+	client.issues[1].State = "CLOSED"
+	if _, err := runRootWith(t, client, "reopen", "1", "--status", "todo"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"ITEM_1 Status=Todo"}; !reflect.DeepEqual(client.fields, want) {
+		t.Errorf("Expected %v, got %v", want, client.fields)
+	}
+}
+
+func TestClose_Errors(t *testing.T) {
+	tests := map[string][]string{
+		`invalid --reason "wontfix"`:      {"close", "1", "--reason", "wontfix"},
+		"requires --duplicate-of":         {"close", "1", "--reason", "duplicate"},
+		"cannot be used with --reason":    {"close", "1", "--reason", "completed", "--duplicate-of", "2"},
+		"cannot be a duplicate of itself": {"close", "1", "--duplicate-of", "acme/api#1"},
+		`unknown flag: --reason`:          {"reopen", "1", "--reason", "completed"},
+	}
+	for want, args := range tests {
+		client := newStateAPI()
+		if _, err := runRootWith(t, client, args...); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%v: expected %q, got %v", args, want, err)
+		}
+		if len(client.calls) != 0 {
+			t.Errorf("%v: expected no changes, got %v", args, client.calls)
+		}
+	}
+}
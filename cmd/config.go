@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the gh-pmu configuration",
+		Long:  `Inspect and validate the layered gh-pmu configuration.`,
+	}
+
+	cmd.AddCommand(newConfigValidateCommand())
+	cmd.AddCommand(newConfigShowCommand())
+
+	return cmd
+}
+
+type configValidateOptions struct {
+	file string
+}
+
+func newConfigValidateCommand() *cobra.Command {
+	opts := &configValidateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the configuration file",
+		Long: `Validate the configuration files that apply to the current directory
+and report every problem as file:line:column.
+
+Checks for:
+  - unknown keys and values of the wrong type
+  - repositories not in owner/repo format
+  - triage queries and view filters that do not parse
+  - aliases mapping to options missing from the cached project metadata
+  - defaults, view, and triage field values that are not known aliases or options
+
+Run 'gh pmu init' again to refresh the cached metadata after changing
+fields in the web UI.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigValidate(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Validate a single config file instead of the layered configuration")
+
+	return cmd
+}
+
+func runConfigValidate(cmd *cobra.Command, opts *configValidateOptions) error {
+	var (
+		cfg   *config.Config
+		err   error
+		files []string
+	)
+
+	if opts.file != "" {
+		files = []string{opts.file}
+		cfg, err = config.Load(opts.file)
+	} else {
+		cwd, cwdErr := os.Getwd()
+		if cwdErr != nil {
+			return fmt.Errorf("failed to get current directory: %w", cwdErr)
+		}
+		cfg, err = loadConfig(cmd, cwd)
+		if cfg != nil {
+			files = cfg.Paths()
+		}
+	}
+
+	var problems []config.Problem
+	var validationErr *config.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		problems = validationErr.Problems
+	case err != nil:
+		return err
+	default:
+		problems = cfg.Check()
+	}
+
+	if len(problems) == 0 {
+		u := ui.New(cmd.OutOrStdout())
+		for _, f := range files {
+			u.Success(fmt.Sprintf("%s is valid", f))
+		}
+		if len(files) == 0 {
+			u.Success("Configuration from environment variables and flags is valid")
+		}
+		return nil
+	}
+
+	for _, p := range problems {
+		fmt.Fprintln(cmd.ErrOrStderr(), p)
+	}
+
+	return fmt.Errorf("found %d problem(s)", len(problems))
+}
+
+type configShowOptions struct {
+	origin bool
+}
+
+func newConfigShowCommand() *cobra.Command {
+	opts := &configShowOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the effective configuration",
+		Long: `Show the effective configuration for the current directory.
+
+Configuration is layered, with later layers taking precedence:
+  1. ~/.config/gh-pmu/config.yml (or $XDG_CONFIG_HOME/gh-pmu/config.yml)
+  2. .gh-pmu.yml at the git repository root
+  3. .gh-pmu.yml files in directories below the root, nearest last
+  4. GH_PMU_* environment variables
+  5. the global --owner, --project-number, --repo, and --config flags
+
+Defaults and field aliases are merged key by key; repositories, triage
+rules, views, and metadata are replaced as a whole.
+
+Use --origin to print where each value came from. Cached metadata is
+omitted from the --origin listing.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+
+			cfg, err := loadConfig(cmd, cwd)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			return runConfigShow(cmd, cfg, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.origin, "origin", false, "Show the file and line (or environment variable or flag) each value came from")
+
+	return cmd
+}
+
+func runConfigShow(cmd *cobra.Command, cfg *config.Config, opts *configShowOptions) error {
+	if !opts.origin {
+		data, err := yaml.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tORIGIN")
+	for _, o := range cfg.Origins() {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", o.Key, o.Value, o.Source)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestConfigValidateCommand_Valid(t *testing.T) {
+	cmd := NewRootCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"config", "validate", "--file", filepath.Join("..", "testdata", "config", "valid.gh-pmu.yml")})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "is valid") {
+		t.Errorf("Expected success message, got: %s", buf.String())
+	}
+}
+
+func TestConfigValidateCommand_ReportsProblems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gh-pmu.yml")
+	content := "project:\n  owner: acme\n  number: 1\nrepositories:\n  - acme/api\ndefaults:\n  status: bogus\nfields:\n  status:\n    field: Status\n    values:\n      todo: Todo\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewRootCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"config", "validate", "--file", path})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error for invalid config")
+	}
+	if !strings.Contains(buf.String(), path+":7:11:") {
+		t.Errorf("Expected file:line:column in output, got: %s", buf.String())
+	}
+}
+
+func TestRunConfigShow_Origin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gh-pmu.yml")
+	content := "project:\n  owner: acme\n  number: 1\nrepositories:\n  - acme/api\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadLayered([]string{path})
+	if err != nil {
+		t.Fatalf("LoadLayered error: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	if err := runConfigShow(cmd, cfg, &configShowOptions{origin: true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "project.owner") || !strings.Contains(buf.String(), path+":2") {
+		t.Errorf("Expected project.owner with its origin, got:\n%s", buf.String())
+	}
+}
+
+func TestRunConfigShow_YAML(t *testing.T) {
+	cfg := &config.Config{Project: config.Project{Owner: "acme", Number: 1}}
+
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	if err := runConfigShow(cmd, cfg, &configShowOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "owner: acme") {
+		t.Errorf("Expected YAML output, got:\n%s", buf.String())
+	}
+}
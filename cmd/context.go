@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// apiClient is the API used by commands. *api.Client implements it; tests
+// provide mocks of the narrower per-command interfaces instead.
+type apiClient interface {
+	listClient
+	viewClient
+	viewSaveClient
+	intakeClient
+	splitClient
+	subClient
+	createClient
+	editClient
+	moveClient
+	stateClient
+	triageClient
+	fieldClient
+	typesClient
+	issueTypeClient
+}
+
+// cmdContext holds what commands share: the resolved configuration and the
+// API client.
+//
+// It is built once by the root command's PersistentPreRunE for commands that
+// declare they need configuration (see needsConfig). Tests can inject one
+// with withCommandContext.
+type cmdContext struct {
+	cfg      *config.Config
+	projects []*config.Config // every project selected with --project; cfg is the first
+	client   apiClient
+}
+
+// configAnnotation marks commands that load configuration before running.
+// The value says how many --project selections the command accepts.
+const configAnnotation = "gh-pmu/config"
+
+const (
+	configSingleProject = "project"
+	configMultiProject  = "projects"
+)
+
+// needsConfig marks cmd as requiring the configuration and API client
+func needsConfig(cmd *cobra.Command) {
+	setConfigAnnotation(cmd, configSingleProject)
+}
+
+// needsConfigForProjects marks cmd as requiring the configuration and API
+// client, accepting several --project flags
+func needsConfigForProjects(cmd *cobra.Command) {
+	setConfigAnnotation(cmd, configMultiProject)
+}
+
+func setConfigAnnotation(cmd *cobra.Command, value string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[configAnnotation] = value
+}
+
+type cmdContextKey struct{}
+
+// withCommandContext returns ctx carrying cc
+func withCommandContext(ctx context.Context, cc *cmdContext) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, cmdContextKey{}, cc)
+}
+
+// commandContext returns the context built for cmd, building it when the
+// command runs without the root command (as in tests)
+func commandContext(cmd *cobra.Command) (*cmdContext, error) {
+	if ctx := cmd.Context(); ctx != nil {
+		if cc, ok := ctx.Value(cmdContextKey{}).(*cmdContext); ok {
+			return cc, nil
+		}
+	}
+
+	cc, err := newCommandContext(cmd)
+	if err != nil {
+		return nil, err
+	}
+	cmd.SetContext(withCommandContext(cmd.Context(), cc))
+	return cc, nil
+}
+
+// prepareCommandContext is the root command's PersistentPreRunE. It builds
+// the context for commands that need configuration, unless one was injected.
+func prepareCommandContext(cmd *cobra.Command, args []string) error {
+	if _, ok := cmd.Annotations[configAnnotation]; !ok {
+		return nil
+	}
+	_, err := commandContext(cmd)
+	return err
+}
+
+// newCommandContext loads and validates the configuration for the current
+// directory, selects the --project profiles, and creates the API client
+func newCommandContext(cmd *cobra.Command) (*cmdContext, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := loadConfig(cmd, cwd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
+	}
+
+	var projects []*config.Config
+	if cmd.Annotations[configAnnotation] == configMultiProject {
+		projects, err = selectProjects(cmd, cfg)
+	} else {
+		var selected *config.Config
+		selected, err = selectProject(cmd, cfg)
+		projects = []*config.Config{selected}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, projectCfg := range projects {
+		if err := projectCfg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
+	return &cmdContext{
+		cfg:      projects[0],
+		projects: projects,
+		client:   api.NewClientForHost(projects[0].Host),
+	}, nil
+}
+
+// defaultRepository returns the first configured repository, used when a
+// command is not given one
+func defaultRepository(cfg *config.Config) (owner, repo string, err error) {
+	if len(cfg.Repositories) == 0 {
+		return "", "", fmt.Errorf("no repository specified and none configured")
+	}
+	owner, repo, ok := strings.Cut(cfg.Repositories[0], "/")
+	if !ok || owner == "" || repo == "" {
+		return "", "", fmt.Errorf("invalid repository format in config: %s", cfg.Repositories[0])
+	}
+	return owner, repo, nil
+}
+
+// featureClient is implemented by API clients that know which optional
+// GitHub features their host supports
+type featureClient interface {
+	Supports(feature string) bool
+	Host() string
+}
+
+// subIssueMarkerNote returns a note for output when client links sub-issues
+// with "Parent: #N" body markers because the host lacks sub-issues, and ""
+// otherwise. Clients that cannot tell (such as test mocks) get no note.
+func subIssueMarkerNote(client interface{}) string {
+	fc, ok := client.(featureClient)
+	if !ok || fc.Supports(api.FeatureSubIssues) {
+		return ""
+	}
+	return fmt.Sprintf("Note: %s does not support sub-issues; links are kept as \"Parent: #N\" lines in issue bodies", fc.Host())
+}
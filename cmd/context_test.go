@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+)
+
+// fakeAPI serves a fixed project and items. Methods a test does not expect
+// to be called panic through the nil embedded apiClient.
+type fakeAPI struct {
+	apiClient
+	project *api.Project
+	items   []api.ProjectItem
+	fields  []api.ProjectField
+	issues  map[int]*api.Issue
+}
+
+func (f *fakeAPI) GetProject(owner string, number int) (*api.Project, error) {
+	return f.project, nil
+}
+
+func (f *fakeAPI) GetProjectItems(projectID string, filter *api.ProjectItemsFilter) ([]api.ProjectItem, error) {
+	var items []api.ProjectItem
+	for _, item := range f.items {
+		if filter.Matches(item) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (f *fakeAPI) GetProjectFields(projectID string) ([]api.ProjectField, error) {
+	return f.fields, nil
+}
+
+func (f *fakeAPI) GetSubIssues(owner, repo string, number int) ([]api.SubIssue, error) {
+	return nil, nil
+}
+
+func (f *fakeAPI) GetParentIssue(owner, repo string, number int) (*api.Issue, error) {
+	return nil, nil
+}
+
+func (f *fakeAPI) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	return f.issues[number], nil
+}
+
+func newFakeAPI() *fakeAPI {
+	issue := func(number int, title string) *api.Issue {
+		return &api.Issue{
+			ID: "I" + title, Number: number, Title: title, State: "OPEN",
+			Repository: api.Repository{Owner: "acme", Name: "api"},
+		}
+	}
+	return &fakeAPI{
+		project: &api.Project{ID: "PVT_1", URL: "https://github.com/orgs/acme/projects/1"},
+		items: []api.ProjectItem{
+			{ID: "ITEM_1", Issue: issue(1, "Login page"), FieldValues: []api.FieldValue{{Field: "Status", Value: "Todo"}}},
+			{ID: "ITEM_2", Issue: issue(2, "Signup flow"), FieldValues: []api.FieldValue{{Field: "Status", Value: "Done"}}},
+		},
+		fields: []api.ProjectField{
+			{Name: "Status", DataType: "SINGLE_SELECT", Options: []api.FieldOption{{Name: "Todo"}, {Name: "In progress"}, {Name: "Done"}}},
+			{Name: "Priority", DataType: "SINGLE_SELECT", Options: []api.FieldOption{{Name: "P0"}, {Name: "P1"}, {Name: "P2"}}},
+		},
+		issues: map[int]*api.Issue{1: issue(1, "Login page"), 2: issue(2, "Signup flow")},
+	}
+}
+
+func newTestCommandContext(client apiClient) *cmdContext {
+	cfg := &config.Config{
+		Project:      config.Project{Owner: "acme", Number: 1},
+		Repositories: []string{"acme/api"},
+		Fields: map[string]config.Field{
+			"status": {Field: "Status", Values: map[string]string{"todo": "Todo", "done": "Done"}},
+		},
+	}
+	return &cmdContext{
+		cfg:      cfg,
+		projects: []*config.Config{cfg},
+		client:   client,
+	}
+}
+
+func TestRootCommand_UsesInjectedContext(t *testing.T) {
+	root := NewRootCommand()
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetContext(withCommandContext(context.Background(), newTestCommandContext(newFakeAPI())))
+	root.SetArgs([]string{"list", "--status", "done"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Signup flow") || strings.Contains(output, "Login page") {
+		t.Errorf("Expected only the done issue, got:\n%s", output)
+	}
+}
+
+func TestRootCommand_BuildsContextBeforeRun(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	root := NewRootCommand()
+	root.SetOut(new(bytes.Buffer))
+	root.SetErr(new(bytes.Buffer))
+	root.SetArgs([]string{"view", "1"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "failed to load configuration") {
+		t.Errorf("Expected configuration error from the shared context, got %v", err)
+	}
+}
+
+func TestNewCommandContext_FromConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	content := "project:\n  owner: acme\n  number: 4\nrepositories:\n  - acme/web\n  - acme/api\n"
+	if err := os.WriteFile(filepath.Join(dir, ".gh-pmu.yml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	cc, err := newCommandContext(newListCommand())
+	if err != nil {
+		t.Fatalf("newCommandContext error: %v", err)
+	}
+
+	if cc.cfg.Project.Number != 4 {
+		t.Errorf("Expected project 4, got %d", cc.cfg.Project.Number)
+	}
+	if owner, repo, _ := defaultRepository(cc.cfg); owner != "acme" || repo != "web" {
+		t.Errorf("Expected default repository acme/web, got %s/%s", owner, repo)
+	}
+	if cc.client == nil {
+		t.Errorf("Expected the client to be set")
+	}
+}
+
+func TestNewCommandContext_ClientUsesConfiguredHost(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GH_HOST", "")
+	dir := t.TempDir()
+	content := "host: ghe.example.com\nproject:\n  owner: acme\n  number: 4\nrepositories:\n  - acme/api\n"
+	if err := os.WriteFile(filepath.Join(dir, ".gh-pmu.yml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	cc, err := newCommandContext(newListCommand())
+	if err != nil {
+		t.Fatalf("newCommandContext error: %v", err)
+	}
+
+	client, ok := cc.client.(*api.Client)
+	if !ok || client.Host() != "ghe.example.com" {
+		t.Errorf("Expected a client for ghe.example.com, got %v", cc.client)
+	}
+}
+
+func TestPrepareCommandContext_SkipsCommandsWithoutConfig(t *testing.T) {
+	dir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(originalDir) }()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := prepareCommandContext(newTemplateCommand(), nil); err != nil {
+		t.Errorf("Expected no configuration to be loaded, got %v", err)
+	}
+}
+
+func TestDefaultRepository(t *testing.T) {
+	owner, repo, err := defaultRepository(&config.Config{Repositories: []string{"acme/api", "acme/web"}})
+	if err != nil || owner != "acme" || repo != "api" {
+		t.Errorf("Expected acme/api, got %s/%s (%v)", owner, repo, err)
+	}
+
+	if _, _, err := defaultRepository(&config.Config{}); err == nil {
+		t.Error("Expected error with no repositories")
+	}
+}
+
+func TestRunViewWithDeps_DefaultsToConfiguredRepository(t *testing.T) {
+	client := &viewFake{fakeAPI: newFakeAPI()}
+	cmd := newViewCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	if err := runViewWithDeps(cmd, []string{"2"}, &viewOptions{}, newTestCommandContext(nil).cfg, client); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if client.repo != "acme/api" {
+		t.Errorf("Expected issue to be fetched from acme/api, got %q", client.repo)
+	}
+}
+
+// viewFake records the repository an issue is fetched from
+type viewFake struct {
+	*fakeAPI
+	repo string
+}
+
+func (f *viewFake) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	f.repo = owner + "/" + repo
+	return f.fakeAPI.GetIssue(owner, repo, number)
+}
+
+func (f *viewFake) GetParentIssue(owner, repo string, number int) (*api.Issue, error) {
+	return nil, nil
+}
+
+func (f *viewFake) GetIssueComments(owner, repo string, number int) ([]api.Comment, error) {
+	return nil, nil
+}
+
+// hostFake reports which features its host supports
+type hostFake struct {
+	*fakeAPI
+	subIssues bool
+}
+
+func (f *hostFake) Supports(feature string) bool {
+	return feature != api.FeatureSubIssues || f.subIssues
+}
+func (f *hostFake) Host() string { return "ghe.example.com" }
+
+func TestSubIssueMarkerNote(t *testing.T) {
+	if note := subIssueMarkerNote(newFakeAPI()); note != "" {
+		t.Errorf("Expected no note for a client that cannot tell, got %q", note)
+	}
+	if note := subIssueMarkerNote(&hostFake{fakeAPI: newFakeAPI(), subIssues: true}); note != "" {
+		t.Errorf("Expected no note when sub-issues are supported, got %q", note)
+	}
+	note := subIssueMarkerNote(&hostFake{fakeAPI: newFakeAPI()})
+	if !strings.Contains(note, "ghe.example.com does not support sub-issues") {
+		t.Errorf("Unexpected note: %q", note)
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -9,7 +8,6 @@ import (
 	"github.com/scooter-indie/gh-pmu/internal/api"
 	"github.com/scooter-indie/gh-pmu/internal/config"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 type createOptions struct {
@@ -20,9 +18,14 @@ type createOptions struct {
 	labels      []string
 	assignees   []string
 	milestone   string
+	issueType   string
 	repo        string
 	fromFile    string
+	template    string
+	inputs      []string
 	interactive bool
+	dryRun      bool
+	results     string
 }
 
 func newCreateCommand() *cobra.Command {
@@ -34,54 +37,121 @@ func newCreateCommand() *cobra.Command {
 		Long: `Create a new issue and add it to the configured project.
 
 When --title is provided, creates the issue non-interactively.
-Otherwise, opens an editor for composing the issue.
+With --interactive, prompts for the repository, title, and body (written in
+$EDITOR from an issue template), then offers menus of the project's status
+and priority options and the repository's labels, assignees, and milestones.
+Flags given alongside --interactive become the defaults. A summary is shown
+before the issue is created.
+
+With --template, the issue is created from one of the repository's issue
+forms or Markdown templates in .github/ISSUE_TEMPLATE, named by file name or
+by its name, or from a local template file. The template's labels, assignees,
+issue type, and title prefix are applied. Each form input, dropdown, and
+checkbox is prompted for, unless answers are given with --input id=value;
+inputs not given then keep their defaults. The body is rendered as GitHub
+renders a submitted form.
+
+With --from-file, issues are created from a YAML file of one or more
+documents, a JSON object or array, or a CSV file with a header row. Each
+issue may set its repo, project fields (by name under fields:, or as extra
+CSV columns), a parent, and the issues it is blocked_by, referring to
+existing issues or to other issues in the file by their id. Flags apply to
+every issue. Everything is validated before the first issue is created, and
+--dry-run shows the plan without creating anything. Created issues are
+recorded in a results file, so rerunning an interrupted import skips them.
 
 The issue is automatically added to the configured project and
-any specified field values (status, priority) are set.`,
+any specified field values (status, priority) are set.
+
+Use the global --project flag more than once to add the issue to several
+named projects; aliases and defaults are resolved for each project:
+
+  gh pmu create -t "Plan Q3" -s in_progress --project team --project roadmap`,
+		Example: `  # Fill in the bug report form at the prompts
+  gh pmu create --template bug_report
+
+  # Answer the form without prompts
+  gh pmu create --template bug_report --title "Crash on save" \
+    --input what-happened="The editor crashes" --input version=1.0.3 --input terms=1
+
+  # Preview, then create, the issues in a file
+  gh pmu create --from-file plan.yml --dry-run
+  gh pmu create --from-file plan.yml`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCreate(cmd, opts)
 		},
 	}
+	needsConfigForProjects(cmd)
 
 	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Issue title (required for non-interactive mode)")
 	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Issue body")
 	cmd.Flags().StringVarP(&opts.status, "status", "s", "", "Set project status field (e.g., backlog, in_progress)")
 	cmd.Flags().StringVarP(&opts.priority, "priority", "p", "", "Set project priority field (e.g., p0, p1, p2)")
 	cmd.Flags().StringArrayVarP(&opts.labels, "label", "l", nil, "Add labels (can be specified multiple times)")
-	cmd.Flags().StringArrayVarP(&opts.assignees, "assignee", "a", nil, "Assign users (can be specified multiple times)")
+	cmd.Flags().StringArrayVarP(&opts.assignees, "assignee", "a", nil, "Assign users by `login`, @me, or @org/team (can be specified multiple times)")
 	cmd.Flags().StringVarP(&opts.milestone, "milestone", "m", "", "Set milestone (title or number)")
+	cmd.Flags().StringVar(&opts.issueType, "type", "", "Set the issue type (e.g., Bug, Feature, Task)")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Target repository (owner/repo format)")
-	cmd.Flags().StringVarP(&opts.fromFile, "from-file", "f", "", "Create issue from YAML/JSON file")
+	cmd.Flags().StringVarP(&opts.fromFile, "from-file", "f", "", "Create issues from a YAML, JSON, or CSV `file`")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the issues --from-file would create without creating them")
+	cmd.Flags().StringVar(&opts.results, "results", "", "Record the issues created from --from-file in `file` (default: <file>.results.json for several issues)")
+	cmd.Flags().StringVar(&opts.template, "template", "", "Create issue from an issue form or template in .github/ISSUE_TEMPLATE, or a local template `file`")
+	cmd.Flags().StringArrayVar(&opts.inputs, "input", nil, "Answer an issue form input as `id=value` (can be specified multiple times)")
 	cmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", false, "Use interactive mode with prompts")
 
 	return cmd
 }
 
-// issueFromFile represents an issue definition in a YAML/JSON file
+// issueFromFile represents an issue definition in a YAML, JSON, or CSV file
 type issueFromFile struct {
-	Title     string   `json:"title" yaml:"title"`
-	Body      string   `json:"body" yaml:"body"`
-	Labels    []string `json:"labels" yaml:"labels"`
-	Assignees []string `json:"assignees" yaml:"assignees"`
-	Milestone string   `json:"milestone" yaml:"milestone"`
-	Status    string   `json:"status" yaml:"status"`
-	Priority  string   `json:"priority" yaml:"priority"`
+	// ID names the issue within the file, for parent and blocked_by references
+	ID        string            `json:"id" yaml:"id"`
+	Repo      string            `json:"repo" yaml:"repo"`
+	Title     string            `json:"title" yaml:"title"`
+	Body      string            `json:"body" yaml:"body"`
+	Labels    []string          `json:"labels" yaml:"labels"`
+	Assignees []string          `json:"assignees" yaml:"assignees"`
+	Milestone string            `json:"milestone" yaml:"milestone"`
+	Status    string            `json:"status" yaml:"status"`
+	Priority  string            `json:"priority" yaml:"priority"`
+	Type      string            `json:"type" yaml:"type"`
+	Fields    map[string]string `json:"fields" yaml:"fields"`
+	Parent    string            `json:"parent" yaml:"parent"`
+	BlockedBy []string          `json:"blocked_by" yaml:"blocked_by"`
+}
+
+// createClient is the part of the API client used by create
+type createClient interface {
+	CreateIssueWithOptions(owner, repo, title, body string, labels, assignees []string, milestone string) (*api.Issue, error)
+	GetRepositoryMetadata(owner, repo string) (*api.RepositoryMetadata, error)
+	GetIssueTemplates(owner, repo string) ([]api.RepositoryFile, error)
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	AddSubIssue(parentIssueID, childIssueID string) error
+	AddBlockedBy(issueID, blockingIssueID string) error
+	issueTypeClient
+	createProjectClient
+	userClient
 }
 
 func runCreate(cmd *cobra.Command, opts *createOptions) error {
-	// Load configuration
-	cwd, err := os.Getwd()
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
+	return runCreateWithDeps(cmd, opts, cc.projects, cc.client)
+}
 
-	cfg, err := config.LoadFromDirectory(cwd)
-	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
+// runCreateWithDeps creates the issue and adds it to each of the selected
+// projects; the first project's configuration supplies the defaults
+func runCreateWithDeps(cmd *cobra.Command, opts *createOptions, projects []*config.Config, client createClient) error {
+	cfg := projects[0]
 
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+	// Handle interactive mode, which picks the repository itself
+	if opts.interactive {
+		if opts.fromFile != "" || opts.template != "" {
+			return fmt.Errorf("cannot use --interactive with --from-file or --template")
+		}
+		return runCreateInteractive(cmd, opts, projects, client)
 	}
 
 	// Determine repository
@@ -95,24 +165,30 @@ func runCreate(cmd *cobra.Command, opts *createOptions) error {
 		owner, repo = repoParts[0], repoParts[1]
 	} else {
 		// Use config
-		if len(cfg.Repositories) == 0 {
-			return fmt.Errorf("no repository configured")
+		var err error
+		if owner, repo, err = defaultRepository(cfg); err != nil {
+			return err
 		}
-		repoParts := strings.Split(cfg.Repositories[0], "/")
-		if len(repoParts) != 2 {
-			return fmt.Errorf("invalid repository format in config: %s", cfg.Repositories[0])
+	}
+
+	if opts.fromFile == "" && (opts.dryRun || opts.results != "") {
+		return fmt.Errorf("--dry-run and --results require --from-file")
+	}
+
+	// Handle --template
+	if opts.template != "" {
+		if opts.fromFile != "" {
+			return fmt.Errorf("cannot use --template with --from-file")
 		}
-		owner, repo = repoParts[0], repoParts[1]
+		return runCreateFromTemplate(cmd, opts, projects, client, owner, repo)
+	}
+	if len(opts.inputs) > 0 {
+		return fmt.Errorf("--input requires --template")
 	}
 
 	// Handle --from-file
 	if opts.fromFile != "" {
-		return runCreateFromFile(cmd, opts, cfg, owner, repo)
-	}
-
-	// Handle interactive mode
-	if opts.interactive {
-		return fmt.Errorf("interactive mode not yet implemented")
+		return runCreateFromFile(cmd, opts, projects, client, owner, repo)
 	}
 
 	// Handle non-interactive mode
@@ -127,52 +203,26 @@ func runCreate(cmd *cobra.Command, opts *createOptions) error {
 	labels := append([]string{}, cfg.Defaults.Labels...)
 	labels = append(labels, opts.labels...)
 
-	// Create API client
-	client := api.NewClient()
-
-	// Create the issue with extended options
-	issue, err := client.CreateIssueWithOptions(owner, repo, title, body, labels, opts.assignees, opts.milestone)
+	// Resolve the assignees and issue type before creating anything
+	assignees, err := resolveAssignees(client, opts.assignees)
 	if err != nil {
-		return fmt.Errorf("failed to create issue: %w", err)
+		return err
 	}
-
-	// Add issue to project
-	project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
+	issueType, err := lookupIssueType(client, owner, opts.issueType)
 	if err != nil {
-		return fmt.Errorf("failed to get project: %w", err)
+		return err
 	}
 
-	itemID, err := client.AddIssueToProject(project.ID, issue.ID)
+	// Create the issue with extended options
+	issue, err := client.CreateIssueWithOptions(owner, repo, title, body, labels, assignees, opts.milestone)
 	if err != nil {
-		return fmt.Errorf("failed to add issue to project: %w", err)
-	}
-
-	// Set project field values
-	if opts.status != "" {
-		statusValue := cfg.ResolveFieldValue("status", opts.status)
-		if err := client.SetProjectItemField(project.ID, itemID, "Status", statusValue); err != nil {
-			// Non-fatal - warn but continue
-			fmt.Fprintf(os.Stderr, "Warning: failed to set status: %v\n", err)
-		}
-	} else if cfg.Defaults.Status != "" {
-		// Apply default status from config
-		statusValue := cfg.ResolveFieldValue("status", cfg.Defaults.Status)
-		if err := client.SetProjectItemField(project.ID, itemID, "Status", statusValue); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to set default status: %v\n", err)
-		}
+		return fmt.Errorf("failed to create issue: %w", err)
 	}
+	setCreatedIssueType(client, issue, issueType)
 
-	if opts.priority != "" {
-		priorityValue := cfg.ResolveFieldValue("priority", opts.priority)
-		if err := client.SetProjectItemField(project.ID, itemID, "Priority", priorityValue); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to set priority: %v\n", err)
-		}
-	} else if cfg.Defaults.Priority != "" {
-		// Apply default priority from config
-		priorityValue := cfg.ResolveFieldValue("priority", cfg.Defaults.Priority)
-		if err := client.SetProjectItemField(project.ID, itemID, "Priority", priorityValue); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to set default priority: %v\n", err)
-		}
+	// Add issue to each project and set its field values
+	if err := addIssueToProjects(client, issue.ID, projects, opts.status, opts.priority); err != nil {
+		return err
 	}
 
 	// Output the result
@@ -182,106 +232,121 @@ func runCreate(cmd *cobra.Command, opts *createOptions) error {
 	return nil
 }
 
-func runCreateFromFile(cmd *cobra.Command, opts *createOptions, cfg *config.Config, owner, repo string) error {
-	// Read the file
-	data, err := os.ReadFile(opts.fromFile)
+// lookupIssueType resolves the issue type named by --type, or returns nil
+// when none was given
+func lookupIssueType(client issueTypeClient, owner, name string) (*api.IssueType, error) {
+	if name == "" {
+		return nil, nil
+	}
+	issueType, err := resolveIssueType(client, owner, name)
 	if err != nil {
-		return fmt.Errorf("failed to read file %s: %w", opts.fromFile, err)
+		return nil, fmt.Errorf("--type: %w", err)
 	}
+	return issueType, nil
+}
 
-	// Parse the file (try YAML first, then JSON)
-	var issueData issueFromFile
-	if strings.HasSuffix(opts.fromFile, ".json") {
-		if err := json.Unmarshal(data, &issueData); err != nil {
-			return fmt.Errorf("failed to parse JSON file: %w", err)
-		}
-	} else {
-		if err := yaml.Unmarshal(data, &issueData); err != nil {
-			return fmt.Errorf("failed to parse YAML file: %w", err)
-		}
+// setCreatedIssueType sets the type of a newly created issue. Failures are
+// reported as warnings, like project field errors.
+func setCreatedIssueType(client issueTypeClient, issue *api.Issue, issueType *api.IssueType) {
+	if issueType == nil {
+		return
 	}
-
-	if issueData.Title == "" {
-		return fmt.Errorf("title is required in file")
+	if err := client.SetIssueType(issue.ID, issueType.ID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to set issue type: %v\n", err)
+		return
 	}
+	issue.Type = issueType.Name
+}
 
-	// Merge with command line options (command line takes precedence)
-	title := issueData.Title
-	body := issueData.Body
-	if opts.body != "" {
-		body = opts.body
-	}
+// createProjectClient is the part of the API client used to add a new issue
+// to projects
+type createProjectClient interface {
+	GetProject(owner string, number int) (*api.Project, error)
+	AddIssueToProject(projectID, issueID string) (string, error)
+	SetProjectItemField(projectID, itemID, fieldName, value string) error
+}
 
-	labels := append([]string{}, cfg.Defaults.Labels...)
-	labels = append(labels, issueData.Labels...)
-	labels = append(labels, opts.labels...)
+// addIssueToProjects adds an issue to each project and sets its status and
+// priority, resolving aliases and defaults with that project's configuration.
+// Field errors are reported as warnings.
+func addIssueToProjects(client createProjectClient, issueID string, projects []*config.Config, status, priority string) error {
+	return addIssueToProjectsWithFields(client, issueID, projects, status, priority, nil)
+}
 
-	assignees := append([]string{}, issueData.Assignees...)
-	assignees = append(assignees, opts.assignees...)
+// addIssueToProjectsWithFields is addIssueToProjects, also setting the
+// project fields in fields, keyed by field name or configured field key
+func addIssueToProjectsWithFields(client createProjectClient, issueID string, projects []*config.Config, status, priority string, fields map[string]string) error {
+	for _, cfg := range projects {
+		project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
+		if err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
 
-	milestone := issueData.Milestone
-	if opts.milestone != "" {
-		milestone = opts.milestone
-	}
+		itemID, err := client.AddIssueToProject(project.ID, issueID)
+		if err != nil {
+			return fmt.Errorf("failed to add issue to project: %w", err)
+		}
 
-	status := issueData.Status
-	if opts.status != "" {
-		status = opts.status
-	}
+		// Set project field values
+		if status != "" {
+			statusValue := cfg.ResolveFieldValue("status", status)
+			if err := client.SetProjectItemField(project.ID, itemID, "Status", statusValue); err != nil {
+				// Non-fatal - warn but continue
+				fmt.Fprintf(os.Stderr, "Warning: failed to set status: %v\n", err)
+			}
+		} else if cfg.Defaults.Status != "" {
+			// Apply default status from config
+			statusValue := cfg.ResolveFieldValue("status", cfg.Defaults.Status)
+			if err := client.SetProjectItemField(project.ID, itemID, "Status", statusValue); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to set default status: %v\n", err)
+			}
+		}
 
-	priority := issueData.Priority
-	if opts.priority != "" {
-		priority = opts.priority
-	}
+		if priority != "" {
+			priorityValue := cfg.ResolveFieldValue("priority", priority)
+			if err := client.SetProjectItemField(project.ID, itemID, "Priority", priorityValue); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to set priority: %v\n", err)
+			}
+		} else if cfg.Defaults.Priority != "" {
+			// Apply default priority from config
+			priorityValue := cfg.ResolveFieldValue("priority", cfg.Defaults.Priority)
+			if err := client.SetProjectItemField(project.ID, itemID, "Priority", priorityValue); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to set default priority: %v\n", err)
+			}
+		}
 
-	// Create API client
-	client := api.NewClient()
+		for _, key := range sortedKeys(fields) {
+			fieldName := cfg.GetFieldName(key)
+			if err := client.SetProjectItemField(project.ID, itemID, fieldName, cfg.ResolveFieldValue(key, fields[key])); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to set %s: %v\n", fieldName, err)
+			}
+		}
 
-	// Create the issue
-	issue, err := client.CreateIssueWithOptions(owner, repo, title, body, labels, assignees, milestone)
-	if err != nil {
-		return fmt.Errorf("failed to create issue: %w", err)
+		if len(projects) > 1 {
+			fmt.Printf("Added to project %s (%s #%d)\n", projectLabel(cfg), cfg.Project.Owner, cfg.Project.Number)
+		}
 	}
 
-	// Add issue to project
-	project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
-	if err != nil {
-		return fmt.Errorf("failed to get project: %w", err)
-	}
+	return nil
+}
 
-	itemID, err := client.AddIssueToProject(project.ID, issue.ID)
-	if err != nil {
-		return fmt.Errorf("failed to add issue to project: %w", err)
+// projectLabel returns the profile name or project name used in output
+func projectLabel(cfg *config.Config) string {
+	if name := cfg.ProfileName(); name != "" {
+		return name
 	}
-
-	// Set project field values
-	if status != "" {
-		statusValue := cfg.ResolveFieldValue("status", status)
-		if err := client.SetProjectItemField(project.ID, itemID, "Status", statusValue); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to set status: %v\n", err)
-		}
-	} else if cfg.Defaults.Status != "" {
-		statusValue := cfg.ResolveFieldValue("status", cfg.Defaults.Status)
-		if err := client.SetProjectItemField(project.ID, itemID, "Status", statusValue); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to set default status: %v\n", err)
-		}
+	if cfg.Project.Name != "" {
+		return cfg.Project.Name
 	}
+	return "default"
+}
 
-	if priority != "" {
-		priorityValue := cfg.ResolveFieldValue("priority", priority)
-		if err := client.SetProjectItemField(project.ID, itemID, "Priority", priorityValue); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to set priority: %v\n", err)
-		}
-	} else if cfg.Defaults.Priority != "" {
-		priorityValue := cfg.ResolveFieldValue("priority", cfg.Defaults.Priority)
-		if err := client.SetProjectItemField(project.ID, itemID, "Priority", priorityValue); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to set default priority: %v\n", err)
-		}
+// runCreateFromFile creates the issues defined in --from-file, which may hold
+// several YAML documents, a JSON array, or CSV rows
+func runCreateFromFile(cmd *cobra.Command, opts *createOptions, projects []*config.Config, client createClient, owner, repo string) error {
+	issues, err := readIssueFile(opts.fromFile)
+	if err != nil {
+		return err
 	}
-
-	// Output the result
-	fmt.Printf("Created issue #%d: %s\n", issue.Number, issue.Title)
-	fmt.Printf("%s\n", issue.URL)
-
-	return nil
+	return runCreateRows(cmd, opts, projects, client, issues, owner, repo)
 }
@@ -0,0 +1,648 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// readIssueFile reads the issues defined in path: CSV with a header row when
+// it ends in .csv, a JSON object or array when it ends in .json, and
+// otherwise YAML documents, each an issue or a list of issues
+func readIssueFile(path string) ([]issueFromFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	var issues []issueFromFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		issues, err = parseIssueCSV(data)
+	case ".json":
+		issues, err = parseIssueJSON(data)
+	default:
+		issues, err = parseIssueYAML(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("no issues found in %s", path)
+	}
+	return issues, nil
+}
+
+func parseIssueJSON(data []byte) ([]issueFromFile, error) {
+	var issues []issueFromFile
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON file: %w", err)
+		}
+		return issues, nil
+	}
+
+	var issue issueFromFile
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON file: %w", err)
+	}
+	return []issueFromFile{issue}, nil
+}
+
+func parseIssueYAML(data []byte) ([]issueFromFile, error) {
+	var issues []issueFromFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for doc := 1; ; doc++ {
+		var node yaml.Node
+		if err := dec.Decode(&node); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML file: %w", err)
+		}
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+			continue
+		}
+
+		if node.Content[0].Kind == yaml.SequenceNode {
+			var list []issueFromFile
+			if err := node.Decode(&list); err != nil {
+				return nil, fmt.Errorf("failed to parse YAML document %d: %w", doc, err)
+			}
+			issues = append(issues, list...)
+			continue
+		}
+		var issue issueFromFile
+		if err := node.Decode(&issue); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML document %d: %w", doc, err)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// parseIssueCSV reads one issue per row. Columns are named by the header
+// row; columns that are not issue keys set project fields, and list columns
+// such as labels separate their values with commas.
+func parseIssueCSV(data []byte) ([]issueFromFile, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	var issues []issueFromFile
+	for _, record := range records[1:] {
+		var issue issueFromFile
+		empty := true
+		for i, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			empty = false
+			column := strings.TrimSpace(header[i])
+
+			switch strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(column)) {
+			case "id":
+				issue.ID = cell
+			case "repo", "repository":
+				issue.Repo = cell
+			case "title":
+				issue.Title = cell
+			case "body":
+				issue.Body = cell
+			case "labels", "label":
+				issue.Labels = splitList(cell)
+			case "assignees", "assignee":
+				issue.Assignees = splitList(cell)
+			case "milestone":
+				issue.Milestone = cell
+			case "status":
+				issue.Status = cell
+			case "priority":
+				issue.Priority = cell
+			case "type":
+				issue.Type = cell
+			case "parent":
+				issue.Parent = cell
+			case "blocked_by":
+				issue.BlockedBy = splitList(cell)
+			default:
+				if issue.Fields == nil {
+					issue.Fields = make(map[string]string)
+				}
+				issue.Fields[column] = cell
+			}
+		}
+		if !empty {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// splitList splits a comma-separated cell into its trimmed values
+func splitList(s string) []string {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// issueRef refers to the parent or a blocker of an issue: another row of the
+// file, or an existing issue
+type issueRef struct {
+	text   string
+	row    int // index of the referenced row, or -1 for an existing issue
+	owner  string
+	repo   string
+	number int
+}
+
+// importRow is an issue of the file, with flags merged in and references
+// resolved
+type importRow struct {
+	index     int
+	key       string // the row's id, or "row N"
+	owner     string
+	repo      string
+	title     string
+	body      string
+	labels    []string
+	assignees []string
+	milestone string
+	status    string
+	priority  string
+	issueType *api.IssueType
+	fields    map[string]string
+	parent    *issueRef
+	blockedBy []issueRef
+}
+
+// planImport validates every issue of the file, merging in the flags, which
+// apply to every issue and take precedence, before anything is created. All
+// problems are reported together.
+func planImport(issues []issueFromFile, opts *createOptions, cfg *config.Config, client createClient, owner, repo string) ([]*importRow, error) {
+	ids := make(map[string]int)
+	for i, issue := range issues {
+		if issue.ID == "" {
+			continue
+		}
+		key := strings.ToLower(issue.ID)
+		if first, ok := ids[key]; ok {
+			return nil, fmt.Errorf("row %d: id %q is already used by row %d", i+1, issue.ID, first+1)
+		}
+		ids[key] = i
+	}
+
+	var problems []string
+	rows := make([]*importRow, len(issues))
+	for i, issue := range issues {
+		row, err := planImportRow(i, issue, ids, opts, cfg, client, owner, repo)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", i+1, err))
+			continue
+		}
+		rows[i] = row
+	}
+	if len(problems) == 0 {
+		if err := checkParentCycles(rows); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "\n"))
+	}
+	return rows, nil
+}
+
+func planImportRow(i int, issue issueFromFile, ids map[string]int, opts *createOptions, cfg *config.Config, client createClient, owner, repo string) (*importRow, error) {
+	row := &importRow{
+		index:     i,
+		key:       issue.ID,
+		owner:     owner,
+		repo:      repo,
+		title:     issue.Title,
+		body:      issue.Body,
+		milestone: issue.Milestone,
+		status:    issue.Status,
+		priority:  issue.Priority,
+		fields:    issue.Fields,
+	}
+	if row.key == "" {
+		row.key = fmt.Sprintf("row %d", i+1)
+	}
+	if row.title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if issue.Repo != "" {
+		row.owner, row.repo = splitRepository(issue.Repo)
+		if row.owner == "" || row.repo == "" || strings.Contains(row.repo, "/") {
+			return nil, fmt.Errorf("invalid repo %q: expected owner/repo", issue.Repo)
+		}
+	}
+
+	if opts.body != "" {
+		row.body = opts.body
+	}
+	if opts.milestone != "" {
+		row.milestone = opts.milestone
+	}
+	if opts.status != "" {
+		row.status = opts.status
+	}
+	if opts.priority != "" {
+		row.priority = opts.priority
+	}
+	for _, label := range append(append(append([]string{}, cfg.Defaults.Labels...), issue.Labels...), opts.labels...) {
+		if !containsString(row.labels, label) {
+			row.labels = append(row.labels, label)
+		}
+	}
+
+	assignees, err := resolveAssignees(client, append(append([]string{}, issue.Assignees...), opts.assignees...))
+	if err != nil {
+		return nil, err
+	}
+	row.assignees = assignees
+
+	typeName := issue.Type
+	if opts.issueType != "" {
+		typeName = opts.issueType
+	}
+	if row.issueType, err = lookupIssueType(client, row.owner, typeName); err != nil {
+		return nil, err
+	}
+
+	if issue.Parent != "" {
+		ref, err := resolveIssueRef(issue.Parent, ids, row.owner, row.repo)
+		if err != nil {
+			return nil, fmt.Errorf("parent: %w", err)
+		}
+		if ref.row == i {
+			return nil, fmt.Errorf("parent: an issue cannot be its own parent")
+		}
+		row.parent = &ref
+	}
+	for _, blocker := range issue.BlockedBy {
+		ref, err := resolveIssueRef(blocker, ids, row.owner, row.repo)
+		if err != nil {
+			return nil, fmt.Errorf("blocked_by: %w", err)
+		}
+		if ref.row == i {
+			return nil, fmt.Errorf("blocked_by: an issue cannot block itself")
+		}
+		row.blockedBy = append(row.blockedBy, ref)
+	}
+
+	return row, nil
+}
+
+// resolveIssueRef resolves a reference to a row id or an existing issue;
+// issue numbers without a repository are in owner/repo
+func resolveIssueRef(text string, ids map[string]int, owner, repo string) (issueRef, error) {
+	if row, ok := ids[strings.ToLower(text)]; ok {
+		return issueRef{text: text, row: row}, nil
+	}
+	refOwner, refRepo, number, err := parseIssueReference(text)
+	if err != nil {
+		return issueRef{}, fmt.Errorf("%q is neither a row id nor an issue reference", text)
+	}
+	if refOwner == "" {
+		refOwner, refRepo = owner, repo
+	}
+	return issueRef{text: text, row: -1, owner: refOwner, repo: refRepo, number: number}, nil
+}
+
+// checkParentCycles reports rows that are, through their parents, their own
+// ancestors
+func checkParentCycles(rows []*importRow) error {
+	for _, row := range rows {
+		seen := map[int]bool{row.index: true}
+		for parent := row.parent; parent != nil && parent.row >= 0; parent = rows[parent.row].parent {
+			if seen[parent.row] {
+				return fmt.Errorf("row %d: parent references form a cycle", row.index+1)
+			}
+			seen[parent.row] = true
+		}
+	}
+	return nil
+}
+
+// importResults records the issues created from a file, so a rerun of the
+// import skips them
+type importResults struct {
+	Source string          `json:"source"`
+	Issues []*importResult `json:"issues"`
+
+	path string
+}
+
+// importResult records what was done for one row of the file
+type importResult struct {
+	Key     string `json:"key"`
+	Title   string `json:"title"`
+	Repo    string `json:"repo"`
+	Number  int    `json:"number"`
+	URL     string `json:"url"`
+	IssueID string `json:"issue_id"`
+
+	InProject bool     `json:"in_project"`
+	Parent    bool     `json:"parent_linked,omitempty"`
+	BlockedBy []string `json:"blocked_by_linked,omitempty"`
+}
+
+// resultsPath returns the results file of an import: --results, or for files
+// with several issues, the file's name with a .results.json extension
+func resultsPath(opts *createOptions, issues int) string {
+	if opts.results != "" || issues < 2 {
+		return opts.results
+	}
+	return strings.TrimSuffix(opts.fromFile, filepath.Ext(opts.fromFile)) + ".results.json"
+}
+
+// loadImportResults reads the results file at path; a missing file, or an
+// empty path, holds no results
+func loadImportResults(path, source string) (*importResults, error) {
+	results := &importResults{Source: source, path: path}
+	if path == "" {
+		return results, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return results, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results file: %w", err)
+	}
+	if err := json.Unmarshal(data, results); err != nil {
+		return nil, fmt.Errorf("invalid results file %s: %w", path, err)
+	}
+	return results, nil
+}
+
+// save writes the results file, if there is one
+func (r *importResults) save() error {
+	if r.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	return nil
+}
+
+// find returns the result recorded for row, checking that it is the same
+// issue
+func (r *importResults) find(row *importRow) (*importResult, error) {
+	for _, result := range r.Issues {
+		if result.Key != row.key {
+			continue
+		}
+		if result.Title != row.title {
+			return nil, fmt.Errorf("%s records %s as %q, but the file now has %q; remove the results file to import again", r.path, row.key, result.Title, row.title)
+		}
+		return result, nil
+	}
+	return nil, nil
+}
+
+// runCreateRows creates the file's issues, resuming from the results file,
+// and then links parents and dependencies
+func runCreateRows(cmd *cobra.Command, opts *createOptions, projects []*config.Config, client createClient, issues []issueFromFile, owner, repo string) error {
+	out := cmd.OutOrStdout()
+
+	rows, err := planImport(issues, opts, projects[0], client, owner, repo)
+	if err != nil {
+		return err
+	}
+	results, err := loadImportResults(resultsPath(opts, len(rows)), opts.fromFile)
+	if err != nil {
+		return err
+	}
+	recorded := make([]*importResult, len(rows))
+	for i, row := range rows {
+		if recorded[i], err = results.find(row); err != nil {
+			return err
+		}
+	}
+
+	if opts.dryRun {
+		return printImportPlan(out, rows, recorded)
+	}
+
+	resume := func(err error) error {
+		if results.path == "" {
+			return err
+		}
+		if saveErr := results.save(); saveErr != nil {
+			return fmt.Errorf("%w (and %v)", err, saveErr)
+		}
+		return fmt.Errorf("%w\nCreated issues are recorded in %s; rerun the same command to resume", err, results.path)
+	}
+
+	// Create the issues and add them to the projects
+	created, skipped := 0, 0
+	for i, row := range rows {
+		result := recorded[i]
+		if result != nil && result.InProject {
+			fmt.Fprintf(out, "Skipped %s: already created as #%d\n", row.key, result.Number)
+			skipped++
+			continue
+		}
+
+		if result == nil {
+			issue, err := client.CreateIssueWithOptions(row.owner, row.repo, row.title, row.body, row.labels, row.assignees, row.milestone)
+			if err != nil {
+				return resume(fmt.Errorf("%s: failed to create issue: %w", row.key, err))
+			}
+			setCreatedIssueType(client, issue, row.issueType)
+
+			result = &importResult{
+				Key:     row.key,
+				Title:   row.title,
+				Repo:    row.owner + "/" + row.repo,
+				Number:  issue.Number,
+				URL:     issue.URL,
+				IssueID: issue.ID,
+			}
+			recorded[i] = result
+			results.Issues = append(results.Issues, result)
+			if err := results.save(); err != nil {
+				return err
+			}
+			created++
+
+			fmt.Fprintf(out, "Created issue #%d: %s\n", issue.Number, issue.Title)
+			fmt.Fprintf(out, "%s\n", issue.URL)
+		}
+
+		if err := addIssueToProjectsWithFields(client, result.IssueID, projects, row.status, row.priority, row.fields); err != nil {
+			return resume(fmt.Errorf("%s: %w", row.key, err))
+		}
+		result.InProject = true
+		if err := results.save(); err != nil {
+			return err
+		}
+	}
+
+	// Link parents and dependencies, now that every row has an issue
+	if err := linkImportRows(out, client, rows, recorded, results); err != nil {
+		return resume(err)
+	}
+
+	if len(rows) > 1 {
+		u := ui.New(out)
+		summary := fmt.Sprintf("Created %d of %d issues", created, len(rows))
+		if skipped > 0 {
+			summary += fmt.Sprintf(" (%d already created)", skipped)
+		}
+		u.Success(summary)
+		if results.path != "" {
+			u.Info(fmt.Sprintf("Results recorded in %s", results.path))
+		}
+	}
+	return nil
+}
+
+// linkImportRows adds each row's issue to its parent and marks it blocked by
+// its blockers, skipping links the results file records as done
+func linkImportRows(out io.Writer, client createClient, rows []*importRow, recorded []*importResult, results *importResults) error {
+	existing := make(map[string]*api.Issue)
+	refIssue := func(ref issueRef) (id string, number int, err error) {
+		if ref.row >= 0 {
+			return recorded[ref.row].IssueID, recorded[ref.row].Number, nil
+		}
+		key := fmt.Sprintf("%s/%s#%d", ref.owner, ref.repo, ref.number)
+		issue, ok := existing[key]
+		if !ok {
+			if issue, err = client.GetIssue(ref.owner, ref.repo, ref.number); err != nil {
+				return "", 0, fmt.Errorf("failed to get issue %s: %w", key, err)
+			}
+			existing[key] = issue
+		}
+		return issue.ID, issue.Number, nil
+	}
+
+	dependenciesSupported := true
+	for i, row := range rows {
+		result := recorded[i]
+
+		if row.parent != nil && !result.Parent {
+			parentID, parentNumber, err := refIssue(*row.parent)
+			if err != nil {
+				return fmt.Errorf("%s: parent: %w", row.key, err)
+			}
+			if err := client.AddSubIssue(parentID, result.IssueID); err != nil {
+				return fmt.Errorf("%s: failed to add #%d as a sub-issue of #%d: %w", row.key, result.Number, parentNumber, err)
+			}
+			result.Parent = true
+			if err := results.save(); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Added #%d as a sub-issue of #%d\n", result.Number, parentNumber)
+		}
+
+		for _, blocker := range row.blockedBy {
+			if !dependenciesSupported || containsString(result.BlockedBy, blocker.text) {
+				continue
+			}
+			blockerID, blockerNumber, err := refIssue(blocker)
+			if err != nil {
+				return fmt.Errorf("%s: blocked_by: %w", row.key, err)
+			}
+			if err := client.AddBlockedBy(result.IssueID, blockerID); err != nil {
+				if api.IsUnsupported(err) {
+					fmt.Fprintf(os.Stderr, "Warning: dependencies were not added: %v\n", err)
+					dependenciesSupported = false
+					continue
+				}
+				return fmt.Errorf("%s: failed to mark #%d as blocked by #%d: %w", row.key, result.Number, blockerNumber, err)
+			}
+			result.BlockedBy = append(result.BlockedBy, blocker.text)
+			if err := results.save(); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Marked #%d as blocked by #%d\n", result.Number, blockerNumber)
+		}
+	}
+	return nil
+}
+
+// printImportPlan writes what an import would do, without doing it
+func printImportPlan(out io.Writer, rows []*importRow, recorded []*importResult) error {
+	ref := func(r issueRef) string {
+		if r.row >= 0 {
+			return rows[r.row].key
+		}
+		return fmt.Sprintf("%s/%s#%d", r.owner, r.repo, r.number)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROW\tREPO\tTITLE\tPARENT\tBLOCKED BY\tACTION")
+	toCreate := 0
+	for i, row := range rows {
+		parent := "-"
+		if row.parent != nil {
+			parent = ref(*row.parent)
+		}
+		blockers := "-"
+		if len(row.blockedBy) > 0 {
+			names := make([]string, len(row.blockedBy))
+			for j, b := range row.blockedBy {
+				names[j] = ref(b)
+			}
+			blockers = strings.Join(names, ", ")
+		}
+		action := "create"
+		if result := recorded[i]; result != nil {
+			action = "skip (#" + strconv.Itoa(result.Number) + ")"
+		} else {
+			toCreate++
+		}
+		fmt.Fprintf(w, "%s\t%s/%s\t%s\t%s\t%s\t%s\n", row.key, row.owner, row.repo, row.title, parent, blockers, action)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nDry run: would create %d of %d issues\n", toCreate, len(rows))
+	return nil
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+)
+
+// bulkAPI is the fake API for create --from-file. Created issues are
+// numbered from 100, and parent and dependency links are recorded as
+// "child>parent" and "issue<blocker" by issue ID.
+type bulkAPI struct {
+	*interactiveAPI
+	next        int
+	failOn      string // title whose creation fails
+	noDeps      bool
+	subIssues   []string
+	blockedBy   []string
+	fieldValues []string // "issue:field=value"
+	lastIssue   string
+}
+
+func newBulkAPI() *bulkAPI {
+	return &bulkAPI{interactiveAPI: newInteractiveAPI(), next: 100}
+}
+
+func (f *bulkAPI) CreateIssueWithOptions(owner, repo, title, body string, labels, assignees []string, milestone string) (*api.Issue, error) {
+	if title == f.failOn {
+		return nil, errors.New("server error")
+	}
+	f.issues = append(f.issues, createdIssue{owner + "/" + repo, title, body, labels, assignees, milestone})
+	number := f.next
+	f.next++
+	return &api.Issue{ID: fmt.Sprintf("I_%d", number), Number: number, Title: title, URL: fmt.Sprintf("https://github.com/%s/%s/issues/%d", owner, repo, number)}, nil
+}
+
+func (f *bulkAPI) AddIssueToProject(projectID, issueID string) (string, error) {
+	f.lastIssue = issueID
+	return "ITEM_" + issueID, nil
+}
+
+func (f *bulkAPI) SetProjectItemField(projectID, itemID, fieldName, value string) error {
+	f.fieldValues = append(f.fieldValues, fmt.Sprintf("%s:%s=%s", f.lastIssue, fieldName, value))
+	return nil
+}
+
+func (f *bulkAPI) AddSubIssue(parentIssueID, childIssueID string) error {
+	f.subIssues = append(f.subIssues, childIssueID+">"+parentIssueID)
+	return nil
+}
+
+func (f *bulkAPI) AddBlockedBy(issueID, blockingIssueID string) error {
+	if f.noDeps {
+		return &api.UnsupportedError{Feature: api.FeatureIssueDependencies, Host: "ghe.example.com"}
+	}
+	f.blockedBy = append(f.blockedBy, issueID+"<"+blockingIssueID)
+	return nil
+}
+
+// writeImportFile writes data to name in a temporary directory
+func writeImportFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const epicYAML = `id: epic
+title: Checkout epic
+labels: [epic]
+---
+- id: cart
+  title: Cart page
+  parent: epic
+  fields:
+    Estimate: "3"
+- title: Payment page
+  repo: acme/web
+  parent: epic
+  blocked_by: [cart, "#2"]
+  status: done
+`
+
+func TestCreateFromFile_MultiDocumentYAML(t *testing.T) {
+	client := newBulkAPI()
+	path := writeImportFile(t, "plan.yml", epicYAML)
+
+	out, err := runRootWithInput(t, client, "", "create", "--from-file", path, "--label", "q3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, out)
+	}
+
+	var titles []string
+	for _, issue := range client.issues {
+		titles = append(titles, issue.repo+" "+issue.title+" "+strings.Join(issue.labels, ","))
+	}
+	wantTitles := []string{"acme/api Checkout epic epic,q3", "acme/api Cart page q3", "acme/web Payment page q3"}
+	if !reflect.DeepEqual(titles, wantTitles) {
+		t.Errorf("Expected %v, got %v", wantTitles, titles)
+	}
+	if want := []string{"I_101>I_100", "I_102>I_100"}; !reflect.DeepEqual(client.subIssues, want) {
+		t.Errorf("Expected sub-issues %v, got %v", want, client.subIssues)
+	}
+	if want := []string{"I_102<I_101", "I_102<ISignup flow"}; !reflect.DeepEqual(client.blockedBy, want) {
+		t.Errorf("Expected dependencies %v, got %v", want, client.blockedBy)
+	}
+	for _, value := range []string{"I_101:Estimate=3", "I_102:Status=Done"} {
+		if !containsString(client.fieldValues, value) {
+			t.Errorf("Expected field value %s, got %v", value, client.fieldValues)
+		}
+	}
+	if !strings.Contains(out, "Created 3 of 3 issues") {
+		t.Errorf("Expected a summary, got:\n%s", out)
+	}
+
+	// The results file records every issue and link
+	data, err := os.ReadFile(strings.TrimSuffix(path, ".yml") + ".results.json")
+	if err != nil {
+		t.Fatalf("Expected a results file: %v", err)
+	}
+	var results importResults
+	if err := json.Unmarshal(data, &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Issues) != 3 || results.Issues[1].Key != "cart" || results.Issues[2].Key != "row 3" ||
+		!results.Issues[2].Parent || len(results.Issues[2].BlockedBy) != 2 {
+		t.Errorf("Unexpected results %+v", results.Issues)
+	}
+}
+
+func TestCreateFromFile_JSONArrayAndCSV(t *testing.T) {
+	files := map[string]string{
+		"plan.json": `[{"id": "a", "title": "First"}, {"title": "Second", "parent": "a", "assignees": ["@me"]}]`,
+		"plan.csv":  "id,title,parent,assignees,labels,Estimate\na,First,,,,\n,,,,,\n,Second,a,@me,\"bug, docs\",5\n",
+	}
+	for name, data := range files {
+		client := newBulkAPI()
+		if out, err := runRootWithInput(t, client, "", "create", "--from-file", writeImportFile(t, name, data)); err != nil {
+			t.Fatalf("%s: unexpected error: %v\n%s", name, err, out)
+		}
+		if len(client.issues) != 2 || client.issues[1].title != "Second" || !reflect.DeepEqual(client.issues[1].assignees, []string{"octocat"}) {
+			t.Errorf("%s: unexpected issues %+v", name, client.issues)
+		}
+		if !reflect.DeepEqual(client.subIssues, []string{"I_101>I_100"}) {
+			t.Errorf("%s: expected Second under First, got %v", name, client.subIssues)
+		}
+	}
+}
+
+func TestParseIssueCSV_Columns(t *testing.T) {
+	issues, err := parseIssueCSV([]byte("Title,Blocked By,Repo,Story Points\nA,\"x, #4\",acme/web,8\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := issueFromFile{Title: "A", BlockedBy: []string{"x", "#4"}, Repo: "acme/web", Fields: map[string]string{"Story Points": "8"}}
+	if len(issues) != 1 || !reflect.DeepEqual(issues[0], want) {
+		t.Errorf("Expected %+v, got %+v", want, issues)
+	}
+}
+
+func TestCreateFromFile_DryRun(t *testing.T) {
+	client := newBulkAPI()
+	path := writeImportFile(t, "plan.yml", epicYAML)
+
+	out, err := runRootWithInput(t, client, "", "create", "--from-file", path, "--dry-run")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.issues) != 0 || len(client.subIssues) != 0 {
+		t.Errorf("Expected nothing to be created, got %+v", client.issues)
+	}
+	for _, want := range []string{"ROW", "epic", "acme/web", "cart, acme/web#2", "Dry run: would create 3 of 3 issues"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat(strings.TrimSuffix(path, ".yml") + ".results.json"); !os.IsNotExist(err) {
+		t.Errorf("Expected no results file, got %v", err)
+	}
+}
+
+func TestCreateFromFile_Resume(t *testing.T) {
+	path := writeImportFile(t, "plan.yml", epicYAML)
+	results := filepath.Join(t.TempDir(), "done.json")
+
+	client := newBulkAPI()
+	client.failOn = "Payment page"
+	_, err := runRootWithInput(t, client, "", "create", "--from-file", path, "--results", results)
+	if err == nil || !strings.Contains(err.Error(), "rerun the same command to resume") {
+		t.Fatalf("Expected a resumable error, got %v", err)
+	}
+
+	// The rerun creates only the failed row, and links it to the epic
+	// created by the first run
+	rerun := newBulkAPI()
+	rerun.next = 200
+	out, err := runRootWithInput(t, rerun, "", "create", "--from-file", path, "--results", results)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, out)
+	}
+	if len(rerun.issues) != 1 || rerun.issues[0].title != "Payment page" {
+		t.Errorf("Expected only the failed row to be created, got %+v", rerun.issues)
+	}
+	if want := []string{"I_101>I_100", "I_200>I_100"}; !reflect.DeepEqual(rerun.subIssues, want) {
+		t.Errorf("Expected links %v, got %v", want, rerun.subIssues)
+	}
+	if !strings.Contains(out, "Skipped epic: already created as #100") || !strings.Contains(out, "(2 already created)") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+
+	// Once everything is done, a rerun does nothing
+	again := newBulkAPI()
+	if _, err := runRootWithInput(t, again, "", "create", "--from-file", path, "--results", results); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(again.issues) != 0 || len(again.subIssues) != 0 || len(again.blockedBy) != 0 {
+		t.Errorf("Expected nothing to be done, got %+v %v %v", again.issues, again.subIssues, again.blockedBy)
+	}
+
+	// A changed row no longer matches its result
+	changed := writeImportFile(t, "plan.yml", strings.Replace(epicYAML, "Cart page", "Basket page", 1))
+	_, err = runRootWithInput(t, newBulkAPI(), "", "create", "--from-file", changed, "--results", results)
+	if err == nil || !strings.Contains(err.Error(), `records cart as "Cart page"`) {
+		t.Errorf("Expected a mismatch error, got %v", err)
+	}
+}
+
+func TestCreateFromFile_Validation(t *testing.T) {
+	data := `- id: a
+  title: A
+  parent: b
+- id: b
+  title: B
+  parent: a
+`
+	_, err := runRootWithInput(t, newBulkAPI(), "", "create", "--from-file", writeImportFile(t, "cycle.yml", data))
+	if err == nil || !strings.Contains(err.Error(), "parent references form a cycle") {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+
+	data = `- title: ""
+- id: x
+  title: X
+  repo: web
+  blocked_by: [x]
+- title: Y
+  parent: nope
+  assignees: [ghost]
+`
+	client := newBulkAPI()
+	_, err = runRootWithInput(t, client, "", "create", "--from-file", writeImportFile(t, "bad.yml", data))
+	for _, want := range []string{
+		"row 1: title is required",
+		`row 2: invalid repo "web"`,
+		`row 3: unknown user "ghost"`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+	if len(client.issues) != 0 {
+		t.Errorf("Expected nothing to be created, got %+v", client.issues)
+	}
+
+	_, err = runRootWithInput(t, newBulkAPI(), "", "create", "--from-file", writeImportFile(t, "dup.yml", "- {id: a, title: A}\n- {id: A, title: B}\n"))
+	if err == nil || !strings.Contains(err.Error(), `row 2: id "A" is already used by row 1`) {
+		t.Errorf("Expected a duplicate id error, got %v", err)
+	}
+}
+
+func TestCreateFromFile_DependenciesUnsupported(t *testing.T) {
+	client := newBulkAPI()
+	client.noDeps = true
+	data := "- {id: a, title: A}\n- {title: B, blocked_by: [a]}\n"
+
+	if _, err := runRootWithInput(t, client, "", "create", "--from-file", writeImportFile(t, "plan.yml", data)); err != nil {
+		t.Fatalf("Expected dependencies to be skipped, got %v", err)
+	}
+	if len(client.issues) != 2 {
+		t.Errorf("Expected both issues to be created, got %+v", client.issues)
+	}
+}
+
+func TestCreate_DryRunRequiresFromFile(t *testing.T) {
+	_, err := runRootWithInput(t, newBulkAPI(), "", "create", "--title", "X", "--dry-run")
+	if err == nil || !strings.Contains(err.Error(), "require --from-file") {
+		t.Errorf("Expected an error, got %v", err)
+	}
+}
@@ -0,0 +1,445 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// issueBodyInstructions heads the body template opened in the editor; it is
+// removed from the saved body
+const issueBodyInstructions = "<!-- Describe the issue below. Saving the template unchanged leaves the body empty. -->"
+
+// issueBodyTemplate is the issue form the editor starts from when no --body
+// was given
+const issueBodyTemplate = issueBodyInstructions + `
+
+## Summary
+
+
+## Details
+
+
+## Acceptance criteria
+
+- [ ]
+`
+
+// prompter asks questions on the command's output and reads the answers,
+// one per line, from its input
+type prompter struct {
+	out    io.Writer
+	u      *ui.UI
+	reader *bufio.Reader
+}
+
+func newPrompter(cmd *cobra.Command, u *ui.UI) *prompter {
+	return &prompter{out: cmd.OutOrStdout(), u: u, reader: bufio.NewReader(cmd.InOrStdin())}
+}
+
+// readLine returns the next answer, failing once the input has ended
+func (p *prompter) readLine(label string) (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil && line == "" {
+		if err == io.EOF {
+			return "", fmt.Errorf("no answer for %q: input ended", label)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// ask prompts for a value; an empty answer keeps defaultVal
+func (p *prompter) ask(label, defaultVal string) (string, error) {
+	fmt.Fprint(p.out, p.u.Prompt(label, defaultVal))
+	answer, err := p.readLine(label)
+	if err != nil || answer != "" {
+		return answer, err
+	}
+	return defaultVal, nil
+}
+
+// confirm asks a yes or no question; an empty answer is def
+func (p *prompter) confirm(label string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		fmt.Fprint(p.out, p.u.Prompt(label, hint))
+		answer, err := p.readLine(label)
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		p.u.Warning("Please answer y or n")
+	}
+}
+
+// choose shows options as a menu and returns the one picked by number or
+// name. An empty answer keeps defaultVal, which may be empty for none.
+func (p *prompter) choose(label string, options []string, defaultVal string) (string, error) {
+	p.u.PrintMenu(options, false)
+	for {
+		answer, err := p.ask(label, defaultVal)
+		if err != nil || answer == "" || answer == defaultVal {
+			return answer, err
+		}
+		if option, ok := pickOption(options, answer); ok {
+			return option, nil
+		}
+		p.u.Warning(fmt.Sprintf("%q is not one of the options: enter a number from 1 to %d or an option name", answer, len(options)))
+	}
+}
+
+// chooseMany shows options as a menu and returns those picked, by number or
+// name, in a comma-separated answer. With allowOther, answers that are not
+// options are kept as given.
+func (p *prompter) chooseMany(label string, options, defaults []string, allowOther bool) ([]string, error) {
+	if len(options) > 0 {
+		p.u.PrintMenu(options, false)
+	}
+	for {
+		answer, err := p.ask(label+" (comma-separated)", strings.Join(defaults, ","))
+		if err != nil {
+			return nil, err
+		}
+
+		var picked []string
+		var invalid string
+		for _, part := range strings.Split(answer, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			option, ok := pickOption(options, part)
+			if !ok {
+				if !allowOther {
+					invalid = part
+					break
+				}
+				option = part
+			}
+			if !containsString(picked, option) {
+				picked = append(picked, option)
+			}
+		}
+		if invalid == "" {
+			return picked, nil
+		}
+		p.u.Warning(fmt.Sprintf("%q is not one of the options: enter numbers from 1 to %d or option names", invalid, len(options)))
+	}
+}
+
+// pickOption returns the option numbered or named by answer
+func pickOption(options []string, answer string) (string, bool) {
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n >= 1 && n <= len(options) {
+			return options[n-1], true
+		}
+		return "", false
+	}
+	for _, option := range options {
+		if strings.EqualFold(option, answer) {
+			return option, true
+		}
+	}
+	return "", false
+}
+
+// fieldOptionNames returns the cached options of the project field a config
+// key such as "status" maps to
+func fieldOptionNames(cfg *config.Config, key string) []string {
+	if cfg.Metadata == nil {
+		return nil
+	}
+	name := cfg.GetFieldName(key)
+	for _, field := range cfg.Metadata.Fields {
+		if strings.EqualFold(field.Name, name) {
+			names := make([]string, len(field.Options))
+			for i, option := range field.Options {
+				names[i] = option.Name
+			}
+			return names
+		}
+	}
+	return nil
+}
+
+// askFieldValue asks for a project field value, from a menu of the field's
+// cached options when there are any
+func askFieldValue(p *prompter, cfg *config.Config, key, label, value string) (string, error) {
+	if value == "" {
+		switch key {
+		case "status":
+			value = cfg.Defaults.Status
+		case "priority":
+			value = cfg.Defaults.Priority
+		}
+	}
+	if value != "" {
+		value = cfg.ResolveFieldValue(key, value)
+	}
+
+	options := fieldOptionNames(cfg, key)
+	if len(options) == 0 {
+		return p.ask(label, value)
+	}
+	return p.choose(label, options, value)
+}
+
+// runCreateInteractive prompts for the issue's repository, title, body,
+// project fields, labels, assignees, and milestone, starting from any flags
+// given, and creates the issue once the summary is confirmed
+func runCreateInteractive(cmd *cobra.Command, opts *createOptions, projects []*config.Config, client createClient) error {
+	cfg := projects[0]
+	u := ui.New(cmd.OutOrStdout())
+	p := newPrompter(cmd, u)
+
+	u.Header("gh pmu create", "Press Enter to keep the value in brackets")
+	fmt.Fprintln(p.out)
+
+	// Repository
+	repository := opts.repo
+	if repository == "" {
+		switch len(cfg.Repositories) {
+		case 0:
+			return fmt.Errorf("no repository configured")
+		case 1:
+			repository = cfg.Repositories[0]
+		default:
+			var err error
+			if repository, err = p.choose("Repository", cfg.Repositories, cfg.Repositories[0]); err != nil {
+				return err
+			}
+		}
+	}
+	owner, repo := splitRepository(repository)
+	if owner == "" || repo == "" {
+		return fmt.Errorf("invalid repository %q: expected owner/repo", repository)
+	}
+	u.Success(fmt.Sprintf("Repository: %s", repository))
+
+	// Title and body
+	title := opts.title
+	for {
+		var err error
+		if title, err = p.ask("Title", title); err != nil {
+			return err
+		}
+		if title != "" {
+			break
+		}
+		u.Warning("A title is required")
+	}
+
+	body := opts.body
+	useEditor, err := p.confirm("Write the body in your editor?", true)
+	if err != nil {
+		return err
+	}
+	if useEditor {
+		initial := body
+		if initial == "" {
+			initial = issueBodyTemplate
+		}
+		edited, err := editText(initial)
+		if err != nil {
+			return err
+		}
+		body = bodyFromTemplate(edited)
+	}
+
+	// Project fields
+	status, err := askFieldValue(p, cfg, "status", "Status", opts.status)
+	if err != nil {
+		return err
+	}
+	priority, err := askFieldValue(p, cfg, "priority", "Priority", opts.priority)
+	if err != nil {
+		return err
+	}
+
+	// Labels, assignees, and milestone, picked from the repository's own
+	metadata, err := client.GetRepositoryMetadata(owner, repo)
+	if err != nil {
+		u.Warning(fmt.Sprintf("Could not fetch labels, assignees, and milestones: %v", err))
+		metadata = &api.RepositoryMetadata{}
+	}
+
+	var labelNames []string
+	for _, label := range metadata.Labels {
+		labelNames = append(labelNames, label.Name)
+	}
+	labels, err := p.chooseMany("Labels", labelNames, opts.labels, len(labelNames) == 0)
+	if err != nil {
+		return err
+	}
+
+	var logins []string
+	for _, user := range metadata.AssignableUsers {
+		logins = append(logins, user.Login)
+	}
+	assigneeArgs, err := p.chooseMany("Assignees", logins, opts.assignees, true)
+	if err != nil {
+		return err
+	}
+	assignees, err := resolveAssignees(client, assigneeArgs)
+	if err != nil {
+		return err
+	}
+
+	milestone := opts.milestone
+	if len(metadata.Milestones) > 0 {
+		titles := make([]string, len(metadata.Milestones))
+		for i, m := range metadata.Milestones {
+			titles[i] = m.Title
+		}
+		if milestone, err = p.choose("Milestone", titles, milestone); err != nil {
+			return err
+		}
+	}
+
+	issueType, err := lookupIssueType(client, owner, opts.issueType)
+	if err != nil {
+		return err
+	}
+
+	allLabels := append([]string{}, cfg.Defaults.Labels...)
+	for _, label := range labels {
+		if !containsString(allLabels, label) {
+			allLabels = append(allLabels, label)
+		}
+	}
+
+	// Preview
+	summary := map[string]string{
+		"Repository": repository,
+		"Title":      title,
+		"Body":       bodySummary(body),
+		"Status":     status,
+		"Priority":   priority,
+		"Labels":     strings.Join(allLabels, ", "),
+		"Assignees":  strings.Join(assignees, ", "),
+		"Milestone":  milestone,
+		"Type":       opts.issueType,
+	}
+	var order []string
+	for _, key := range []string{"Repository", "Title", "Body", "Status", "Priority", "Labels", "Assignees", "Milestone", "Type"} {
+		if summary[key] != "" {
+			order = append(order, key)
+		}
+	}
+	u.SummaryBox("Issue preview", summary, order)
+
+	submit, err := p.confirm("Create this issue?", true)
+	if err != nil {
+		return err
+	}
+	if !submit {
+		u.Info("Aborted")
+		return nil
+	}
+
+	issue, err := client.CreateIssueWithOptions(owner, repo, title, body, allLabels, assignees, milestone)
+	if err != nil {
+		return fmt.Errorf("failed to create issue: %w", err)
+	}
+	setCreatedIssueType(client, issue, issueType)
+
+	if err := addIssueToProjects(client, issue.ID, projects, status, priority); err != nil {
+		return err
+	}
+
+	u.Success(fmt.Sprintf("Created issue #%d: %s", issue.Number, issue.Title))
+	fmt.Fprintln(p.out, issue.URL)
+	return nil
+}
+
+// bodySummary describes a body in one line for the preview
+func bodySummary(body string) string {
+	if body == "" {
+		return "(empty)"
+	}
+	lines := strings.Count(body, "\n") + 1
+	first, _, _ := strings.Cut(body, "\n")
+	if lines == 1 {
+		return first
+	}
+	return fmt.Sprintf("%s (%d lines)", first, lines)
+}
+
+// bodyFromTemplate removes the template instructions from an edited body,
+// and returns an empty body when the template was saved unchanged
+func bodyFromTemplate(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSpace(strings.Replace(text, issueBodyInstructions, "", 1))
+	if text == strings.TrimSpace(strings.Replace(issueBodyTemplate, issueBodyInstructions, "", 1)) {
+		return ""
+	}
+	return text
+}
+
+// editorCommand returns the user's editor from $EDITOR or $VISUAL, falling
+// back to notepad on Windows and vi elsewhere
+func editorCommand() string {
+	for _, name := range []string{"EDITOR", "VISUAL"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// editText opens text in the user's editor and returns it once the editor
+// exits
+func editText(text string) (string, error) {
+	file, err := os.CreateTemp("", "gh-pmu-issue-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a file to edit: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write %s: %w", file.Name(), err)
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	args := strings.Fields(editorCommand())
+	editor := exec.Command(args[0], append(args[1:], file.Name())...)
+	editor.Stdin = os.Stdin
+	editor.Stdout = os.Stdout
+	editor.Stderr = os.Stderr
+	if err := editor.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", args[0], err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read the edited body: %w", err)
+	}
+	return string(data), nil
+}
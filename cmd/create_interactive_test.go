@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// createdIssue records an issue created through CreateIssueWithOptions
+type createdIssue struct {
+	repo      string
+	title     string
+	body      string
+	labels    []string
+	assignees []string
+	milestone string
+}
+
+// interactiveAPI is the fake API for create --interactive, with repository
+// metadata to pick from
+type interactiveAPI struct {
+	*userAPI
+	metadata *api.RepositoryMetadata
+	issues   []createdIssue
+	fields   map[string]string
+}
+
+func newInteractiveAPI() *interactiveAPI {
+	return &interactiveAPI{
+		userAPI: newUserAPI(),
+		metadata: &api.RepositoryMetadata{
+			Labels:          []api.Label{{Name: "bug"}, {Name: "docs"}, {Name: "enhancement"}},
+			AssignableUsers: []api.Actor{{Login: "alice"}, {Login: "bob"}},
+			Milestones:      []api.Milestone{{Title: "v1.0"}, {Title: "v2.0"}},
+		},
+		fields: make(map[string]string),
+	}
+}
+
+func (f *interactiveAPI) GetRepositoryMetadata(owner, repo string) (*api.RepositoryMetadata, error) {
+	return f.metadata, nil
+}
+
+func (f *interactiveAPI) CreateIssueWithOptions(owner, repo, title, body string, labels, assignees []string, milestone string) (*api.Issue, error) {
+	f.issues = append(f.issues, createdIssue{owner + "/" + repo, title, body, labels, assignees, milestone})
+	return &api.Issue{ID: "I_new", Number: 99, Title: title, URL: "https://github.com/" + owner + "/" + repo + "/issues/99"}, nil
+}
+
+func (f *interactiveAPI) SetProjectItemField(projectID, itemID, fieldName, value string) error {
+	f.fields[fieldName] = value
+	return nil
+}
+
+// runRootWithInput runs the root command with args against client, reading
+// input as the answers typed at prompts. The project has two repositories
+// and cached Status and Priority options.
+func runRootWithInput(t *testing.T, client apiClient, input string, args ...string) (string, error) {
+	t.Helper()
+	return runRootSetup(t, client, func(root *cobra.Command, cc *cmdContext) {
+		root.SetIn(strings.NewReader(input))
+		cc.cfg.Repositories = []string{"acme/api", "acme/web"}
+		cc.cfg.Metadata = &config.Metadata{Fields: []config.FieldMetadata{
+			{Name: "Status", Options: []config.OptionMetadata{{Name: "Todo"}, {Name: "In progress"}, {Name: "Done"}}},
+			{Name: "Priority", Options: []config.OptionMetadata{{Name: "P0"}, {Name: "P1"}, {Name: "P2"}}},
+		}}
+	}, args...)
+}
+
+// runCreateInteractiveTest runs create --interactive with args, answering
+// the prompts with input
+func runCreateInteractiveTest(t *testing.T, client apiClient, input string, args ...string) (string, error) {
+	t.Helper()
+	return runRootWithInput(t, client, input, append([]string{"create", "--interactive"}, args...)...)
+}
+
+func TestCreateInteractive_PromptsForEverything(t *testing.T) {
+	client := newInteractiveAPI()
+	input := strings.Join([]string{
+		"2",         // repository: acme/web
+		"Fix login", // title
+		"n",         // no editor
+		"2",         // status: In progress
+		"p1",        // priority by name
+		"1,docs",    // labels by number and name
+		"@me,alice", // assignees
+		"v2.0",      // milestone
+		"",          // create
+	}, "\n") + "\n"
+
+	out, err := runCreateInteractiveTest(t, client, input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, out)
+	}
+
+	want := createdIssue{
+		repo:      "acme/web",
+		title:     "Fix login",
+		labels:    []string{"bug", "docs"},
+		assignees: []string{"octocat", "alice"},
+		milestone: "v2.0",
+	}
+	if len(client.issues) != 1 || !reflect.DeepEqual(client.issues[0], want) {
+		t.Errorf("Expected %+v, got %+v", want, client.issues)
+	}
+	if client.fields["Status"] != "In progress" || client.fields["Priority"] != "P1" {
+		t.Errorf("Expected the chosen status and priority, got %v", client.fields)
+	}
+	for _, s := range []string{"Issue preview", "bug, docs", "octocat, alice", "Created issue #99: Fix login"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected output to contain %q, got:\n%s", s, out)
+		}
+	}
+}
+
+func TestCreateInteractive_FlagsAreDefaults(t *testing.T) {
+	client := newInteractiveAPI()
+	input := strings.Join([]string{
+		"1",   // repository
+		"",    // keep the title
+		"n",   // no editor
+		"",    // keep the status
+		"",    // no priority
+		"9",   // not a label
+		"bug", // labels
+		"",    // keep the assignees
+		"",    // no milestone
+		"y",   // create
+	}, "\n") + "\n"
+
+	out, err := runCreateInteractiveTest(t, client, input, "--title", "From flags", "--body", "Details", "--status", "todo", "--assignee", "bob")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, out)
+	}
+
+	want := createdIssue{
+		repo:      "acme/api",
+		title:     "From flags",
+		body:      "Details",
+		labels:    []string{"bug"},
+		assignees: []string{"bob"},
+	}
+	if len(client.issues) != 1 || !reflect.DeepEqual(client.issues[0], want) {
+		t.Errorf("Expected %+v, got %+v", want, client.issues)
+	}
+	if client.fields["Status"] != "Todo" {
+		t.Errorf("Expected the --status alias to be resolved, got %v", client.fields)
+	}
+	if _, ok := client.fields["Priority"]; ok {
+		t.Errorf("Expected no priority, got %v", client.fields)
+	}
+	if !strings.Contains(out, `"9" is not one of the options`) {
+		t.Errorf("Expected a warning for the invalid label, got:\n%s", out)
+	}
+}
+
+func TestCreateInteractive_Abort(t *testing.T) {
+	client := newInteractiveAPI()
+
+	out, err := runCreateInteractiveTest(t, client, "1\nTitle\nn\n\n\n\n\n\nn\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.issues) != 0 {
+		t.Errorf("Expected no issue to be created, got %+v", client.issues)
+	}
+	if !strings.Contains(out, "Aborted") {
+		t.Errorf("Expected Aborted, got:\n%s", out)
+	}
+}
+
+func TestCreateInteractive_InputEnds(t *testing.T) {
+	client := newInteractiveAPI()
+
+	_, err := runCreateInteractiveTest(t, client, "1\nTitle\n")
+	if err == nil || !strings.Contains(err.Error(), "input ended") {
+		t.Errorf("Expected input ended error, got %v", err)
+	}
+	if len(client.issues) != 0 {
+		t.Errorf("Expected no issue to be created, got %+v", client.issues)
+	}
+}
+
+func TestCreateInteractive_UnknownAssignee(t *testing.T) {
+	client := newInteractiveAPI()
+
+	_, err := runCreateInteractiveTest(t, client, "1\nTitle\nn\n\n\n\nghost\n")
+	if err == nil || !strings.Contains(err.Error(), `unknown user "ghost"`) {
+		t.Errorf("Expected unknown user error, got %v", err)
+	}
+}
+
+func TestCreateInteractive_EditsBody(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nprintf 'Steps to reproduce\\n' >> \"$1\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", script)
+
+	client := newInteractiveAPI()
+	out, err := runCreateInteractiveTest(t, client, "1\nTitle\n\n\n\n\n\n\n\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, out)
+	}
+	if len(client.issues) != 1 {
+		t.Fatalf("Expected one issue, got %+v", client.issues)
+	}
+	body := client.issues[0].body
+	if strings.Contains(body, issueBodyInstructions) || !strings.HasPrefix(body, "## Summary") || !strings.HasSuffix(body, "Steps to reproduce") {
+		t.Errorf("Expected the edited template without instructions, got %q", body)
+	}
+}
+
+func TestCreateInteractive_WithFromFile(t *testing.T) {
+	_, err := runCreateInteractiveTest(t, newInteractiveAPI(), "", "--from-file", "issue.yml")
+	if err == nil || !strings.Contains(err.Error(), "cannot use --interactive with --from-file") {
+		t.Errorf("Expected an error, got %v", err)
+	}
+}
+
+func TestBodyFromTemplate(t *testing.T) {
+	if got := bodyFromTemplate(issueBodyTemplate); got != "" {
+		t.Errorf("Expected an unchanged template to give an empty body, got %q", got)
+	}
+	if got := bodyFromTemplate("Just text\r\n"); got != "Just text" {
+		t.Errorf("Expected the text itself, got %q", got)
+	}
+}
+
+func TestPickOption(t *testing.T) {
+	options := []string{"Todo", "In progress"}
+	tests := []struct {
+		answer string
+		want   string
+		ok     bool
+	}{
+		{"2", "In progress", true},
+		{"in progress", "In progress", true},
+		{"0", "", false},
+		{"3", "", false},
+		{"Done", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := pickOption(options, tt.answer)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("pickOption(%q) = %q, %v; want %q, %v", tt.answer, got, ok, tt.want, tt.ok)
+		}
+	}
+}
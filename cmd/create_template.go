@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/scooter-indie/gh-pmu/internal/issueform"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// runCreateFromTemplate creates an issue from one of the repository's issue
+// forms or Markdown templates. Form inputs are answered with --input, or
+// prompted for when no --input is given.
+func runCreateFromTemplate(cmd *cobra.Command, opts *createOptions, projects []*config.Config, client createClient, owner, repo string) error {
+	cfg := projects[0]
+
+	if opts.body != "" {
+		return fmt.Errorf("cannot use --body with --template: answer the template's inputs with --input")
+	}
+
+	form, err := loadIssueTemplate(client, owner, repo, opts.template)
+	if err != nil {
+		return err
+	}
+	if !form.IsForm() && len(opts.inputs) > 0 {
+		return fmt.Errorf("template %q is not an issue form: --input cannot be used with it", form.DisplayName())
+	}
+
+	prompt := len(opts.inputs) == 0
+	p := newPrompter(cmd, ui.New(cmd.OutOrStdout()))
+
+	title := opts.title
+	if title == "" && prompt {
+		if title, err = p.ask("Title", ""); err != nil {
+			return err
+		}
+	}
+	if title == "" {
+		return fmt.Errorf("--title is required")
+	}
+	title = form.IssueTitle(title)
+
+	answers, err := templateAnswers(p, form, opts.inputs, prompt)
+	if err != nil {
+		return err
+	}
+	body := form.Render(answers)
+
+	labels := append([]string{}, form.Labels...)
+	for _, label := range append(append([]string{}, cfg.Defaults.Labels...), opts.labels...) {
+		if !containsString(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+
+	assignees, err := resolveAssignees(client, append(append([]string{}, form.Assignees...), opts.assignees...))
+	if err != nil {
+		return err
+	}
+
+	typeName := opts.issueType
+	if typeName == "" {
+		typeName = form.Type
+	}
+	issueType, err := lookupIssueType(client, owner, typeName)
+	if err != nil {
+		return err
+	}
+
+	issue, err := client.CreateIssueWithOptions(owner, repo, title, body, labels, assignees, opts.milestone)
+	if err != nil {
+		return fmt.Errorf("failed to create issue: %w", err)
+	}
+	setCreatedIssueType(client, issue, issueType)
+
+	if err := addIssueToProjects(client, issue.ID, projects, opts.status, opts.priority); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Created issue #%d: %s\n", issue.Number, issue.Title)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", issue.URL)
+	return nil
+}
+
+// loadIssueTemplate reads the issue template named by name: a local file if
+// name is a path to one, otherwise the template in the repository's
+// .github/ISSUE_TEMPLATE with that file name or name
+func loadIssueTemplate(client createClient, owner, repo, name string) (*issueform.Template, error) {
+	if info, err := os.Stat(name); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", name, err)
+		}
+		return issueform.Parse(filepath.Base(name), data)
+	}
+
+	files, err := client.GetIssueTemplates(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var available []string
+	for _, file := range files {
+		if !issueform.IsTemplateFile(file.Name) {
+			continue
+		}
+		base := strings.TrimSuffix(file.Name, path.Ext(file.Name))
+		form, err := issueform.Parse(file.Name, []byte(file.Text))
+		if err != nil {
+			// A broken template only matters when it is the one asked for
+			if strings.EqualFold(name, base) || strings.EqualFold(name, file.Name) {
+				return nil, err
+			}
+			continue
+		}
+		if form.Matches(name) {
+			return form, nil
+		}
+		available = append(available, base)
+	}
+
+	if len(available) == 0 {
+		return nil, fmt.Errorf("issue template %q not found: %s/%s has no templates in %s", name, owner, repo, api.IssueTemplateDir)
+	}
+	return nil, fmt.Errorf("issue template %q not found in %s/%s\nAvailable templates:\n  %s", name, owner, repo, strings.Join(available, "\n  "))
+}
+
+// templateAnswers collects answers to a form's inputs from --input key=value
+// flags, keyed by input. Inputs not given are prompted for when prompt is
+// set, and otherwise keep their default values.
+func templateAnswers(p *prompter, form *issueform.Template, inputs []string, prompt bool) (map[string][]string, error) {
+	answers := make(map[string][]string)
+	given := make(map[string]bool)
+
+	for _, input := range inputs {
+		key, value, ok := strings.Cut(input, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --input %q: expected key=value", input)
+		}
+		element, ok := form.Input(strings.TrimSpace(key))
+		if !ok {
+			keys := make([]string, 0, len(form.Inputs()))
+			for _, e := range form.Inputs() {
+				keys = append(keys, e.Key())
+			}
+			return nil, fmt.Errorf("template %q has no input %q\nAvailable inputs:\n  %s", form.DisplayName(), key, strings.Join(keys, "\n  "))
+		}
+		values, err := element.ParseAnswer(value)
+		if err != nil {
+			return nil, fmt.Errorf("--input %s: %w", key, err)
+		}
+		answers[element.Key()] = values
+		given[element.Key()] = true
+	}
+
+	for _, element := range form.Inputs() {
+		key := element.Key()
+		if given[key] {
+			continue
+		}
+		if !prompt {
+			answers[key] = element.Defaults()
+			continue
+		}
+		values, err := askFormInput(p, element)
+		if err != nil {
+			return nil, err
+		}
+		answers[key] = values
+	}
+
+	for _, element := range form.Inputs() {
+		if err := element.Check(answers[element.Key()]); err != nil {
+			return nil, fmt.Errorf("template %q: %w; set it with --input %s=<value>", form.DisplayName(), err, element.Key())
+		}
+	}
+	return answers, nil
+}
+
+// askFormInput prompts for one form input until the answer passes the
+// input's validations
+func askFormInput(p *prompter, element issueform.Element) ([]string, error) {
+	label := element.Attributes.Label
+	defaults := element.Defaults()
+	if element.Attributes.Description != "" {
+		p.u.Info(element.Attributes.Description)
+	}
+
+	for {
+		var values []string
+		var err error
+		switch element.Type {
+		case issueform.TypeDropdown:
+			if element.Attributes.Multiple {
+				values, err = p.chooseMany(label, element.OptionLabels(), defaults, false)
+				break
+			}
+			var option string
+			option, err = p.choose(label, element.OptionLabels(), strings.Join(defaults, ""))
+			if option != "" {
+				values = []string{option}
+			}
+		case issueform.TypeCheckboxes:
+			values, err = p.chooseMany(label, element.OptionLabels(), nil, false)
+		default:
+			var answer string
+			answer, err = p.ask(label, strings.Join(defaults, ""))
+			values, _ = element.ParseAnswer(answer)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := element.Check(values); err != nil {
+			p.u.Warning(err.Error())
+			continue
+		}
+		return values, nil
+	}
+}
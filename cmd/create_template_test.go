@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+)
+
+const bugReportForm = `name: Bug Report
+description: File a bug report
+title: "[Bug]: "
+labels: [bug]
+assignees: [alice]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for reporting!
+  - type: textarea
+    id: what-happened
+    attributes:
+      label: What happened?
+    validations:
+      required: true
+  - type: dropdown
+    id: version
+    attributes:
+      label: Version
+      options: [1.0.2, 1.0.3]
+      default: 0
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Code of Conduct
+      options:
+        - label: I agree to follow the Code of Conduct
+          required: true
+`
+
+const featureTemplate = `---
+name: Feature request
+about: Suggest an idea
+title: "[Feature] "
+labels: enhancement
+---
+**Describe the solution you'd like**
+`
+
+// templateAPI is the fake API for create --template, with the repository's
+// .github/ISSUE_TEMPLATE files
+type templateAPI struct {
+	*interactiveAPI
+	files []api.RepositoryFile
+}
+
+func newTemplateAPI() *templateAPI {
+	return &templateAPI{
+		interactiveAPI: newInteractiveAPI(),
+		files: []api.RepositoryFile{
+			{Name: "bug_report.yml", Text: bugReportForm},
+			{Name: "config.yml", Text: "blank_issues_enabled: false\n"},
+			{Name: "feature_request.md", Text: featureTemplate},
+		},
+	}
+}
+
+func (f *templateAPI) GetIssueTemplates(owner, repo string) ([]api.RepositoryFile, error) {
+	return f.files, nil
+}
+
+func TestCreateTemplate_Inputs(t *testing.T) {
+	client := newTemplateAPI()
+
+	out, err := runRootWithInput(t, client, "", "create", "--template", "bug_report", "--title", "Crash on save",
+		"--input", "what-happened=It crashes", "--input", "version=2", "--input", "terms=1", "--label", "docs", "--assignee", "@me")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := createdIssue{
+		repo:  "acme/api",
+		title: "[Bug]: Crash on save",
+		body: "### What happened?\n\nIt crashes\n\n### Version\n\n1.0.3\n\n" +
+			"### Code of Conduct\n\n- [X] I agree to follow the Code of Conduct",
+		labels:    []string{"bug", "docs"},
+		assignees: []string{"alice", "octocat"},
+	}
+	if len(client.issues) != 1 || !reflect.DeepEqual(client.issues[0], want) {
+		t.Errorf("Expected %+v, got %+v", want, client.issues)
+	}
+	if !strings.Contains(out, "Created issue #99: [Bug]: Crash on save") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+}
+
+func TestCreateTemplate_Prompts(t *testing.T) {
+	client := newTemplateAPI()
+	input := strings.Join([]string{
+		"Crash on save", // title
+		"",              // what happened: required
+		"It crashes",    // what happened
+		"",              // version: keep the default
+		"",              // code of conduct: required
+		"1",             // code of conduct
+	}, "\n") + "\n"
+
+	out, err := runRootWithInput(t, client, input, "create", "--template", "Bug Report")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, out)
+	}
+
+	wantBody := "### What happened?\n\nIt crashes\n\n### Version\n\n1.0.2\n\n" +
+		"### Code of Conduct\n\n- [X] I agree to follow the Code of Conduct"
+	if len(client.issues) != 1 || client.issues[0].body != wantBody || client.issues[0].title != "[Bug]: Crash on save" {
+		t.Errorf("Unexpected issue %+v", client.issues)
+	}
+	for _, warning := range []string{`"What happened?" is required`, `"I agree to follow the Code of Conduct" must be checked`} {
+		if !strings.Contains(out, warning) {
+			t.Errorf("Expected warning %q, got:\n%s", warning, out)
+		}
+	}
+}
+
+func TestCreateTemplate_MissingRequiredInput(t *testing.T) {
+	client := newTemplateAPI()
+
+	_, err := runRootWithInput(t, client, "", "create", "--template", "bug_report", "--title", "Crash", "--input", "terms=1")
+	if err == nil || !strings.Contains(err.Error(), `"What happened?" is required; set it with --input what-happened=<value>`) {
+		t.Errorf("Expected a required input error, got %v", err)
+	}
+	if len(client.issues) != 0 {
+		t.Errorf("Expected no issue to be created, got %+v", client.issues)
+	}
+}
+
+func TestCreateTemplate_InvalidInputs(t *testing.T) {
+	tests := map[string][]string{
+		`has no input "severity"`:           {"--input", "severity=high"},
+		`invalid --input "version"`:         {"--input", "version"},
+		`"9" is not an option of "Version"`: {"--input", "version=9"},
+	}
+	for want, args := range tests {
+		args = append([]string{"create", "--template", "bug_report", "--title", "Crash"}, args...)
+		if _, err := runRootWithInput(t, newTemplateAPI(), "", args...); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%v: expected %q, got %v", args, want, err)
+		}
+	}
+}
+
+func TestCreateTemplate_Markdown(t *testing.T) {
+	client := newTemplateAPI()
+
+	if _, err := runRootWithInput(t, client, "", "create", "--template", "feature request", "--title", "Dark mode"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := createdIssue{
+		repo:   "acme/api",
+		title:  "[Feature] Dark mode",
+		body:   "**Describe the solution you'd like**\n",
+		labels: []string{"enhancement"},
+	}
+	if len(client.issues) != 1 || !reflect.DeepEqual(client.issues[0], want) {
+		t.Errorf("Expected %+v, got %+v", want, client.issues)
+	}
+
+	_, err := runRootWithInput(t, newTemplateAPI(), "", "create", "--template", "feature_request", "--title", "X", "--input", "a=b")
+	if err == nil || !strings.Contains(err.Error(), "is not an issue form") {
+		t.Errorf("Expected an error for --input, got %v", err)
+	}
+}
+
+func TestCreateTemplate_NotFound(t *testing.T) {
+	_, err := runRootWithInput(t, newTemplateAPI(), "", "create", "--template", "question", "--title", "X")
+	if err == nil || !strings.Contains(err.Error(), "Available templates:\n  bug_report\n  feature_request") {
+		t.Errorf("Expected the available templates, got %v", err)
+	}
+
+	client := newTemplateAPI()
+	client.files = nil
+	_, err = runRootWithInput(t, client, "", "create", "--template", "question", "--title", "X")
+	if err == nil || !strings.Contains(err.Error(), "has no templates in .github/ISSUE_TEMPLATE") {
+		t.Errorf("Expected a no templates error, got %v", err)
+	}
+}
+
+func TestCreateTemplate_LocalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bug.yml")
+	if err := os.WriteFile(path, []byte(bugReportForm), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client := newTemplateAPI()
+	client.files = nil
+
+	if _, err := runRootWithInput(t, client, "", "create", "--template", path, "--title", "Crash", "--input", "what-happened=Boom", "--input", "terms=1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.issues) != 1 || !strings.Contains(client.issues[0].body, "Boom") {
+		t.Errorf("Expected the issue from the local form, got %+v", client.issues)
+	}
+}
+
+func TestCreate_InputRequiresTemplate(t *testing.T) {
+	_, err := runRootWithInput(t, newTemplateAPI(), "", "create", "--title", "X", "--input", "a=b")
+	if err == nil || !strings.Contains(err.Error(), "--input requires --template") {
+		t.Errorf("Expected an error, got %v", err)
+	}
+}
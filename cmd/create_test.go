@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
 )
 
 func TestCreateCommand_Exists(t *testing.T) {
@@ -706,3 +709,42 @@ defaults:
 		t.Errorf("Expected to pass config validation with defaults, got: %v", err)
 	}
 }
+
+func TestAddIssueToProjects_ResolvesEachProject(t *testing.T) {
+	cfg := newProfilesConfig()
+	roadmap, err := cfg.ForProject("roadmap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &mockTriageClient{
+		project:            &api.Project{ID: "PVT_1"},
+		addToProjectItemID: "ITEM_1",
+	}
+
+	if err := addIssueToProjects(client, "ISSUE_1", []*config.Config{cfg, roadmap}, "", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(client.setFieldCalls) != 2 {
+		t.Fatalf("Expected 2 field updates, got %v", client.setFieldCalls)
+	}
+	if client.setFieldCalls[0].value != "Todo" || client.setFieldCalls[1].value != "Later" {
+		t.Errorf("Expected each project's default status (Todo, Later), got %v", client.setFieldCalls)
+	}
+}
+
+func TestAddIssueToProjects_ExplicitStatusUsesEachProjectsAliases(t *testing.T) {
+	cfg := newProfilesConfig()
+	roadmap, err := cfg.ForProject("roadmap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &mockTriageClient{project: &api.Project{ID: "PVT_1"}, addToProjectItemID: "ITEM_1"}
+
+	if err := addIssueToProjects(client, "ISSUE_1", []*config.Config{roadmap}, "later", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.setFieldCalls) != 1 || client.setFieldCalls[0].value != "Later" {
+		t.Errorf("Expected status 'Later', got %v", client.setFieldCalls)
+	}
+}
@@ -0,0 +1,677 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type editOptions struct {
+	title           string
+	body            string
+	bodyFile        string
+	addLabels       []string
+	removeLabels    []string
+	addAssignees    []string
+	removeAssignees []string
+	milestone       string
+	removeMilestone bool
+	status          string
+	priority        string
+	fields          []string
+	editor          bool
+	dryRun          bool
+}
+
+// editClient is the part of the API client used by edit
+type editClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	GetProject(owner string, number int) (*api.Project, error)
+	GetProjectItems(projectID string, filter *api.ProjectItemsFilter) ([]api.ProjectItem, error)
+	SetProjectItemField(projectID, itemID, fieldName, value string) error
+	ClearProjectItemField(projectID, itemID, fieldName string) error
+	EditIssue(issue *api.Issue, edit api.IssueEdit) error
+	userClient
+}
+
+func newEditCommand() *cobra.Command {
+	opts := &editOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit <issue>",
+		Short: "Edit an issue and its project fields",
+		Long: `Edit an issue's title, body, labels, assignees, and milestone, and its
+project fields, in one command.
+
+Issue changes are made with GitHub's issue mutations; status, priority, and
+other project fields are set on the issue's project item, resolving config
+aliases as move does. Only values that change are sent.
+
+With --editor, the issue opens in $EDITOR as a document whose YAML front
+matter holds every editable field, followed by the body. Flags given
+alongside are applied to the document before it opens. Saving the document
+applies the differences.
+
+Use --dry-run to print the changes as a diff without making them.`,
+		Example: `  # Retitle an issue and move it to In Progress
+  gh pmu edit 42 --title "Login screen" --status in_progress
+
+  # Swap labels and assign yourself
+  gh pmu edit 42 --add-label bug --remove-label triage --add-assignee @me
+
+  # Replace the body with a file, previewing the change first
+  gh pmu edit 42 --body-file notes.md --dry-run
+
+  # Edit everything in $EDITOR
+  gh pmu edit 42 --editor`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEdit(cmd, args, opts)
+		},
+	}
+	needsConfig(cmd)
+
+	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Set the issue title")
+	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Set the issue body")
+	cmd.Flags().StringVarP(&opts.bodyFile, "body-file", "F", "", "Read the issue body from `file` (use \"-\" to read from standard input)")
+	cmd.Flags().StringArrayVar(&opts.addLabels, "add-label", nil, "Add labels (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&opts.removeLabels, "remove-label", nil, "Remove labels (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&opts.addAssignees, "add-assignee", nil, "Assign users by `login`, @me, or @org/team (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&opts.removeAssignees, "remove-assignee", nil, "Unassign users by `login`, @me, or @org/team (can be specified multiple times)")
+	cmd.Flags().StringVarP(&opts.milestone, "milestone", "m", "", "Set the milestone (title or number)")
+	cmd.Flags().BoolVar(&opts.removeMilestone, "remove-milestone", false, "Remove the milestone")
+	cmd.Flags().StringVarP(&opts.status, "status", "s", "", "Set project status field")
+	cmd.Flags().StringVarP(&opts.priority, "priority", "p", "", "Set project priority field")
+	cmd.Flags().StringArrayVar(&opts.fields, "field", nil, "Set a project field as `name=value` (can be specified multiple times)")
+	cmd.Flags().BoolVarP(&opts.editor, "editor", "e", false, "Edit the issue and its fields in $EDITOR")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the changes as a diff without making them")
+
+	return cmd
+}
+
+func runEdit(cmd *cobra.Command, args []string, opts *editOptions) error {
+	cc, err := commandContext(cmd)
+	if err != nil {
+		return err
+	}
+	return runEditWithDeps(cmd, args, opts, cc.cfg, cc.client)
+}
+
+// issueDocument holds an issue's editable values. With --editor it is
+// written as YAML front matter followed by the body.
+type issueDocument struct {
+	Title     string            `yaml:"title"`
+	Labels    []string          `yaml:"labels,flow"`
+	Assignees []string          `yaml:"assignees,flow"`
+	Milestone string            `yaml:"milestone"`
+	Status    string            `yaml:"status"`
+	Priority  string            `yaml:"priority"`
+	Fields    map[string]string `yaml:"fields,omitempty"`
+	Body      string            `yaml:"-"`
+}
+
+// issueDocumentHeader introduces the front matter opened with --editor
+const issueDocumentHeader = "# Edit the fields and the body below, then save and close the editor.\n" +
+	"# Status, priority, and fields are project fields; config aliases are accepted.\n" +
+	"# Empty a project field's value to clear it.\n"
+
+// runEditWithDeps is the testable implementation of runEdit
+func runEditWithDeps(cmd *cobra.Command, args []string, opts *editOptions, cfg *config.Config, client editClient) error {
+	out := cmd.OutOrStdout()
+	flags := cmd.Flags()
+
+	if flags.Changed("body") && opts.bodyFile != "" {
+		return fmt.Errorf("cannot use --body with --body-file")
+	}
+	if opts.milestone != "" && opts.removeMilestone {
+		return fmt.Errorf("cannot use --milestone with --remove-milestone")
+	}
+	if !opts.editor && !flags.Changed("title") && !flags.Changed("body") && opts.bodyFile == "" &&
+		len(opts.addLabels)+len(opts.removeLabels)+len(opts.addAssignees)+len(opts.removeAssignees)+len(opts.fields) == 0 &&
+		opts.milestone == "" && !opts.removeMilestone && opts.status == "" && opts.priority == "" {
+		return fmt.Errorf("nothing to edit: use flags such as --title and --status, or --editor")
+	}
+	if flags.Changed("title") && strings.TrimSpace(opts.title) == "" {
+		return fmt.Errorf("--title cannot be empty")
+	}
+
+	owner, repo, number, err := parseIssueReference(args[0])
+	if err != nil {
+		return err
+	}
+	if owner == "" || repo == "" {
+		if owner, repo, err = defaultRepository(cfg); err != nil {
+			return err
+		}
+	}
+
+	issue, err := client.GetIssue(owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	items, err := client.GetProjectItems(project.ID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get project items: %w", err)
+	}
+	var item *api.ProjectItem
+	for i := range items {
+		if it := items[i].Issue; it != nil && it.Number == number &&
+			strings.EqualFold(it.Repository.Owner, owner) && strings.EqualFold(it.Repository.Name, repo) {
+			item = &items[i]
+			break
+		}
+	}
+
+	current := currentIssueDocument(issue, item)
+	edited, err := applyEditFlags(cmd, opts, cfg, client, current)
+	if err != nil {
+		return err
+	}
+
+	resolveDocumentFields(cfg, edited)
+
+	if opts.editor {
+		text, err := renderIssueDocument(edited, true)
+		if err != nil {
+			return err
+		}
+		if text, err = editText(text); err != nil {
+			return err
+		}
+		if edited, err = parseIssueDocument(text); err != nil {
+			return fmt.Errorf("%w\nNo changes were made", err)
+		}
+		if strings.TrimRight(edited.Body, "\n") == strings.TrimRight(current.Body, "\n") {
+			edited.Body = current.Body
+		}
+		if strings.TrimSpace(edited.Title) == "" {
+			return fmt.Errorf("the title cannot be empty\nNo changes were made")
+		}
+		if edited.Assignees, err = resolveLogins(client, edited.Assignees); err != nil {
+			return err
+		}
+		resolveDocumentFields(cfg, edited)
+	}
+
+	edit, fieldChanges := issueChanges(current, edited)
+	if err := validateLogins(client, edit.AddAssignees); err != nil {
+		return err
+	}
+	if len(fieldChanges) > 0 && item == nil {
+		return fmt.Errorf("issue #%d is not in the project, so its project fields cannot be set", number)
+	}
+
+	if edit.IsEmpty() && len(fieldChanges) == 0 {
+		fmt.Fprintf(out, "No changes to issue #%d\n", number)
+		return nil
+	}
+
+	if opts.dryRun {
+		fmt.Fprintln(out, "Dry run - no changes will be made")
+		fmt.Fprintln(out)
+		before, err := renderIssueDocument(current, false)
+		if err != nil {
+			return err
+		}
+		after, err := renderIssueDocument(edited, false)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "--- %s/%s#%d\n+++ %s/%s#%d (edited)\n", owner, repo, number, owner, repo, number)
+		for _, line := range diffLines(before, after, 2) {
+			fmt.Fprintln(out, line)
+		}
+		return nil
+	}
+
+	if !edit.IsEmpty() {
+		if err := client.EditIssue(issue, edit); err != nil {
+			return err
+		}
+	}
+	var applied []projectFieldChange
+	var failed []string
+	for _, change := range fieldChanges {
+		if change.value == "" {
+			err = client.ClearProjectItemField(project.ID, item.ID, change.field)
+		} else {
+			err = client.SetProjectItemField(project.ID, item.ID, change.field, change.value)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to set %s: %v\n", change.field, err)
+			failed = append(failed, change.field)
+			continue
+		}
+		applied = append(applied, change)
+	}
+
+	title := issue.Title
+	if edit.Title != nil {
+		title = *edit.Title
+	}
+	fmt.Fprintf(out, "✓ Updated issue #%d: %s\n", number, title)
+	for _, desc := range describeIssueChanges(current, edited, edit, applied) {
+		fmt.Fprintf(out, "  • %s\n", desc)
+	}
+	link := issue.URL
+	if link == "" {
+		link = issueURL(cfg.Host, owner, repo, number)
+	}
+	fmt.Fprintf(out, "🔗 %s\n", link)
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to set %s on issue #%d", strings.Join(failed, ", "), number)
+	}
+	return nil
+}
+
+// currentIssueDocument returns the issue's editable values, with its project
+// fields when it has a project item
+func currentIssueDocument(issue *api.Issue, item *api.ProjectItem) *issueDocument {
+	doc := &issueDocument{Title: issue.Title, Body: issue.Body, Labels: []string{}, Assignees: []string{}}
+	for _, label := range issue.Labels {
+		doc.Labels = append(doc.Labels, label.Name)
+	}
+	for _, assignee := range issue.Assignees {
+		doc.Assignees = append(doc.Assignees, assignee.Login)
+	}
+	if issue.Milestone != nil {
+		doc.Milestone = issue.Milestone.Title
+	}
+	if item == nil {
+		return doc
+	}
+	for _, fv := range item.FieldValues {
+		switch fv.Field {
+		case "Status":
+			doc.Status = fv.Value
+		case "Priority":
+			doc.Priority = fv.Value
+		case "Title":
+			// The issue's own title
+		default:
+			if doc.Fields == nil {
+				doc.Fields = make(map[string]string)
+			}
+			doc.Fields[fv.Field] = fv.Value
+		}
+	}
+	return doc
+}
+
+// applyEditFlags returns a copy of current with the flags applied
+func applyEditFlags(cmd *cobra.Command, opts *editOptions, cfg *config.Config, client editClient, current *issueDocument) (*issueDocument, error) {
+	edited := *current
+	edited.Labels = append([]string{}, current.Labels...)
+	edited.Assignees = append([]string{}, current.Assignees...)
+	edited.Fields = make(map[string]string)
+	for key, value := range current.Fields {
+		edited.Fields[key] = value
+	}
+
+	if cmd.Flags().Changed("title") {
+		edited.Title = opts.title
+	}
+	if cmd.Flags().Changed("body") {
+		edited.Body = opts.body
+	}
+	if opts.bodyFile != "" {
+		body, err := readBodyFile(cmd, opts.bodyFile)
+		if err != nil {
+			return nil, err
+		}
+		edited.Body = body
+	}
+
+	edited.Labels = removeFold(edited.Labels, opts.removeLabels)
+	for _, label := range opts.addLabels {
+		if !containsFold(edited.Labels, label) {
+			edited.Labels = append(edited.Labels, label)
+		}
+	}
+
+	removed, err := resolveLogins(client, opts.removeAssignees)
+	if err != nil {
+		return nil, err
+	}
+	added, err := resolveLogins(client, opts.addAssignees)
+	if err != nil {
+		return nil, err
+	}
+	edited.Assignees = removeFold(edited.Assignees, removed)
+	for _, login := range added {
+		if !containsFold(edited.Assignees, login) {
+			edited.Assignees = append(edited.Assignees, login)
+		}
+	}
+
+	if opts.milestone != "" {
+		edited.Milestone = opts.milestone
+	}
+	if opts.removeMilestone {
+		edited.Milestone = ""
+	}
+	if opts.status != "" {
+		edited.Status = opts.status
+	}
+	if opts.priority != "" {
+		edited.Priority = opts.priority
+	}
+	for _, field := range opts.fields {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid --field %q: expected name=value", field)
+		}
+		edited.Fields[strings.TrimSpace(name)] = value
+	}
+	if len(edited.Fields) == 0 {
+		edited.Fields = nil
+	}
+
+	return &edited, nil
+}
+
+// readBodyFile reads the body given with --body-file, from standard input
+// when path is "-"
+func readBodyFile(cmd *cobra.Command, path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", fmt.Errorf("failed to read the body from standard input: %w", err)
+		}
+		return string(data), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read body file: %w", err)
+	}
+	return string(data), nil
+}
+
+// resolveDocumentFields replaces aliases in the project fields with their
+// values and field keys with field names
+func resolveDocumentFields(cfg *config.Config, doc *issueDocument) {
+	if doc.Status != "" {
+		doc.Status = cfg.ResolveFieldValue("status", doc.Status)
+	}
+	if doc.Priority != "" {
+		doc.Priority = cfg.ResolveFieldValue("priority", doc.Priority)
+	}
+	if len(doc.Fields) == 0 {
+		return
+	}
+	fields := make(map[string]string)
+	for key, value := range doc.Fields {
+		fields[cfg.GetFieldName(key)] = cfg.ResolveFieldValue(key, value)
+	}
+	doc.Fields = fields
+}
+
+// renderIssueDocument writes doc as YAML front matter followed by the body,
+// with the editing instructions when header is set
+func renderIssueDocument(doc *issueDocument, header bool) (string, error) {
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to write the issue document: %w", err)
+	}
+	text := "---\n"
+	if header {
+		text += issueDocumentHeader
+	}
+	return text + string(data) + "---\n" + doc.Body, nil
+}
+
+// parseIssueDocument reads a document written by renderIssueDocument and
+// edited
+func parseIssueDocument(text string) (*issueDocument, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return nil, fmt.Errorf("the edited issue must start with --- and its front matter")
+	}
+	frontMatter, body, found := strings.Cut(rest, "\n---\n")
+	if !found {
+		frontMatter, found = strings.CutSuffix(rest, "\n---")
+		if !found {
+			return nil, fmt.Errorf("the edited issue's front matter is not closed with ---")
+		}
+	}
+
+	doc := &issueDocument{}
+	dec := yaml.NewDecoder(strings.NewReader(frontMatter))
+	dec.KnownFields(true)
+	if err := dec.Decode(doc); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid front matter: %w", err)
+	}
+	doc.Body = body
+	if doc.Labels == nil {
+		doc.Labels = []string{}
+	}
+	if doc.Assignees == nil {
+		doc.Assignees = []string{}
+	}
+	return doc, nil
+}
+
+// projectFieldChange is a project field to set on the issue's item
+type projectFieldChange struct {
+	field string
+	value string
+}
+
+// issueChanges compares the current and edited documents, returning the
+// issue edit and the project fields to set. Fields emptied or removed in the
+// edited document are cleared, which their change marks with an empty value.
+func issueChanges(current, edited *issueDocument) (api.IssueEdit, []projectFieldChange) {
+	var edit api.IssueEdit
+	if edited.Title != current.Title {
+		edit.Title = &edited.Title
+	}
+	if edited.Body != current.Body {
+		edit.Body = &edited.Body
+	}
+	if !strings.EqualFold(edited.Milestone, current.Milestone) {
+		edit.Milestone = &edited.Milestone
+	}
+	edit.AddLabels = removeFold(edited.Labels, current.Labels)
+	edit.RemoveLabels = removeFold(current.Labels, edited.Labels)
+	edit.AddAssignees = removeFold(edited.Assignees, current.Assignees)
+	edit.RemoveAssignees = removeFold(current.Assignees, edited.Assignees)
+
+	var changes []projectFieldChange
+	if edited.Status != current.Status {
+		changes = append(changes, projectFieldChange{"Status", edited.Status})
+	}
+	if edited.Priority != current.Priority {
+		changes = append(changes, projectFieldChange{"Priority", edited.Priority})
+	}
+	var names []string
+	for name := range edited.Fields {
+		names = append(names, name)
+	}
+	for name := range current.Fields {
+		if _, ok := edited.Fields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if value := edited.Fields[name]; value != current.Fields[name] {
+			changes = append(changes, projectFieldChange{name, value})
+		}
+	}
+	return edit, changes
+}
+
+// describeIssueChanges summarizes the changes for the output after editing
+func describeIssueChanges(current, edited *issueDocument, edit api.IssueEdit, fieldChanges []projectFieldChange) []string {
+	var descs []string
+	if edit.Title != nil {
+		descs = append(descs, fmt.Sprintf("Title → %s", edited.Title))
+	}
+	if edit.Body != nil {
+		descs = append(descs, "Body updated")
+	}
+	if edit.Milestone != nil {
+		if edited.Milestone == "" {
+			descs = append(descs, "Milestone removed")
+		} else {
+			descs = append(descs, fmt.Sprintf("Milestone → %s", edited.Milestone))
+		}
+	}
+	if desc := describeListChange("Labels", edit.AddLabels, edit.RemoveLabels); desc != "" {
+		descs = append(descs, desc)
+	}
+	if desc := describeListChange("Assignees", edit.AddAssignees, edit.RemoveAssignees); desc != "" {
+		descs = append(descs, desc)
+	}
+	for _, change := range fieldChanges {
+		if change.value == "" {
+			descs = append(descs, fmt.Sprintf("%s cleared", change.field))
+		} else {
+			descs = append(descs, fmt.Sprintf("%s → %s", change.field, change.value))
+		}
+	}
+	return descs
+}
+
+func describeListChange(name string, added, removed []string) string {
+	var parts []string
+	for _, value := range added {
+		parts = append(parts, "+"+value)
+	}
+	for _, value := range removed {
+		parts = append(parts, "-"+value)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return name + ": " + strings.Join(parts, ", ")
+}
+
+// removeFold returns the values of list not in remove, ignoring case
+func removeFold(list, remove []string) []string {
+	var kept []string
+	for _, value := range list {
+		if !containsFold(remove, value) {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
+
+// diffLines returns a line diff of a and b: unchanged lines start with a
+// space, removed lines with -, and added lines with +. Only context lines
+// around changes are kept; skipped lines are marked with @@.
+func diffLines(a, b string, context int) []string {
+	x := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	y := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+
+	// Only the lines between the common prefix and suffix need diffing
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix && x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+
+	var all []string
+	for _, line := range x[:prefix] {
+		all = append(all, " "+line)
+	}
+	all = append(all, diffChanged(x[prefix:len(x)-suffix], y[prefix:len(y)-suffix])...)
+	for _, line := range x[len(x)-suffix:] {
+		all = append(all, " "+line)
+	}
+
+	keep := make([]bool, len(all))
+	for n, line := range all {
+		if line[0] == ' ' {
+			continue
+		}
+		for k := max(0, n-context); k <= min(len(all)-1, n+context); k++ {
+			keep[k] = true
+		}
+	}
+	var lines []string
+	skipped := false
+	for n, line := range all {
+		if !keep[n] {
+			skipped = true
+			continue
+		}
+		if skipped && len(lines) > 0 {
+			lines = append(lines, "@@")
+		}
+		skipped = false
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// maxDiffCells bounds the table diffChanged builds, so a large rewrite of a
+// long body does not need hundreds of MB
+const maxDiffCells = 1 << 20
+
+// diffChanged diffs the changed lines between diffLines' common prefix and
+// suffix. When x and y are too long to compare line by line, all of x is
+// shown replaced by y.
+func diffChanged(x, y []string) []string {
+	var all []string
+	if (len(x)+1)*(len(y)+1) > maxDiffCells {
+		for _, line := range x {
+			all = append(all, "-"+line)
+		}
+		for _, line := range y {
+			all = append(all, "+"+line)
+		}
+		return all
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			all = append(all, " "+x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			all = append(all, "-"+x[i])
+			i++
+		default:
+			all = append(all, "+"+y[j])
+			j++
+		}
+	}
+	return all
+}
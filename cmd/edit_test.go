@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+)
+
+// editAPI is the fake API for edit. Issue #1 is in the project with a label,
+// an assignee, and a milestone; issue #3 is not in the project.
+type editAPI struct {
+	*userAPI
+	edits    []api.IssueEdit
+	fields   []string // "field=value" set on project items, "field=" when cleared
+	failures map[string]error
+}
+
+func newEditAPI() *editAPI {
+	f := &editAPI{userAPI: newUserAPI()}
+	login := f.issues[1]
+	login.Body = "Old body\nSecond line"
+	login.URL = "https://github.com/acme/api/issues/1"
+	login.Labels = []api.Label{{Name: "triage"}}
+	login.Assignees = []api.Actor{{Login: "bob"}}
+	login.Milestone = &api.Milestone{Title: "v1"}
+	f.items[0].FieldValues = append(f.items[0].FieldValues, api.FieldValue{Field: "Estimate", Value: "3"})
+	f.issues[3] = &api.Issue{ID: "I3", Number: 3, Title: "Untracked", Repository: api.Repository{Owner: "acme", Name: "api"}}
+	return f
+}
+
+func (f *editAPI) EditIssue(issue *api.Issue, edit api.IssueEdit) error {
+	f.edits = append(f.edits, edit)
+	return nil
+}
+
+func (f *editAPI) SetProjectItemField(projectID, itemID, fieldName, value string) error {
+	if err := f.failures[fieldName]; err != nil {
+		return err
+	}
+	f.fields = append(f.fields, fieldName+"="+value)
+	return nil
+}
+
+func (f *editAPI) ClearProjectItemField(projectID, itemID, fieldName string) error {
+	return f.SetProjectItemField(projectID, itemID, fieldName, "")
+}
+
+func TestEdit_Flags(t *testing.T) {
+	client := newEditAPI()
+
+	out, err := runRootWith(t, client, "edit", "1", "--title", "Login screen", "--add-label", "bug", "--remove-label", "TRIAGE",
+		"--add-assignee", "@me", "--remove-milestone", "--status", "done", "--field", "Estimate=5")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(client.edits) != 1 {
+		t.Fatalf("Expected one edit, got %+v", client.edits)
+	}
+	edit := client.edits[0]
+	if edit.Title == nil || *edit.Title != "Login screen" || edit.Body != nil || edit.Milestone == nil || *edit.Milestone != "" {
+		t.Errorf("Unexpected edit %+v", edit)
+	}
+	if !reflect.DeepEqual(edit.AddLabels, []string{"bug"}) || !reflect.DeepEqual(edit.RemoveLabels, []string{"triage"}) ||
+		!reflect.DeepEqual(edit.AddAssignees, []string{"octocat"}) || len(edit.RemoveAssignees) != 0 {
+		t.Errorf("Unexpected label and assignee changes %+v", edit)
+	}
+	if want := []string{"Status=Done", "Estimate=5"}; !reflect.DeepEqual(client.fields, want) {
+		t.Errorf("Expected fields %v, got %v", want, client.fields)
+	}
+	for _, want := range []string{"✓ Updated issue #1: Login screen", "Labels: +bug, -triage", "Milestone removed", "Status → Done"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestEdit_DryRunDiff(t *testing.T) {
+	client := newEditAPI()
+
+	out, err := runRootWithInput(t, client, "New body\nSecond line", "edit", "1", "--body-file", "-", "--priority", "P1", "--dry-run")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.edits) != 0 || len(client.fields) != 0 {
+		t.Errorf("Expected no changes, got %+v %v", client.edits, client.fields)
+	}
+	for _, want := range []string{"Dry run", "--- acme/api#1", `-priority: ""`, "+priority: P1", "-Old body", "+New body", " Second line"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "title:") {
+		t.Errorf("Expected unchanged lines far from changes to be left out:\n%s", out)
+	}
+}
+
+func TestEdit_Editor(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("needs /bin/sh")
+	}
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	edit := `sed -e 's/^title: .*/title: Edited title/' -e 's/^status: .*/status: done/' -e 's/^assignees: .*/assignees: [alice]/' -e 's/^\( *Estimate:\).*/\1 ""/' "$1" > "$1.new" && mv "$1.new" "$1"`
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"+edit+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", script)
+
+	client := newEditAPI()
+	out, err := runRootWith(t, client, "edit", "1", "--editor", "--add-label", "bug")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, out)
+	}
+
+	if len(client.edits) != 1 {
+		t.Fatalf("Expected one edit, got %+v", client.edits)
+	}
+	edit0 := client.edits[0]
+	if edit0.Title == nil || *edit0.Title != "Edited title" || edit0.Body != nil || edit0.Milestone != nil {
+		t.Errorf("Unexpected edit %+v", edit0)
+	}
+	if !reflect.DeepEqual(edit0.AddLabels, []string{"bug"}) || !reflect.DeepEqual(edit0.AddAssignees, []string{"alice"}) ||
+		!reflect.DeepEqual(edit0.RemoveAssignees, []string{"bob"}) {
+		t.Errorf("Expected the flag and editor changes, got %+v", edit0)
+	}
+	if want := []string{"Status=Done", "Estimate="}; !reflect.DeepEqual(client.fields, want) {
+		t.Errorf("Expected the status to be set and the estimate cleared, got %v", client.fields)
+	}
+	if !strings.Contains(out, "Estimate cleared") {
+		t.Errorf("Expected the cleared field in:\n%s", out)
+	}
+}
+
+func TestEdit_FieldFailure(t *testing.T) {
+	client := newEditAPI()
+	client.failures = map[string]error{"Estimate": fmt.Errorf("invalid number")}
+
+	out, err := runRootWith(t, client, "edit", "1", "--status", "done", "--field", "Estimate=lots")
+	if err == nil || !strings.Contains(err.Error(), "failed to set Estimate on issue #1") {
+		t.Fatalf("Expected the failed field to be reported, got %v", err)
+	}
+	if !reflect.DeepEqual(client.fields, []string{"Status=Done"}) {
+		t.Errorf("Expected the status to still be set, got %v", client.fields)
+	}
+	if !strings.Contains(out, "Status → Done") || strings.Contains(out, "Estimate →") {
+		t.Errorf("Expected only the applied change in:\n%s", out)
+	}
+}
+
+func TestEdit_NoChanges(t *testing.T) {
+	client := newEditAPI()
+
+	out, err := runRootWith(t, client, "edit", "1", "--status", "todo", "--add-label", "triage")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "No changes to issue #1") || len(client.edits) != 0 || len(client.fields) != 0 {
+		t.Errorf("Expected no changes, got %q %+v %v", out, client.edits, client.fields)
+	}
+}
+
+func TestEdit_Errors(t *testing.T) {
+	tests := map[string][]string{
+		"nothing to edit":                      {"edit", "1"},
+		"cannot use --body with --body-file":   {"edit", "1", "--body", "x", "--body-file", "notes.md"},
+		"cannot use --milestone with --remove": {"edit", "1", "--milestone", "v2", "--remove-milestone"},
+		"--title cannot be empty":              {"edit", "1", "--title", " "},
+		`invalid --field "Estimate"`:           {"edit", "1", "--field", "Estimate"},
+		`unknown user "ghost"`:                 {"edit", "1", "--add-assignee", "ghost"},
+		"#3 is not in the project":             {"edit", "3", "--status", "done"},
+		"failed to read body file":             {"edit", "1", "--body-file", filepath.Join(t.TempDir(), "missing.md")},
+	}
+	for want, args := range tests {
+		client := newEditAPI()
+		if _, err := runRootWith(t, client, args...); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%v: expected %q, got %v", args, want, err)
+		}
+		if len(client.edits) != 0 {
+			t.Errorf("%v: expected no edits, got %+v", args, client.edits)
+		}
+	}
+}
+
+func TestIssueDocument_RoundTrip(t *testing.T) {
+	doc := &issueDocument{
+		Title: "Login: page", Labels: []string{"bug", "ui"}, Assignees: []string{},
+		Status: "Todo", Fields: map[string]string{"Estimate": "3"}, Body: "---\nBody with a rule\n",
+	}
+	text, err := renderIssueDocument(doc, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(text, "labels: [bug, ui]\nassignees: []\n") {
+		t.Errorf("Expected flow lists, got:\n%s", text)
+	}
+
+	parsed, err := parseIssueDocument(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, doc) {
+		t.Errorf("Expected %+v, got %+v", doc, parsed)
+	}
+
+	for _, text := range []string{"title: x\n", "---\ntitle: x\n", "---\nlabel: [x]\n---\n"} {
+		if _, err := parseIssueDocument(text); err == nil {
+			t.Errorf("Expected an error for %q", text)
+		}
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\n"
+	b := "a\nB\nc\nd\ne\nf\ng\nH\n"
+	want := []string{" a", "-b", "+B", " c", " d", "@@", " f", " g", "-h", "+H"}
+	if got := diffLines(a, b, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got := diffLines(a, a, 2); len(got) != 0 {
+		t.Errorf("Expected no diff, got %q", got)
+	}
+
+	// Only the lines between the common prefix and suffix are compared
+	same := strings.Repeat("x\n", 5000)
+	got := diffLines(same+"old\n"+same, same+"new\n"+same, 1)
+	if want := []string{" x", "-old", "+new", " x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// Rewrites too long to compare line by line replace the old lines
+	var old, rewritten strings.Builder
+	for n := 0; n < 1100; n++ {
+		fmt.Fprintf(&old, "a%d\n", n)
+		fmt.Fprintf(&rewritten, "b%d\n", n)
+	}
+	got = diffLines("x\n"+old.String(), "x\n"+rewritten.String(), 1)
+	if len(got) != 2201 || got[0] != " x" || got[1] != "-a0" || got[1100] != "-a1099" || got[1101] != "+b0" {
+		t.Errorf("Unexpected diff of %d lines starting %q", len(got), got[:min(len(got), 3)])
+	}
+}
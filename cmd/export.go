@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/cli/go-gh/v2/pkg/template"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+// jsonFieldsAnnotation lists the JSON fields a command exports, so that
+// "--json a,b" can be told apart from a positional argument
+const jsonFieldsAnnotation = "gh-pmu/json-fields"
+
+// wordArgsAnnotation marks commands whose positional arguments can be plain
+// words, such as triage config names, so that a single unknown word after
+// --json is left to them as an argument
+const wordArgsAnnotation = "gh-pmu/word-args"
+
+// allJSONFields is the value of a bare --json flag
+const allJSONFields = "all"
+
+// exportOptions holds the --json, --jq, and --template flags shared by
+// commands with JSON output.
+//
+// A command's JSON output is an object (or array) holding records, such as
+// the "items" of list. --json selects fields of those records, --jq filters
+// the whole output with a jq expression, and --template formats it with a Go
+// template using gh's helpers (tablerow, timeago, truncate, pluck, ...).
+type exportOptions struct {
+	json       bool
+	fields     []string
+	jq         string
+	template   string
+	recordKeys []string // keys holding records; none when the output is the record
+	available  []string
+}
+
+// addExportFlags adds --json, --jq, and --template to cmd. record is the
+// struct whose JSON fields can be selected; recordKeys name the keys of the
+// output that hold records, or none when the output itself is the record.
+func addExportFlags(cmd *cobra.Command, record interface{}, recordKeys ...string) *exportOptions {
+	opts := &exportOptions{
+		recordKeys: recordKeys,
+		available:  jsonFieldNames(record),
+	}
+
+	cmd.Flags().Var(&jsonFieldsValue{opts: opts}, "json", "Output JSON with the specified `fields` (all fields when none are given)")
+	cmd.Flags().Lookup("json").NoOptDefVal = allJSONFields
+	cmd.Flags().StringVar(&opts.jq, "jq", "", "Filter JSON output using a jq `expression`")
+	cmd.Flags().StringVar(&opts.template, "template", "", "Format JSON output using a Go template; see \"gh help formatting\"")
+
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[jsonFieldsAnnotation] = strings.Join(opts.available, ",")
+
+	// Check the flags before the command makes any API calls
+	preRunE := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.validate(); err != nil {
+			return err
+		}
+		if preRunE != nil {
+			return preRunE(cmd, args)
+		}
+		return nil
+	}
+
+	return opts
+}
+
+// takesWordArgs marks cmd as taking plain words as positional arguments
+func takesWordArgs(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[wordArgsAnnotation] = "true"
+}
+
+// enabled reports whether the command should write JSON
+func (o *exportOptions) enabled() bool {
+	return o != nil && (o.json || o.jq != "" || o.template != "")
+}
+
+// validate checks the flag combination and the selected fields
+func (o *exportOptions) validate() error {
+	if o.jq != "" && o.template != "" {
+		return fmt.Errorf("cannot use --jq and --template together")
+	}
+	for _, field := range o.fields {
+		if !containsString(o.available, field) {
+			return fmt.Errorf("unknown JSON field %q\nAvailable fields:\n  %s", field, strings.Join(o.available, "\n  "))
+		}
+	}
+	return nil
+}
+
+// write writes data to the command's output: as indented JSON, filtered by
+// --jq, or formatted by --template, with records reduced to the --json
+// fields. A nil o writes indented JSON. The options are validated before
+// the command runs.
+func (o *exportOptions) write(cmd *cobra.Command, data interface{}) error {
+	if o == nil {
+		o = &exportOptions{}
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if len(o.fields) > 0 {
+		var generic interface{}
+		if err := json.Unmarshal(raw, &generic); err != nil {
+			return err
+		}
+		if raw, err = json.Marshal(o.selectFields(generic)); err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	switch {
+	case o.jq != "":
+		return jq.Evaluate(bytes.NewReader(raw), out, o.jq)
+	case o.template != "":
+		width := 80
+		terminal := term.FromEnv()
+		if w, _, err := terminal.Size(); err == nil && w > 0 {
+			width = w
+		}
+		t := template.New(out, width, terminal.IsColorEnabled())
+		if err := t.Parse(o.template); err != nil {
+			return err
+		}
+		if err := t.Execute(bytes.NewReader(raw)); err != nil {
+			return err
+		}
+		return t.Flush()
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, raw, "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+	_, err = out.Write(indented.Bytes())
+	return err
+}
+
+// selectFields reduces the records in data to the selected fields
+func (o *exportOptions) selectFields(data interface{}) interface{} {
+	if len(o.recordKeys) == 0 {
+		return o.selectRecords(data)
+	}
+	if object, ok := data.(map[string]interface{}); ok {
+		for _, key := range o.recordKeys {
+			if records, ok := object[key]; ok {
+				object[key] = o.selectRecords(records)
+			}
+		}
+	}
+	return data
+}
+
+// selectRecords reduces a record, or an array of records, to the selected fields
+func (o *exportOptions) selectRecords(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = o.selectRecords(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		selected := make(map[string]interface{}, len(o.fields))
+		for _, field := range o.fields {
+			if fieldValue, ok := v[field]; ok {
+				selected[field] = fieldValue
+			} else {
+				selected[field] = nil
+			}
+		}
+		return selected
+	}
+	return value
+}
+
+// jsonFieldsValue is the pflag.Value of --json
+type jsonFieldsValue struct {
+	opts *exportOptions
+}
+
+func (v *jsonFieldsValue) String() string {
+	if v.opts == nil || !v.opts.json {
+		return ""
+	}
+	if len(v.opts.fields) == 0 {
+		return allJSONFields
+	}
+	return strings.Join(v.opts.fields, ",")
+}
+
+func (v *jsonFieldsValue) Set(value string) error {
+	v.opts.json = true
+	v.opts.fields = nil
+	if value == allJSONFields {
+		return nil
+	}
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			v.opts.fields = append(v.opts.fields, field)
+		}
+	}
+	return nil
+}
+
+func (v *jsonFieldsValue) Type() string {
+	return "fields"
+}
+
+// jsonFieldNames returns the sorted JSON names of record's fields
+func jsonFieldNames(record interface{}) []string {
+	t := reflect.TypeOf(record)
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normalizeJSONArgs rewrites "--json a,b" as "--json=a,b" when a,b looks
+// like a field list of the command being run. A bare --json takes no value,
+// so without this the field list would be read as a positional argument.
+func normalizeJSONArgs(root *cobra.Command, args []string) []string {
+	target, _, err := root.Find(args)
+	if err != nil || target.Annotations[jsonFieldsAnnotation] == "" {
+		return args
+	}
+	available := strings.Split(target.Annotations[jsonFieldsAnnotation], ",")
+	wordArgs := target.Annotations[wordArgsAnnotation] != ""
+
+	normalized := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			normalized = append(normalized, args[i:]...)
+			break
+		}
+		if arg == "--json" && i+1 < len(args) && looksLikeJSONFields(args[i+1], available, wordArgs) {
+			normalized = append(normalized, "--json="+args[i+1])
+			i++
+			continue
+		}
+		normalized = append(normalized, arg)
+	}
+	return normalized
+}
+
+// jsonFieldPattern matches a name that could be a JSON field
+var jsonFieldPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// looksLikeJSONFields reports whether s is meant as a --json field list: a
+// comma-separated list of names, or a single name. Issue references are
+// never names, so they are left as arguments; with wordArgs a single word
+// that is not an available field is left too, since it may be an argument
+// such as a triage config name. Unknown fields are reported by validate.
+func looksLikeJSONFields(s string, available []string, wordArgs bool) bool {
+	if !strings.Contains(s, ",") {
+		if containsString(available, s) {
+			return true
+		}
+		return !wordArgs && jsonFieldPattern.MatchString(s)
+	}
+	for _, field := range strings.Split(s, ",") {
+		if field = strings.TrimSpace(field); field != "" && !jsonFieldPattern.MatchString(field) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
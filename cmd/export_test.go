@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// runRoot runs the root command against the fake API, with arguments
+// normalized as Execute does
+func runRoot(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return runRootWith(t, newFakeAPI(), args...)
+}
+
+// runRootWith runs the root command against client
+func runRootWith(t *testing.T, client apiClient, args ...string) (string, error) {
+	t.Helper()
+	return runRootSetup(t, client, nil, args...)
+}
+
+// runRootSetup runs the root command against client, letting setup change
+// the command context (such as its configuration) or the root command
+// (such as its input) first
+func runRootSetup(t *testing.T, client apiClient, setup func(root *cobra.Command, cc *cmdContext), args ...string) (string, error) {
+	t.Helper()
+	root := NewRootCommand()
+	buf := new(bytes.Buffer)
+	root.SetOut(buf)
+	root.SetErr(new(bytes.Buffer))
+	cc := newTestCommandContext(client)
+	if setup != nil {
+		setup(root, cc)
+	}
+	root.SetContext(withCommandContext(context.Background(), cc))
+	root.SetArgs(normalizeJSONArgs(root, args))
+	err := root.Execute()
+	return buf.String(), err
+}
+
+func TestExport_BareJSONKeepsFullOutput(t *testing.T) {
+	out, err := runRoot(t, "list", "--json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var result JSONOutput
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, out)
+	}
+	if len(result.Items) != 2 || result.Items[0].URL != "" || result.Items[0].Repository != "acme/api" {
+		t.Errorf("Unexpected output: %+v", result)
+	}
+}
+
+func TestExport_SelectsFields(t *testing.T) {
+	out, err := runRoot(t, "list", "--json", "number,title")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var result struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, out)
+	}
+	want := map[string]interface{}{"number": float64(1), "title": "Login page"}
+	if len(result.Items) != 2 || !reflect.DeepEqual(result.Items[0], want) {
+		t.Errorf("Expected only number and title, got %v", result.Items)
+	}
+}
+
+func TestExport_SelectsFieldsOfSingleRecord(t *testing.T) {
+	out, err := runRoot(t, "view", "--json", "number,state", "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(out) != "{\n  \"number\": 1,\n  \"state\": \"OPEN\"\n}" {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+}
+
+func TestExport_UnknownField(t *testing.T) {
+	_, err := runRoot(t, "list", "--json=number,color")
+	if err == nil || !strings.Contains(err.Error(), `unknown JSON field "color"`) || !strings.Contains(err.Error(), "fieldValues") {
+		t.Errorf("Expected unknown field error listing available fields, got %v", err)
+	}
+}
+
+func TestExport_UnknownFieldBeforeAPICalls(t *testing.T) {
+	// Any API call panics through the nil apiClient
+	client := struct{ apiClient }{}
+
+	tests := map[string][]string{
+		`unknown JSON field "bogus"`:              {"view", "1", "--json", "title,bogus"},
+		`unknown JSON field "titel"`:              {"list", "--json", "titel"},
+		`unknown command "stray"`:                 {"intake", "stray"},
+		"cannot use --jq and --template together": {"list", "--jq", ".", "--template", "{{.}}"},
+	}
+	for want, args := range tests {
+		if _, err := runRootWith(t, client, args...); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%v: expected %q, got %v", args, want, err)
+		}
+	}
+}
+
+func TestExport_JQ(t *testing.T) {
+	out, err := runRoot(t, "list", "--jq", `.items[] | select(.fieldValues.Status == "Done") | .title`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out != "Signup flow\n" {
+		t.Errorf("Expected raw title, got %q", out)
+	}
+}
+
+func TestExport_Template(t *testing.T) {
+	out, err := runRoot(t, "list", "--template", `{{range .items}}#{{.number}} {{.title | truncate 6}}{{"\n"}}{{end}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out != "#1 Log...\n#2 Sig...\n" {
+		t.Errorf("Unexpected template output %q", out)
+	}
+}
+
+func TestExport_JQAndTemplateConflict(t *testing.T) {
+	_, err := runRoot(t, "list", "--jq", ".", "--template", "{{.}}")
+	if err == nil || !strings.Contains(err.Error(), "cannot use --jq and --template together") {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+func TestNormalizeJSONArgs(t *testing.T) {
+	root := NewRootCommand()
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"field list", []string{"list", "--json", "number,title"}, []string{"list", "--json=number,title"}},
+		{"bare flag before argument", []string{"view", "--json", "42"}, []string{"view", "--json", "42"}},
+		{"bare flag before triage config", []string{"triage", "--json", "tracked"}, []string{"triage", "--json", "tracked"}},
+		{"triage field", []string{"triage", "--json", "title"}, []string{"triage", "--json=title"}},
+		{"unknown single field", []string{"list", "--json", "titel"}, []string{"list", "--json=titel"}},
+		{"unknown field before issue", []string{"view", "42", "--json", "titel"}, []string{"view", "42", "--json=titel"}},
+		{"single field", []string{"view", "42", "--json", "title"}, []string{"view", "42", "--json=title"}},
+		{"list with unknown field", []string{"view", "42", "--json", "title,bogus"}, []string{"view", "42", "--json=title,bogus"}},
+		{"bare flag before issue reference", []string{"view", "--json", "acme/api#42"}, []string{"view", "--json", "acme/api#42"}},
+		{"after --", []string{"split", "1", "--", "--json", "title"}, []string{"split", "1", "--", "--json", "title"}},
+		{"command without JSON output", []string{"move", "--json", "title"}, []string{"move", "--json", "title"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeJSONArgs(root, tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeJSONArgs(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportFlags_RecordFields(t *testing.T) {
+	for _, path := range [][]string{{"list"}, {"view"}, {"intake"}, {"triage"}, {"split"}, {"sub", "list"}, {"types", "list"}} {
+		cmd, _, err := NewRootCommand().Find(path)
+		if err != nil {
+			t.Fatalf("Find(%v): %v", path, err)
+		}
+		for _, name := range []string{"json", "jq", "template"} {
+			if cmd.Flags().Lookup(name) == nil {
+				t.Errorf("Expected %v to have --%s", path, name)
+			}
+		}
+		if !strings.Contains(cmd.Annotations[jsonFieldsAnnotation], "title") && path[0] != "types" {
+			t.Errorf("Expected %v to export title, got %q", path, cmd.Annotations[jsonFieldsAnnotation])
+		}
+	}
+}
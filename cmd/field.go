@@ -0,0 +1,666 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	tmpl "github.com/scooter-indie/gh-pmu/internal/template"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newFieldCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "field",
+		Short: "Manage project fields",
+		Long: `Manage the fields and single-select options of the configured project.
+
+After each change the field metadata cached in the nearest .gh-pmu.yml
+is refreshed and the field aliases under 'fields:' are updated to match.`,
+	}
+
+	for _, sub := range []*cobra.Command{newFieldListCommand(), newFieldCreateCommand(), newFieldDeleteCommand()} {
+		needsConfig(sub)
+		cmd.AddCommand(sub)
+	}
+	cmd.AddCommand(newFieldOptionCommand())
+
+	return cmd
+}
+
+func newFieldOptionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "option",
+		Short: "Manage single-select field options",
+		Long: `Add, rename, and reorder the options of a single-select field.
+
+Options keep their IDs, so items set to a renamed or moved option keep it.`,
+	}
+
+	for _, sub := range []*cobra.Command{newFieldOptionAddCommand(), newFieldOptionRenameCommand(), newFieldOptionReorderCommand()} {
+		needsConfig(sub)
+		cmd.AddCommand(sub)
+	}
+
+	return cmd
+}
+
+// fieldClient defines the interface for API methods used by field commands.
+// This allows for easier testing with mock implementations.
+type fieldClient interface {
+	GetProject(owner string, number int) (*api.Project, error)
+	GetProjectFields(projectID string) ([]api.ProjectField, error)
+	CreateProjectField(projectID, name, dataType string, options []api.FieldOption) (*api.ProjectField, error)
+	CreateIterationField(projectID, name, startDate string, duration, count int) (*api.ProjectField, error)
+	DeleteProjectField(fieldID string) error
+	UpdateSingleSelectOptions(fieldID string, options []api.FieldOption) (*api.ProjectField, error)
+}
+
+// fieldContext holds the state shared by field commands
+type fieldContext struct {
+	cfg        *config.Config // effective (layered) configuration
+	file       *config.Config // nearest .gh-pmu.yml, which receives updates
+	configPath string
+	client     fieldClient
+	project    *api.Project
+	fields     []api.ProjectField
+}
+
+// loadFieldContext loads the configuration and the configured project's fields
+func loadFieldContext(cmd *cobra.Command) (*fieldContext, error) {
+	cc, err := commandContext(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	configPath := cc.cfg.Path()
+	if configPath == "" {
+		return nil, fmt.Errorf("no %s to update: field commands need a configuration file", config.ConfigFileName)
+	}
+
+	file, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	return newFieldContext(cc.cfg, file, configPath, cc.client)
+}
+
+// newFieldContext fetches the project and its fields for the given configuration.
+// Changes are written to file, which is saved at configPath.
+func newFieldContext(cfg, file *config.Config, configPath string, client fieldClient) (*fieldContext, error) {
+	project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	fields, err := client.GetProjectFields(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fieldContext{
+		cfg:        cfg,
+		file:       file,
+		configPath: configPath,
+		client:     client,
+		project:    project,
+		fields:     fields,
+	}, nil
+}
+
+// findField returns the project field with the given name (case-insensitive)
+func (fc *fieldContext) findField(name string) (*api.ProjectField, error) {
+	for i := range fc.fields {
+		if strings.EqualFold(fc.fields[i].Name, name) {
+			return &fc.fields[i], nil
+		}
+	}
+	return nil, fmt.Errorf("field %q not found in project", name)
+}
+
+// findSingleSelectField returns a single-select field by name or alias key
+func (fc *fieldContext) findSingleSelectField(name string) (*api.ProjectField, error) {
+	field, err := fc.findField(fc.cfg.GetFieldName(name))
+	if err != nil {
+		return nil, err
+	}
+	if field.DataType != "SINGLE_SELECT" {
+		return nil, fmt.Errorf("field %q is not a single-select field", field.Name)
+	}
+	return field, nil
+}
+
+// editAliases applies an alias change to both the effective configuration
+// and the file being updated. When a named project is selected, the file's
+// aliases for that project are updated.
+func (fc *fieldContext) editAliases(edit func(cfg *config.Config)) {
+	edit(fc.cfg)
+	if fc.file == fc.cfg {
+		return
+	}
+
+	if name := fc.cfg.ProfileName(); name != "" {
+		profile := fc.fileProfile(name)
+		aliases := &config.Config{Fields: profile.Fields}
+		edit(aliases)
+		profile.Fields = aliases.Fields
+		fc.setProfile(name, profile)
+		return
+	}
+	edit(fc.file)
+}
+
+// fileProfile returns a named project from the file being updated. A project
+// defined in another config layer is copied without its aliases.
+func (fc *fieldContext) fileProfile(name string) config.Profile {
+	if profile, ok := fc.file.Projects[name]; ok {
+		return profile
+	}
+	profile := fc.cfg.Projects[name]
+	profile.Fields = nil
+	profile.Metadata = nil
+	return profile
+}
+
+// setProfile stores a named project in the file being updated
+func (fc *fieldContext) setProfile(name string, profile config.Profile) {
+	if fc.file.Projects == nil {
+		fc.file.Projects = make(map[string]config.Profile)
+	}
+	fc.file.Projects[name] = profile
+}
+
+// save refreshes the cached field metadata and writes the configuration file
+func (fc *fieldContext) save() error {
+	fields, err := fc.client.GetProjectFields(fc.project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to refresh field metadata: %w", err)
+	}
+	fc.fields = fields
+	fc.cfg.Metadata = metadataFromFields(fc.project.ID, fields)
+	if name := fc.cfg.ProfileName(); name != "" {
+		profile := fc.fileProfile(name)
+		profile.Metadata = fc.cfg.Metadata
+		fc.setProfile(name, profile)
+	} else {
+		fc.file.Metadata = fc.cfg.Metadata
+	}
+
+	return fc.file.Save(fc.configPath)
+}
+
+// aliasKeysForField returns the alias groups in the config that refer to a field
+func aliasKeysForField(cfg *config.Config, fieldName string) []string {
+	var keys []string
+	for key := range cfg.Fields {
+		if strings.EqualFold(cfg.GetFieldName(key), fieldName) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// aliasKey converts a field or option name to an alias key ("In Progress" -> "in_progress")
+func aliasKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "_"))
+}
+
+// ============================================================================
+// field list
+// ============================================================================
+
+func newFieldListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List project fields",
+		Long:  `List the fields of the configured project with their types and options.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fc, err := loadFieldContext(cmd)
+			if err != nil {
+				return err
+			}
+			return runFieldList(cmd, fc)
+		},
+	}
+}
+
+func runFieldList(cmd *cobra.Command, fc *fieldContext) error {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tOPTIONS")
+
+	for _, f := range fc.fields {
+		var options []string
+		for _, opt := range f.Options {
+			options = append(options, opt.Name)
+		}
+		if f.Iteration != nil {
+			options = append(options, fmt.Sprintf("%d iteration(s), %d days", len(f.Iteration.Iterations), f.Iteration.Duration))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Name, f.DataType, strings.Join(options, ", "))
+	}
+
+	return w.Flush()
+}
+
+// ============================================================================
+// field create
+// ============================================================================
+
+type fieldCreateOptions struct {
+	fieldType string
+	options   []string
+	duration  int
+	startDay  string
+}
+
+func newFieldCreateCommand() *cobra.Command {
+	opts := &fieldCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a project field",
+		Long: `Create a field in the configured project.
+
+Options of single-select fields are given with --option, optionally
+with a color: --option "High:RED".`,
+		Example: `  # Create a number field
+  gh pmu field create Estimate --type number
+
+  # Create a single-select field
+  gh pmu field create Risk --type single_select --option Low:GREEN --option High:RED
+
+  # Create a two-week iteration field starting on Mondays
+  gh pmu field create Sprint --type iteration --duration 14 --start-day monday`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fc, err := loadFieldContext(cmd)
+			if err != nil {
+				return err
+			}
+			return runFieldCreate(cmd, args[0], opts, fc)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.fieldType, "type", "", "Field type: single_select, text, number, date, or iteration (required)")
+	cmd.Flags().StringArrayVar(&opts.options, "option", nil, "Single-select option as Name or Name:COLOR (can be specified multiple times)")
+	cmd.Flags().IntVar(&opts.duration, "duration", defaultIterationDuration, "Iteration length in days")
+	cmd.Flags().StringVar(&opts.startDay, "start-day", "", "Day of the week iterations start (defaults to today)")
+
+	_ = cmd.MarkFlagRequired("type")
+
+	return cmd
+}
+
+func runFieldCreate(cmd *cobra.Command, name string, opts *fieldCreateOptions, fc *fieldContext) error {
+	if _, err := fc.findField(name); err == nil {
+		return fmt.Errorf("field %q already exists", name)
+	}
+
+	fieldType := strings.ToUpper(opts.fieldType)
+	options := parseOptionFlags(opts.options)
+
+	switch fieldType {
+	case "SINGLE_SELECT":
+		if len(options) == 0 {
+			return fmt.Errorf("single_select fields require at least one --option")
+		}
+	case "TEXT", "NUMBER", "DATE", "ITERATION":
+		if len(options) > 0 {
+			return fmt.Errorf("--option is only valid for single_select fields")
+		}
+	default:
+		return fmt.Errorf("invalid field type %q: expected single_select, text, number, date, or iteration", opts.fieldType)
+	}
+
+	var err error
+	if fieldType == "ITERATION" {
+		start := iterationStartDate(time.Now(), opts.startDay)
+		_, err = fc.client.CreateIterationField(fc.project.ID, name, start, opts.duration, defaultIterationCount)
+	} else {
+		_, err = fc.client.CreateProjectField(fc.project.ID, name, fieldType, options)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := fc.save(); err != nil {
+		return err
+	}
+
+	u := ui.New(cmd.OutOrStdout())
+	u.Success(fmt.Sprintf("Created field %q (%s)", name, strings.ToLower(fieldType)))
+	return nil
+}
+
+// parseOptionFlags parses --option values of the form Name or Name:COLOR
+func parseOptionFlags(values []string) []api.FieldOption {
+	var options []api.FieldOption
+	for _, v := range values {
+		opt := api.FieldOption{Name: v}
+		// Only a known color after the last colon is split off, so names like "Act 1: Setup" survive
+		if idx := strings.LastIndex(v, ":"); idx > 0 && isOptionColor(v[idx+1:]) {
+			opt.Name = v[:idx]
+			opt.Color = strings.ToUpper(v[idx+1:])
+		}
+		options = append(options, opt)
+	}
+	return options
+}
+
+// isOptionColor reports whether s is a single-select option color
+func isOptionColor(s string) bool {
+	for _, c := range tmpl.OptionColors {
+		if strings.EqualFold(c, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================================================================
+// field delete
+// ============================================================================
+
+type fieldDeleteOptions struct {
+	yes bool
+}
+
+func newFieldDeleteCommand() *cobra.Command {
+	opts := &fieldDeleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a project field",
+		Long: `Delete a field from the configured project.
+
+The field's values are removed from every item in the project, and
+aliases for the field are removed from .gh-pmu.yml. Requires --yes.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fc, err := loadFieldContext(cmd)
+			if err != nil {
+				return err
+			}
+			return runFieldDelete(cmd, args[0], opts, fc)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Confirm deleting the field and its values")
+
+	return cmd
+}
+
+func runFieldDelete(cmd *cobra.Command, name string, opts *fieldDeleteOptions, fc *fieldContext) error {
+	field, err := fc.findField(fc.cfg.GetFieldName(name))
+	if err != nil {
+		return err
+	}
+
+	if !opts.yes {
+		return fmt.Errorf("deleting %q removes its values from all project items; re-run with --yes to confirm", field.Name)
+	}
+
+	if err := fc.client.DeleteProjectField(field.ID); err != nil {
+		return err
+	}
+
+	fieldName := field.Name
+	fc.editAliases(func(cfg *config.Config) {
+		for _, key := range aliasKeysForField(cfg, fieldName) {
+			delete(cfg.Fields, key)
+		}
+	})
+
+	if err := fc.save(); err != nil {
+		return err
+	}
+
+	u := ui.New(cmd.OutOrStdout())
+	u.Success(fmt.Sprintf("Deleted field %q", fieldName))
+	return nil
+}
+
+// ============================================================================
+// field option add / rename / reorder
+// ============================================================================
+
+type fieldOptionAddOptions struct {
+	color       string
+	description string
+	alias       string
+}
+
+func newFieldOptionAddCommand() *cobra.Command {
+	opts := &fieldOptionAddOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <field> <option>",
+		Short: "Add an option to a single-select field",
+		Example: `  # Add a priority level with an alias for --priority p3
+  gh pmu field option add Priority P3 --color GRAY --alias p3`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fc, err := loadFieldContext(cmd)
+			if err != nil {
+				return err
+			}
+			return runFieldOptionAdd(cmd, args[0], args[1], opts, fc)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.color, "color", "", "Option color (GRAY, BLUE, GREEN, YELLOW, ORANGE, RED, PINK, PURPLE)")
+	cmd.Flags().StringVar(&opts.description, "description", "", "Option description")
+	cmd.Flags().StringVar(&opts.alias, "alias", "", "Add an alias for the option to .gh-pmu.yml")
+
+	return cmd
+}
+
+func runFieldOptionAdd(cmd *cobra.Command, fieldName, option string, opts *fieldOptionAddOptions, fc *fieldContext) error {
+	field, err := fc.findSingleSelectField(fieldName)
+	if err != nil {
+		return err
+	}
+
+	for _, opt := range field.Options {
+		if strings.EqualFold(opt.Name, option) {
+			return fmt.Errorf("option %q already exists in field %q", opt.Name, field.Name)
+		}
+	}
+
+	options := append(copyOptions(field.Options), api.FieldOption{
+		Name:        option,
+		Color:       strings.ToUpper(opts.color),
+		Description: opts.description,
+	})
+
+	if _, err := fc.client.UpdateSingleSelectOptions(field.ID, options); err != nil {
+		return err
+	}
+
+	if opts.alias != "" {
+		key := aliasKey(field.Name)
+		if keys := aliasKeysForField(fc.cfg, field.Name); len(keys) > 0 {
+			sort.Strings(keys)
+			key = keys[0]
+		}
+		fc.editAliases(func(cfg *config.Config) {
+			if cfg.Fields == nil {
+				cfg.Fields = make(map[string]config.Field)
+			}
+			group := cfg.Fields[key]
+			if group.Field == "" {
+				group.Field = field.Name
+			}
+			if group.Values == nil {
+				group.Values = make(map[string]string)
+			}
+			group.Values[opts.alias] = option
+			cfg.Fields[key] = group
+		})
+	}
+
+	if err := fc.save(); err != nil {
+		return err
+	}
+
+	u := ui.New(cmd.OutOrStdout())
+	u.Success(fmt.Sprintf("Added option %q to %s", option, field.Name))
+	return nil
+}
+
+func newFieldOptionRenameCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <field> <old-name> <new-name>",
+		Short: "Rename a single-select option",
+		Long: `Rename an option of a single-select field.
+
+Aliases in .gh-pmu.yml that map to the old name are updated.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fc, err := loadFieldContext(cmd)
+			if err != nil {
+				return err
+			}
+			return runFieldOptionRename(cmd, args[0], args[1], args[2], fc)
+		},
+	}
+}
+
+func runFieldOptionRename(cmd *cobra.Command, fieldName, oldName, newName string, fc *fieldContext) error {
+	field, err := fc.findSingleSelectField(fieldName)
+	if err != nil {
+		return err
+	}
+
+	options := copyOptions(field.Options)
+	found := -1
+	for i, opt := range options {
+		if strings.EqualFold(opt.Name, newName) && !strings.EqualFold(opt.Name, oldName) {
+			return fmt.Errorf("option %q already exists in field %q", opt.Name, field.Name)
+		}
+		if strings.EqualFold(opt.Name, oldName) {
+			found = i
+		}
+	}
+	if found < 0 {
+		return fmt.Errorf("option %q not found in field %q", oldName, field.Name)
+	}
+	oldName = options[found].Name
+	options[found].Name = newName
+
+	if _, err := fc.client.UpdateSingleSelectOptions(field.ID, options); err != nil {
+		return err
+	}
+
+	fc.editAliases(func(cfg *config.Config) {
+		for _, key := range aliasKeysForField(cfg, field.Name) {
+			for alias, value := range cfg.Fields[key].Values {
+				if value == oldName {
+					cfg.Fields[key].Values[alias] = newName
+				}
+			}
+		}
+	})
+
+	if err := fc.save(); err != nil {
+		return err
+	}
+
+	u := ui.New(cmd.OutOrStdout())
+	u.Success(fmt.Sprintf("Renamed %s option %q to %q", field.Name, oldName, newName))
+	return nil
+}
+
+func newFieldOptionReorderCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reorder <field> <option>...",
+		Short: "Reorder single-select options",
+		Long: `Reorder the options of a single-select field.
+
+The given options are placed first, in the order listed; options not
+listed keep their relative order after them.`,
+		Example: `  # Move Urgent to the top of Priority
+  gh pmu field option reorder Priority Urgent`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fc, err := loadFieldContext(cmd)
+			if err != nil {
+				return err
+			}
+			return runFieldOptionReorder(cmd, args[0], args[1:], fc)
+		},
+	}
+}
+
+func runFieldOptionReorder(cmd *cobra.Command, fieldName string, order []string, fc *fieldContext) error {
+	field, err := fc.findSingleSelectField(fieldName)
+	if err != nil {
+		return err
+	}
+
+	options, err := reorderOptions(field.Options, order)
+	if err != nil {
+		return fmt.Errorf("%w in field %q", err, field.Name)
+	}
+
+	if _, err := fc.client.UpdateSingleSelectOptions(field.ID, options); err != nil {
+		return err
+	}
+
+	if err := fc.save(); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, opt := range options {
+		names = append(names, opt.Name)
+	}
+
+	u := ui.New(cmd.OutOrStdout())
+	u.Success(fmt.Sprintf("Reordered %s: %s", field.Name, strings.Join(names, ", ")))
+	return nil
+}
+
+// reorderOptions moves the named options to the front in the given order
+func reorderOptions(options []api.FieldOption, order []string) ([]api.FieldOption, error) {
+	used := make(map[int]bool)
+	var result []api.FieldOption
+
+	for _, name := range order {
+		found := -1
+		for i, opt := range options {
+			if strings.EqualFold(opt.Name, name) {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			return nil, fmt.Errorf("option %q not found", name)
+		}
+		if used[found] {
+			return nil, fmt.Errorf("option %q listed more than once", name)
+		}
+		used[found] = true
+		result = append(result, options[found])
+	}
+
+	for i, opt := range options {
+		if !used[i] {
+			result = append(result, opt)
+		}
+	}
+
+	return result, nil
+}
+
+// copyOptions returns a copy of options so callers can modify it
+func copyOptions(options []api.FieldOption) []api.FieldOption {
+	return append([]api.FieldOption(nil), options...)
+}
@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// mockFieldClient implements fieldClient for testing
+type mockFieldClient struct {
+	fields        []api.ProjectField
+	deletedFields []string
+	updateErr     error
+}
+
+func (m *mockFieldClient) GetProject(owner string, number int) (*api.Project, error) {
+	return &api.Project{ID: "proj-1", Number: number, Title: "Board"}, nil
+}
+
+func (m *mockFieldClient) GetProjectFields(projectID string) ([]api.ProjectField, error) {
+	return append([]api.ProjectField(nil), m.fields...), nil
+}
+
+func (m *mockFieldClient) CreateProjectField(projectID, name, dataType string, options []api.FieldOption) (*api.ProjectField, error) {
+	f := api.ProjectField{ID: "field-" + name, Name: name, DataType: dataType}
+	for i, opt := range options {
+		opt.ID = fmt.Sprintf("opt-%s-%d", name, i)
+		f.Options = append(f.Options, opt)
+	}
+	m.fields = append(m.fields, f)
+	return &f, nil
+}
+
+func (m *mockFieldClient) CreateIterationField(projectID, name, startDate string, duration, count int) (*api.ProjectField, error) {
+	f := api.ProjectField{ID: "field-" + name, Name: name, DataType: "ITERATION", Iteration: &api.IterationConfig{Duration: duration}}
+	m.fields = append(m.fields, f)
+	return &f, nil
+}
+
+func (m *mockFieldClient) DeleteProjectField(fieldID string) error {
+	m.deletedFields = append(m.deletedFields, fieldID)
+	for i, f := range m.fields {
+		if f.ID == fieldID {
+			m.fields = append(m.fields[:i], m.fields[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockFieldClient) UpdateSingleSelectOptions(fieldID string, options []api.FieldOption) (*api.ProjectField, error) {
+	if m.updateErr != nil {
+		return nil, m.updateErr
+	}
+	for i := range m.fields {
+		if m.fields[i].ID == fieldID {
+			m.fields[i].Options = options
+			return &m.fields[i], nil
+		}
+	}
+	return nil, fmt.Errorf("field not found: %s", fieldID)
+}
+
+func newTestFieldContext(t *testing.T) (*fieldContext, *mockFieldClient) {
+	t.Helper()
+
+	mock := &mockFieldClient{
+		fields: []api.ProjectField{
+			{ID: "field-status", Name: "Status", DataType: "SINGLE_SELECT", Options: []api.FieldOption{
+				{ID: "o1", Name: "Backlog"}, {ID: "o2", Name: "In progress"}, {ID: "o3", Name: "Done"},
+			}},
+			{ID: "field-priority", Name: "Priority", DataType: "SINGLE_SELECT", Options: []api.FieldOption{
+				{ID: "p0", Name: "P0"}, {ID: "p1", Name: "P1"},
+			}},
+			{ID: "field-notes", Name: "Notes", DataType: "TEXT"},
+		},
+	}
+
+	cfg := &config.Config{
+		Project:      config.Project{Owner: "acme", Number: 1},
+		Repositories: []string{"acme/api"},
+		Fields: map[string]config.Field{
+			"status":   {Field: "Status", Values: map[string]string{"backlog": "Backlog", "in_progress": "In progress"}},
+			"priority": {Field: "Priority", Values: map[string]string{"p0": "P0"}},
+		},
+	}
+
+	fc, err := newFieldContext(cfg, cfg, filepath.Join(t.TempDir(), config.ConfigFileName), mock)
+	if err != nil {
+		t.Fatalf("newFieldContext error: %v", err)
+	}
+	return fc, mock
+}
+
+func newFieldTestCommand() (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	return cmd, buf
+}
+
+func TestFieldCommand_HasSubcommands(t *testing.T) {
+	cmd := NewRootCommand()
+	for _, path := range [][]string{
+		{"field", "list"},
+		{"field", "create"},
+		{"field", "delete"},
+		{"field", "option", "add"},
+		{"field", "option", "rename"},
+		{"field", "option", "reorder"},
+	} {
+		if _, _, err := cmd.Find(path); err != nil {
+			t.Errorf("command %v not found: %v", path, err)
+		}
+	}
+}
+
+func TestRunFieldList(t *testing.T) {
+	fc, _ := newTestFieldContext(t)
+	cmd, buf := newFieldTestCommand()
+
+	if err := runFieldList(cmd, fc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Backlog, In progress, Done") {
+		t.Errorf("Expected Status options in output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "TEXT") {
+		t.Errorf("Expected Notes type in output, got:\n%s", output)
+	}
+}
+
+func TestRunFieldCreate_SingleSelectUpdatesMetadata(t *testing.T) {
+	fc, mock := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	opts := &fieldCreateOptions{fieldType: "single_select", options: []string{"Low:GREEN", "Act 1: Setup"}}
+	if err := runFieldCreate(cmd, "Risk", opts, fc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	created := mock.fields[len(mock.fields)-1]
+	if created.Options[0].Name != "Low" || created.Options[0].Color != "GREEN" {
+		t.Errorf("Expected Low:GREEN option, got %+v", created.Options[0])
+	}
+	if created.Options[1].Name != "Act 1: Setup" {
+		t.Errorf("Expected colon in option name to be kept, got %q", created.Options[1].Name)
+	}
+
+	cfg, err := config.Load(fc.configPath)
+	if err != nil {
+		t.Fatalf("Expected config to be saved: %v", err)
+	}
+	found := false
+	for _, f := range cfg.Metadata.Fields {
+		if f.Name == "Risk" && len(f.Options) == 2 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected Risk in metadata, got %+v", cfg.Metadata.Fields)
+	}
+}
+
+func TestRunFieldCreate_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *fieldCreateOptions
+		want string
+	}{
+		{"Status", &fieldCreateOptions{fieldType: "text"}, "already exists"},
+		{"Risk", &fieldCreateOptions{fieldType: "enum"}, "invalid field type"},
+		{"Risk", &fieldCreateOptions{fieldType: "single_select"}, "at least one --option"},
+		{"Risk", &fieldCreateOptions{fieldType: "number", options: []string{"A"}}, "only valid for single_select"},
+	}
+
+	for _, tt := range tests {
+		fc, _ := newTestFieldContext(t)
+		cmd, _ := newFieldTestCommand()
+		err := runFieldCreate(cmd, tt.name, tt.opts, fc)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("runFieldCreate(%q, %+v) error = %v, want %q", tt.name, tt.opts, err, tt.want)
+		}
+	}
+}
+
+func TestRunFieldDelete_RequiresConfirmation(t *testing.T) {
+	fc, mock := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	err := runFieldDelete(cmd, "Priority", &fieldDeleteOptions{}, fc)
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("Expected confirmation error, got %v", err)
+	}
+	if len(mock.deletedFields) != 0 {
+		t.Error("Expected no field to be deleted without --yes")
+	}
+}
+
+func TestRunFieldDelete_RemovesAliases(t *testing.T) {
+	fc, mock := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	if err := runFieldDelete(cmd, "priority", &fieldDeleteOptions{yes: true}, fc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(mock.deletedFields) != 1 || mock.deletedFields[0] != "field-priority" {
+		t.Errorf("Expected Priority to be deleted, got %v", mock.deletedFields)
+	}
+	if _, ok := fc.cfg.Fields["priority"]; ok {
+		t.Error("Expected priority alias group to be removed")
+	}
+	for _, f := range fc.cfg.Metadata.Fields {
+		if f.Name == "Priority" {
+			t.Error("Expected Priority to be removed from metadata")
+		}
+	}
+}
+
+func TestRunFieldOptionAdd_WithAlias(t *testing.T) {
+	fc, mock := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	opts := &fieldOptionAddOptions{color: "gray", alias: "p2"}
+	if err := runFieldOptionAdd(cmd, "Priority", "P2", opts, fc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	options := mock.fields[1].Options
+	if len(options) != 3 || options[2].Name != "P2" || options[2].Color != "GRAY" {
+		t.Errorf("Expected P2 appended with color GRAY, got %+v", options)
+	}
+	if options[0].Name != "P0" {
+		t.Error("Expected existing options to be kept")
+	}
+	if got := fc.cfg.ResolveFieldValue("priority", "p2"); got != "P2" {
+		t.Errorf("Expected alias p2 -> P2, got %q", got)
+	}
+}
+
+func TestRunFieldOptionAdd_KeepsConfigComments(t *testing.T) {
+	_, mock := newTestFieldContext(t)
+	path := filepath.Join(t.TempDir(), config.ConfigFileName)
+	content := `project:
+  owner: acme
+  number: 1
+repositories:
+  - acme/api
+fields:
+  # Short names for priorities
+  priority:
+    field: Priority
+    values:
+      p0: P0 # drop everything
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := newFieldContext(file, file, path, mock)
+	if err != nil {
+		t.Fatalf("newFieldContext error: %v", err)
+	}
+	cmd, _ := newFieldTestCommand()
+
+	if err := runFieldOptionAdd(cmd, "Priority", "P2", &fieldOptionAddOptions{alias: "p2"}, fc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `fields:
+  # Short names for priorities
+  priority:
+    field: Priority
+    values:
+      p0: P0 # drop everything
+      p2: P2
+`
+	if !strings.HasPrefix(string(data), "project:\n  owner: acme\n") || !strings.Contains(string(data), want) {
+		t.Errorf("Expected the comments and order to be kept, got:\n%s", data)
+	}
+}
+
+func TestRunFieldOptionAdd_Duplicate(t *testing.T) {
+	fc, _ := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	err := runFieldOptionAdd(cmd, "Priority", "p0", &fieldOptionAddOptions{}, fc)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected duplicate error, got %v", err)
+	}
+}
+
+func TestRunFieldOptionAdd_NotSingleSelect(t *testing.T) {
+	fc, _ := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	err := runFieldOptionAdd(cmd, "Notes", "x", &fieldOptionAddOptions{}, fc)
+	if err == nil || !strings.Contains(err.Error(), "not a single-select") {
+		t.Errorf("Expected single-select error, got %v", err)
+	}
+}
+
+func TestRunFieldOptionRename_UpdatesAliases(t *testing.T) {
+	fc, mock := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	if err := runFieldOptionRename(cmd, "status", "In progress", "In Progress", fc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if opt := mock.fields[0].Options[1]; opt.Name != "In Progress" || opt.ID != "o2" {
+		t.Errorf("Expected option renamed in place, got %+v", mock.fields[0].Options)
+	}
+	if got := fc.cfg.ResolveFieldValue("status", "in_progress"); got != "In Progress" {
+		t.Errorf("Expected alias to follow rename, got %q", got)
+	}
+}
+
+func TestRunFieldOptionRename_IgnoresCase(t *testing.T) {
+	fc, mock := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	if err := runFieldOptionRename(cmd, "Status", "in PROGRESS", "Doing", fc); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opt := mock.fields[0].Options[1]; opt.Name != "Doing" || opt.ID != "o2" {
+		t.Errorf("Expected option renamed in place, got %+v", mock.fields[0].Options)
+	}
+	if got := fc.cfg.ResolveFieldValue("status", "in_progress"); got != "Doing" {
+		t.Errorf("Expected alias to follow rename, got %q", got)
+	}
+
+	if err := runFieldOptionRename(cmd, "Status", "backlog", "done", fc); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected duplicate error, got %v", err)
+	}
+}
+
+func TestRunFieldOptionRename_NotFound(t *testing.T) {
+	fc, _ := newTestFieldContext(t)
+	cmd, _ := newFieldTestCommand()
+
+	err := runFieldOptionRename(cmd, "Status", "Missing", "Other", fc)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestReorderOptions(t *testing.T) {
+	options := []api.FieldOption{{Name: "A"}, {Name: "B"}, {Name: "C"}, {Name: "D"}}
+
+	got, err := reorderOptions(options, []string{"c", "A"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, o := range got {
+		names = append(names, o.Name)
+	}
+	if strings.Join(names, ",") != "C,A,B,D" {
+		t.Errorf("Expected C,A,B,D, got %s", strings.Join(names, ","))
+	}
+
+	if _, err := reorderOptions(options, []string{"X"}); err == nil {
+		t.Error("Expected error for unknown option")
+	}
+	if _, err := reorderOptions(options, []string{"A", "a"}); err == nil {
+		t.Error("Expected error for repeated option")
+	}
+}
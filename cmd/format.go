@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --format
+const (
+	formatTable    = "table"
+	formatCSV      = "csv"
+	formatTSV      = "tsv"
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+	formatYAML     = "yaml"
+)
+
+var outputFormats = []string{formatTable, formatCSV, formatTSV, formatMarkdown, formatJSON, formatYAML}
+
+// issueColumns are the columns every issue has, whatever the command
+var issueColumns = []string{"number", "title", "state", "url", "repository", "author", "assignees", "labels", "milestone", "type", "created", "updated", "closed", "comments", "body"}
+
+// columnDetails maps issue columns to the project item details they need
+// fetched, for those not fetched by default
+var columnDetails = map[string]string{
+	"author":    api.DetailAuthor,
+	"labels":    api.DetailLabels,
+	"milestone": api.DetailMilestone,
+	"created":   api.DetailDates,
+	"updated":   api.DetailDates,
+	"closed":    api.DetailDates,
+	"comments":  api.DetailComments,
+	"body":      api.DetailBody,
+}
+
+// formatOptions holds the --format and --columns flags shared by commands
+// that list issues.
+//
+// Rows are written as a table (the default), CSV, TSV, a Markdown table, or
+// JSON or YAML records keyed by column. Columns are named as given, so
+// --columns title,"Story Points" produces a "Story Points" header.
+type formatOptions struct {
+	format   string
+	columns  []string
+	defaults []string
+}
+
+// addFormatFlags adds --format and --columns to cmd. defaults are the
+// columns shown when --columns is not given.
+func addFormatFlags(cmd *cobra.Command, defaults ...string) *formatOptions {
+	opts := &formatOptions{defaults: defaults}
+
+	cmd.Flags().StringVar(&opts.format, "format", "", "Output `format`: "+strings.Join(outputFormats, ", "))
+	cmd.Flags().StringSliceVar(&opts.columns, "columns", nil, fmt.Sprintf("Columns to show (default %q)", strings.Join(defaults, ",")))
+
+	return opts
+}
+
+// enabled reports whether --format or --columns was given
+func (o *formatOptions) enabled() bool {
+	return o != nil && (o.format != "" || len(o.columns) > 0)
+}
+
+// dataOnly reports whether the output is data for another program, so
+// headings and hints around it are left out
+func (o *formatOptions) dataOnly() bool {
+	return o.enabled() && o.format != "" && o.format != formatTable
+}
+
+// validate checks the format and that it is not combined with JSON export
+func (o *formatOptions) validate(export *exportOptions) error {
+	if !o.enabled() {
+		return nil
+	}
+	if o.format != "" && !containsString(outputFormats, o.format) {
+		return fmt.Errorf("invalid format %q: expected one of %s", o.format, strings.Join(outputFormats, ", "))
+	}
+	if export.enabled() {
+		return fmt.Errorf("cannot use --format or --columns with --json, --jq, or --template")
+	}
+	return nil
+}
+
+// selected returns the columns to show
+func (o *formatOptions) selected() []string {
+	var columns []string
+	source := o.defaults
+	if len(o.columns) > 0 {
+		source = o.columns
+	}
+	for _, column := range source {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// checkColumns returns an error naming the first column that known rejects
+func checkColumns(columns []string, known func(string) bool, available []string) error {
+	for _, column := range columns {
+		if !known(column) {
+			return fmt.Errorf("unknown column %q\nAvailable columns:\n  %s", column, strings.Join(available, "\n  "))
+		}
+	}
+	return nil
+}
+
+// write writes rows, one cell per column, in the selected format
+func (o *formatOptions) write(cmd *cobra.Command, columns []string, rows [][]string) error {
+	out := cmd.OutOrStdout()
+
+	switch o.format {
+	case formatCSV:
+		return writeCSV(out, ',', columns, rows)
+	case formatTSV:
+		return writeTSV(out, columns, rows)
+	case formatMarkdown:
+		return writeMarkdown(out, columns, rows)
+	case formatJSON:
+		return writeJSONRows(out, columns, rows)
+	case formatYAML:
+		return writeYAMLRows(out, columns, rows)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = strings.ToUpper(column)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = singleLine(cell)
+			if cells[i] == "" {
+				cells[i] = "-"
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+func writeCSV(out io.Writer, comma rune, columns []string, rows [][]string) error {
+	w := csv.NewWriter(out)
+	w.Comma = comma
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// writeTSV writes tab-separated values. Tabs and line breaks inside cells
+// become spaces, so each row stays on one line.
+func writeTSV(out io.Writer, columns []string, rows [][]string) error {
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	for _, row := range append([][]string{columns}, rows...) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = clean.Replace(cell)
+		}
+		if _, err := fmt.Fprintln(out, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMarkdown writes a GitHub-flavored Markdown table
+func writeMarkdown(out io.Writer, columns []string, rows [][]string) error {
+	clean := strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+	line := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = clean.Replace(cell)
+		}
+		return "| " + strings.Join(escaped, " | ") + " |"
+	}
+
+	separator := make([]string, len(columns))
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	if _, err := fmt.Fprintln(out, line(columns)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(out, "|"+strings.Join(separator, "|")+"|"); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(out, line(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSONRows writes an array of objects whose keys keep column order
+func writeJSONRows(out io.Writer, columns []string, rows [][]string) error {
+	var b strings.Builder
+	b.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  {")
+		for j, column := range columns {
+			if j > 0 {
+				b.WriteString(",")
+			}
+			key, _ := json.Marshal(column)
+			value, _ := json.Marshal(row[j])
+			fmt.Fprintf(&b, "\n    %s: %s", key, value)
+		}
+		b.WriteString("\n  }")
+	}
+	if len(rows) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// writeYAMLRows writes a sequence of mappings whose keys keep column order
+func writeYAMLRows(out io.Writer, columns []string, rows [][]string) error {
+	doc := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range rows {
+		record := &yaml.Node{Kind: yaml.MappingNode}
+		for j, column := range columns {
+			record.Content = append(record.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: column},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: row[j]},
+			)
+		}
+		doc.Content = append(doc.Content, record)
+	}
+
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// singleLine collapses line breaks so a cell fits on one table line
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, "\n", " ")), " ")
+}
+
+// issueCell returns the value of one of issueColumns for an issue
+func issueCell(issue *api.Issue, column string) (string, bool) {
+	switch strings.ToLower(column) {
+	case "number":
+		return strconv.Itoa(issue.Number), true
+	case "title":
+		return issue.Title, true
+	case "state":
+		return issue.State, true
+	case "url":
+		return issue.URL, true
+	case "repository", "repo":
+		if issue.Repository.Owner == "" {
+			return "", true
+		}
+		return issue.Repository.Owner + "/" + issue.Repository.Name, true
+	case "author":
+		return issue.Author.Login, true
+	case "assignees":
+		var logins []string
+		for _, a := range issue.Assignees {
+			logins = append(logins, a.Login)
+		}
+		return strings.Join(logins, ", "), true
+	case "labels":
+		var names []string
+		for _, l := range issue.Labels {
+			names = append(names, l.Name)
+		}
+		return strings.Join(names, ", "), true
+	case "milestone":
+		if issue.Milestone == nil {
+			return "", true
+		}
+		return issue.Milestone.Title, true
+	case "type":
+		return issue.Type, true
+	case "created":
+		return issue.CreatedAt, true
+	case "updated":
+		return issue.UpdatedAt, true
+	case "closed":
+		return issue.ClosedAt, true
+	case "comments":
+		return strconv.Itoa(issue.Comments), true
+	case "body":
+		return issue.Body, true
+	}
+	return "", false
+}
+
+// isIssueColumn reports whether column is one of issueColumns, or "repo"
+// for repository
+func isIssueColumn(column string) bool {
+	_, ok := issueCell(&api.Issue{}, column)
+	return ok
+}
+
+// issueRows builds one row per issue from issueColumns
+func issueRows(issues []api.Issue, columns []string) [][]string {
+	rows := make([][]string, 0, len(issues))
+	for i := range issues {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j], _ = issueCell(&issues[i], column)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// writeIssueRows validates columns and writes issues in the selected format
+func (o *formatOptions) writeIssueRows(cmd *cobra.Command, issues []api.Issue) error {
+	columns := o.selected()
+	if err := checkColumns(columns, isIssueColumn, issueColumns); err != nil {
+		return err
+	}
+	return o.write(cmd, columns, issueRows(issues, columns))
+}
+
+// itemCell returns the value of a column for a project item: one of
+// issueColumns, or a project field named directly or by its config alias
+func itemCell(cfg *config.Config, item api.ProjectItem, column string) string {
+	if item.Issue != nil {
+		if value, ok := issueCell(item.Issue, column); ok {
+			return value
+		}
+	} else if isIssueColumn(column) {
+		return ""
+	}
+	if value := getFieldValue(item, cfg.GetFieldName(column)); value != "" {
+		return value
+	}
+	return getFieldValue(item, column)
+}
+
+// projectColumns returns the project fields that can be used as columns:
+// config aliases, cached field metadata, and fields set on items
+func projectColumns(cfg *config.Config, items []api.ProjectItem) []string {
+	var columns []string
+	add := func(name string) {
+		for _, existing := range columns {
+			if strings.EqualFold(existing, name) {
+				return
+			}
+		}
+		columns = append(columns, name)
+	}
+
+	for alias := range cfg.Fields {
+		add(alias)
+	}
+	if cfg.Metadata != nil {
+		for _, field := range cfg.Metadata.Fields {
+			add(field.Name)
+		}
+	}
+	for _, item := range items {
+		for _, fv := range item.FieldValues {
+			add(fv.Field)
+		}
+	}
+	return columns
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
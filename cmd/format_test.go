@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// graphQLStub answers queries by name with canned JSON responses
+type graphQLStub map[string]string
+
+func (s graphQLStub) Query(name string, query interface{}, variables map[string]interface{}) error {
+	if response, ok := s[name]; ok {
+		return json.Unmarshal([]byte(response), query)
+	}
+	return nil
+}
+
+func (s graphQLStub) Mutate(name string, mutation interface{}, variables map[string]interface{}) error {
+	return fmt.Errorf("unexpected mutation %s", name)
+}
+
+// storyPointsAPI is the fake API with project items parsed by the real
+// client from a GetProjectItems response, including a number field and
+// an iteration field
+type storyPointsAPI struct {
+	*fakeAPI
+	client *api.Client
+}
+
+func newStoryPointsAPI() *storyPointsAPI {
+	fake := newFakeAPI()
+	fake.fields = append(fake.fields, api.ProjectField{Name: "Story Points", DataType: "NUMBER"}, api.ProjectField{Name: "Sprint", DataType: "ITERATION"})
+	return &storyPointsAPI{
+		fakeAPI: fake,
+		client: api.NewClientWithGraphQL(graphQLStub{"GetProjectItems": `{"node": {"projectV2": {"items": {"nodes": [
+			{"id": "ITEM_1", "content": {"typeName": "Issue", "issue": {
+				"id": "ILogin page", "number": 1, "title": "Login page", "state": "OPEN",
+				"repository": {"nameWithOwner": "acme/api"},
+				"assignees": {"nodes": [{"login": "alice"}, {"login": "bob"}]}
+			}}, "fieldValues": {"nodes": [
+				{"typeName": "ProjectV2ItemFieldSingleSelectValue", "projectV2ItemFieldSingleSelectValue": {"name": "Todo", "field": {"projectV2SingleSelectField": {"name": "Status"}}}},
+				{"typeName": "ProjectV2ItemFieldNumberValue", "projectV2ItemFieldNumberValue": {"number": 3, "field": {"projectV2Field": {"name": "Story Points"}}}},
+				{"typeName": "ProjectV2ItemFieldIterationValue", "projectV2ItemFieldIterationValue": {"title": "Sprint 4", "field": {"projectV2IterationField": {"name": "Sprint"}}}}
+			]}},
+			{"id": "ITEM_2", "content": {"typeName": "Issue", "issue": {
+				"id": "ISignup flow", "number": 2, "title": "Signup flow", "state": "OPEN",
+				"repository": {"nameWithOwner": "acme/api"}
+			}}, "fieldValues": {"nodes": [
+				{"typeName": "ProjectV2ItemFieldSingleSelectValue", "projectV2ItemFieldSingleSelectValue": {"name": "Done", "field": {"projectV2SingleSelectField": {"name": "Status"}}}}
+			]}}
+		]}}}}`}),
+	}
+}
+
+func (f *storyPointsAPI) GetProjectItems(projectID string, filter *api.ProjectItemsFilter) ([]api.ProjectItem, error) {
+	return f.client.GetProjectItems(projectID, filter)
+}
+
+func TestFormat_ListCSVWithCustomField(t *testing.T) {
+	out, err := runRootWith(t, newStoryPointsAPI(), "list", "--format", "csv", "--columns", `number,title,status,assignees,"Story Points"`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "number,title,status,assignees,Story Points\n1,Login page,Todo,\"alice, bob\",3\n2,Signup flow,Done,,\n"
+	if out != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestFormat_ListSortsByNumberField(t *testing.T) {
+	out, err := runRootWith(t, newStoryPointsAPI(), "list", "--format", "csv", "--columns", "number,Sprint,Story Points", "--sort", "-Story Points,number")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "number,Sprint,Story Points\n1,Sprint 4,3\n2,,\n"
+	if out != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestFormat_ListTSV(t *testing.T) {
+	out, err := runRoot(t, "list", "--format", "tsv", "--columns", "number,status")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out != "number\tstatus\n1\tTodo\n2\tDone\n" {
+		t.Errorf("Unexpected TSV %q", out)
+	}
+}
+
+func TestFormat_ListMarkdown(t *testing.T) {
+	client := newFakeAPI()
+	client.items[0].Issue.Title = "Login | logout"
+
+	out, err := runRootWith(t, client, "list", "--format", "markdown", "--columns", "number,title")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "| number | title |\n|---|---|\n| 1 | Login \\| logout |\n| 2 | Signup flow |\n"
+	if out != want {
+		t.Errorf("Unexpected Markdown:\n%s", out)
+	}
+}
+
+func TestFormat_ListJSONKeepsColumnOrder(t *testing.T) {
+	out, err := runRootWith(t, newStoryPointsAPI(), "list", "--format", "json", "--columns", "title,Story Points,number", "--limit", "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "[\n  {\n    \"title\": \"Login page\",\n    \"Story Points\": \"3\",\n    \"number\": \"1\"\n  }\n]\n"
+	if out != want {
+		t.Errorf("Unexpected JSON:\n%s", out)
+	}
+}
+
+func TestFormat_ListYAML(t *testing.T) {
+	out, err := runRoot(t, "list", "--format", "yaml", "--columns", "number,status", "--status", "done")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out != "- number: \"2\"\n  status: Done\n" {
+		t.Errorf("Unexpected YAML %q", out)
+	}
+}
+
+func TestFormat_ListTableColumns(t *testing.T) {
+	out, err := runRootWith(t, newStoryPointsAPI(), "list", "--columns", "title,story points")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "TITLE") || !strings.Contains(lines[0], "STORY POINTS") {
+		t.Fatalf("Unexpected table:\n%s", out)
+	}
+	if !strings.HasSuffix(lines[1], "3") || !strings.HasSuffix(lines[2], "-") {
+		t.Errorf("Expected story points and a placeholder for the missing value, got:\n%s", out)
+	}
+}
+
+func TestFormat_InvalidFormat(t *testing.T) {
+	_, err := runRoot(t, "list", "--format", "xml")
+	if err == nil || !strings.Contains(err.Error(), `invalid format "xml"`) {
+		t.Errorf("Expected invalid format error, got %v", err)
+	}
+}
+
+func TestFormat_ConflictsWithJSON(t *testing.T) {
+	_, err := runRoot(t, "list", "--format", "csv", "--json")
+	if err == nil || !strings.Contains(err.Error(), "cannot use --format or --columns with --json") {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+func TestFormat_IssueRowsRejectUnknownColumn(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+	format := &formatOptions{format: formatCSV, columns: []string{"title", "priority"}}
+
+	err := format.writeIssueRows(cmd, []api.Issue{{Number: 1, Title: "A"}})
+	if err == nil || !strings.Contains(err.Error(), `unknown column "priority"`) || !strings.Contains(err.Error(), "labels") {
+		t.Errorf("Expected unknown column error listing available columns, got %v", err)
+	}
+}
+
+func TestFormat_IssueRows(t *testing.T) {
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	format := &formatOptions{format: formatTSV, defaults: []string{"number", "title", "labels"}}
+
+	issues := []api.Issue{{Number: 7, Title: "Tabs\tand\nlines", Labels: []api.Label{{Name: "bug"}, {Name: "ui"}}}}
+	if err := format.writeIssueRows(cmd, issues); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != "number\ttitle\tlabels\n7\tTabs and lines\tbug, ui\n" {
+		t.Errorf("Unexpected TSV %q", buf.String())
+	}
+}
+
+func TestOutputItemRows_UnknownColumnWithMetadata(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+	cfg := newTestCommandContext(nil).cfg
+	cfg.Metadata = &config.Metadata{Fields: []config.FieldMetadata{{Name: "Status"}, {Name: "Story Points"}}}
+
+	items := newFakeAPI().items
+	if err := outputItemRows(cmd, cfg, items, &formatOptions{columns: []string{"Story Points"}}); err != nil {
+		t.Errorf("Expected a known field without values to be accepted, got %v", err)
+	}
+	err := outputItemRows(cmd, cfg, items, &formatOptions{columns: []string{"Estimate"}})
+	if err == nil || !strings.Contains(err.Error(), `unknown column "Estimate"`) {
+		t.Errorf("Expected unknown column error, got %v", err)
+	}
+}
+
+func TestSubListRows_RelationAll(t *testing.T) {
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	result := SubListResult{
+		Issue:    &api.Issue{Number: 5, Title: "Child"},
+		Parent:   &api.Issue{Number: 1, Title: "Epic", State: "OPEN"},
+		Siblings: []api.SubIssue{{Number: 4, Title: "Sibling", State: "CLOSED"}},
+		Children: []api.SubIssue{{Number: 9, Title: "Task", State: "OPEN"}},
+	}
+	format := &formatOptions{format: formatCSV, defaults: []string{"number", "title", "state"}}
+	if err := outputSubListRows(cmd, result, "all", format); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := "relation,number,title,state\nparent,1,Epic,OPEN\nsibling,4,Sibling,CLOSED\nchild,9,Task,OPEN\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+}
+
+func TestFormat_FlagsOnListingCommands(t *testing.T) {
+	for _, path := range [][]string{{"list"}, {"intake"}, {"triage"}, {"sub", "list"}} {
+		cmd, _, err := NewRootCommand().Find(path)
+		if err != nil {
+			t.Fatalf("Find(%v): %v", path, err)
+		}
+		for _, name := range []string{"format", "columns"} {
+			if cmd.Flags().Lookup(name) == nil {
+				t.Errorf("Expected %v to have --%s", path, name)
+			}
+		}
+	}
+}
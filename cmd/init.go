@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/cli/go-gh/v2/pkg/auth"
 	"github.com/scooter-indie/gh-pmu/internal/api"
 	"github.com/scooter-indie/gh-pmu/internal/ui"
 	"github.com/spf13/cobra"
@@ -55,7 +56,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Auto-detect repository
-	detectedRepo := detectRepository()
+	host, detectedRepo := detectRemote()
 	var owner string
 	var defaultRepo string
 
@@ -74,8 +75,11 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Initialize API client
-	client := api.NewClient()
+	// Initialize API client, on the remote's GitHub Enterprise Server host if any
+	if strings.EqualFold(host, api.DefaultHost) {
+		host = ""
+	}
+	client := api.NewClientForHost(host)
 
 	// Fetch projects for owner
 	fmt.Fprintln(cmd.OutOrStdout())
@@ -233,6 +237,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	// Create config
 	cfg := &InitConfig{
+		Host:          host,
 		ProjectName:   selectedProject.Title,
 		ProjectOwner:  owner,
 		ProjectNumber: projectNumber,
@@ -260,34 +265,62 @@ func runInit(cmd *cobra.Command, args []string) error {
 // Supports both HTTPS and SSH formats.
 // Returns empty string if not a valid GitHub remote.
 func parseGitRemote(remote string) string {
+	_, repo := parseGitRemoteHost(remote)
+	return repo
+}
+
+// parseGitRemoteHost extracts the host and owner/repo from a remote URL on
+// github.com or a GitHub Enterprise Server host known to gh.
+// Returns empty strings if not a valid GitHub remote.
+func parseGitRemoteHost(remote string) (host, repo string) {
 	if remote == "" {
-		return ""
+		return "", ""
 	}
 
 	// HTTPS format: https://github.com/owner/repo.git or https://github.com/owner/repo
-	httpsRegex := regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+?)(?:\.git)?$`)
-	if matches := httpsRegex.FindStringSubmatch(remote); matches != nil {
-		return matches[1] + "/" + matches[2]
-	}
-
+	httpsRegex := regexp.MustCompile(`^https://([^/@:]+)/([^/]+)/([^/]+?)(?:\.git)?$`)
 	// SSH format: git@github.com:owner/repo.git or git@github.com:owner/repo
-	sshRegex := regexp.MustCompile(`^git@github\.com:([^/]+)/([^/]+?)(?:\.git)?$`)
-	if matches := sshRegex.FindStringSubmatch(remote); matches != nil {
-		return matches[1] + "/" + matches[2]
+	sshRegex := regexp.MustCompile(`^git@([^/:]+):([^/]+)/([^/]+?)(?:\.git)?$`)
+
+	matches := httpsRegex.FindStringSubmatch(remote)
+	if matches == nil {
+		matches = sshRegex.FindStringSubmatch(remote)
+	}
+	if matches == nil || !isGitHubHost(matches[1]) {
+		return "", ""
 	}
+	return matches[1], matches[2] + "/" + matches[3]
+}
 
-	return ""
+// isGitHubHost reports whether host is github.com or a host gh is
+// authenticated with (from GH_HOST or gh's hosts configuration)
+func isGitHubHost(host string) bool {
+	if strings.EqualFold(host, api.DefaultHost) {
+		return true
+	}
+	for _, known := range auth.KnownHosts() {
+		if strings.EqualFold(host, known) {
+			return true
+		}
+	}
+	return false
 }
 
 // detectRepository attempts to get the repository from git remote.
 func detectRepository() string {
+	_, repo := detectRemote()
+	return repo
+}
+
+// detectRemote attempts to get the host and repository from git remote.
+func detectRemote() (host, repo string) {
 	// Try to get the origin remote URL
 	cmd := exec.Command("git", "remote", "get-url", "origin")
 	output, err := cmd.Output()
 	if err != nil {
-		return ""
+		return "", ""
 	}
-	return parseGitRemote(strings.TrimSpace(string(output)))
+	return parseGitRemoteHost(strings.TrimSpace(string(output)))
 }
 
 // splitRepository splits "owner/repo" into owner and repo parts.
@@ -301,6 +334,7 @@ func splitRepository(repo string) (owner, name string) {
 
 // InitConfig holds the configuration gathered during init.
 type InitConfig struct {
+	Host          string // GitHub Enterprise Server host; empty for github.com
 	ProjectName   string
 	ProjectOwner  string
 	ProjectNumber int
@@ -309,6 +343,7 @@ type InitConfig struct {
 
 // ConfigFile represents the .gh-pmu.yml file structure.
 type ConfigFile struct {
+	Host         string                  `yaml:"host,omitempty"`
 	Project      ProjectConfig           `yaml:"project"`
 	Repositories []string                `yaml:"repositories"`
 	Defaults     DefaultsConfig          `yaml:"defaults"`
@@ -396,6 +431,7 @@ type TriageApply struct {
 
 // ConfigFileWithMetadata extends ConfigFile with metadata section.
 type ConfigFileWithMetadata struct {
+	Host         string                  `yaml:"host,omitempty"`
 	Project      ProjectConfig           `yaml:"project"`
 	Repositories []string                `yaml:"repositories"`
 	Defaults     DefaultsConfig          `yaml:"defaults"`
@@ -418,6 +454,7 @@ func validateProject(client ProjectValidator, owner string, number int) error {
 // writeConfig writes the configuration to a .gh-pmu.yml file.
 func writeConfig(dir string, cfg *InitConfig) error {
 	configFile := &ConfigFile{
+		Host: cfg.Host,
 		Project: ProjectConfig{
 			Name:   cfg.ProjectName,
 			Owner:  cfg.ProjectOwner,
@@ -506,6 +543,7 @@ func writeConfigWithMetadata(dir string, cfg *InitConfig, metadata *ProjectMetad
 	}
 
 	configFile := &ConfigFileWithMetadata{
+		Host: cfg.Host,
 		Project: ProjectConfig{
 			Name:   cfg.ProjectName,
 			Owner:  cfg.ProjectOwner,
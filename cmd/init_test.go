@@ -622,13 +622,15 @@ func TestWriteConfigWithMetadata_NilMetadataPanics(t *testing.T) {
 }
 
 func TestParseGitRemote_EdgeCases(t *testing.T) {
+	isolateGitHubHosts(t)
+
 	tests := []struct {
 		name     string
 		remote   string
 		expected string
 	}{
 		{
-			name:     "GitHub enterprise HTTPS - not supported",
+			name:     "GitHub enterprise HTTPS - host unknown to gh",
 			remote:   "https://github.example.com/owner/repo.git",
 			expected: "",
 		},
@@ -673,3 +675,57 @@ func TestParseGitRemote_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// isolateGitHubHosts runs a test without GH_HOST or gh's configured hosts
+func isolateGitHubHosts(t *testing.T) {
+	t.Helper()
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+}
+
+func TestParseGitRemoteHost_EnterpriseServer(t *testing.T) {
+	isolateGitHubHosts(t)
+	t.Setenv("GH_HOST", "ghe.example.com")
+
+	tests := []struct {
+		remote string
+		host   string
+		repo   string
+	}{
+		{"https://ghe.example.com/owner/repo.git", "ghe.example.com", "owner/repo"},
+		{"git@ghe.example.com:owner/repo.git", "ghe.example.com", "owner/repo"},
+		{"https://github.com/owner/repo", "github.com", "owner/repo"},
+		{"https://gitlab.com/owner/repo.git", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			host, repo := parseGitRemoteHost(tt.remote)
+			if host != tt.host || repo != tt.repo {
+				t.Errorf("parseGitRemoteHost(%q) = %q, %q, want %q, %q", tt.remote, host, repo, tt.host, tt.repo)
+			}
+		})
+	}
+}
+
+func TestWriteConfig_Host(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &InitConfig{
+		Host:          "ghe.example.com",
+		ProjectOwner:  "owner",
+		ProjectNumber: 1,
+		Repositories:  []string{"owner/repo"},
+	}
+
+	if err := writeConfig(tmpDir, cfg); err != nil {
+		t.Fatalf("writeConfig error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".gh-pmu.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "host: ghe.example.com\n") {
+		t.Errorf("Expected host at the top of the config, got:\n%s", data)
+	}
+}
@@ -1,9 +1,7 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"text/tabwriter"
 
@@ -15,7 +13,8 @@ import (
 type intakeOptions struct {
 	apply    string
 	dryRun   bool
-	json     bool
+	export   *exportOptions
+	format   *formatOptions
 	label    []string
 	assignee []string
 }
@@ -40,6 +39,9 @@ Use --apply to automatically add discovered issues to the project.`,
   # Filter by assignee
   gh pmu intake --assignee username
 
+  # Issues assigned to you or anyone on a team
+  gh pmu intake --assignee @me --assignee @acme/backend
+
   # Preview what would be added
   gh pmu intake --dry-run
 
@@ -50,43 +52,52 @@ Use --apply to automatically add discovered issues to the project.`,
   gh pmu intake --apply status:backlog,priority:p1
 
   # Output as JSON
-  gh pmu intake --json`,
+  gh pmu intake --json
+
+  # Export untracked issues as CSV
+  gh pmu intake --format csv --columns number,title,repository,labels`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runIntake(cmd, opts)
 		},
 	}
+	needsConfig(cmd)
 
 	cmd.Flags().StringVarP(&opts.apply, "apply", "a", "", "Add untracked issues to project (optionally set fields: status:backlog,priority:p1)")
 	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be added without making changes")
-	cmd.Flags().BoolVar(&opts.json, "json", false, "Output in JSON format")
+	opts.export = addExportFlags(cmd, intakeJSONIssue{}, "issues")
+	opts.format = addFormatFlags(cmd, "number", "title", "repository", "state")
 	cmd.Flags().StringArrayVarP(&opts.label, "label", "l", nil, "Filter issues by label (can be specified multiple times)")
-	cmd.Flags().StringArrayVar(&opts.assignee, "assignee", nil, "Filter issues by assignee (can be specified multiple times)")
+	cmd.Flags().StringArrayVar(&opts.assignee, "assignee", nil, "Filter issues by assignee `login`, @me, or @org/team (can be specified multiple times)")
 
 	return cmd
 }
 
-func runIntake(cmd *cobra.Command, opts *intakeOptions) error {
-	// Load configuration
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
+// intakeClient is the part of the API client used by intake
+type intakeClient interface {
+	GetProject(owner string, number int) (*api.Project, error)
+	GetProjectItems(projectID string, filter *api.ProjectItemsFilter) ([]api.ProjectItem, error)
+	GetRepositoryIssues(owner, repo, state string) ([]api.Issue, error)
+	AddIssueToProject(projectID, issueID string) (string, error)
+	SetProjectItemField(projectID, itemID, fieldName, value string) error
+	userClient
+}
 
-	cfg, err := config.LoadFromDirectory(cwd)
+func runIntake(cmd *cobra.Command, opts *intakeOptions) error {
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
+	return runIntakeWithDeps(cmd, opts, cc.cfg, cc.client)
+}
 
+func runIntakeWithDeps(cmd *cobra.Command, opts *intakeOptions, cfg *config.Config, client intakeClient) error {
 	if len(cfg.Repositories) == 0 {
 		return fmt.Errorf("no repositories configured in .gh-pmu.yml")
 	}
-
-	// Create API client
-	client := api.NewClient()
+	if err := opts.format.validate(opts.export); err != nil {
+		return err
+	}
 
 	// Get project
 	project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
@@ -141,28 +152,35 @@ func runIntake(cmd *cobra.Command, opts *intakeOptions) error {
 
 	// Apply assignee filter if specified
 	if len(opts.assignee) > 0 {
-		untrackedIssues = filterIntakeByAssignee(untrackedIssues, opts.assignee)
+		assignees, err := resolveLogins(client, opts.assignee)
+		if err != nil {
+			return err
+		}
+		untrackedIssues = filterIntakeByAssignee(untrackedIssues, assignees)
 	}
 
 	// Handle output
 	if len(untrackedIssues) == 0 {
-		if !opts.json {
-			cmd.Println("All issues are already tracked in the project")
-		} else {
-			encoder := json.NewEncoder(os.Stdout)
-			encoder.SetIndent("", "  ")
-			_ = encoder.Encode(map[string]interface{}{"issues": []interface{}{}, "count": 0})
+		if opts.export.enabled() {
+			return opts.export.write(cmd, map[string]interface{}{"issues": []interface{}{}, "count": 0})
 		}
+		if opts.format.dataOnly() && !cmd.Flags().Changed("apply") {
+			return opts.format.writeIssueRows(cmd, nil)
+		}
+		cmd.Println("All issues are already tracked in the project")
 		return nil
 	}
 
 	// Dry run - just show what would be added
 	if opts.dryRun {
-		if opts.json {
-			return outputIntakeJSON(cmd, untrackedIssues, "dry-run")
+		if opts.export.enabled() {
+			return outputIntakeJSON(cmd, untrackedIssues, "dry-run", opts.export)
+		}
+		if opts.format.dataOnly() {
+			return opts.format.writeIssueRows(cmd, untrackedIssues)
 		}
 		cmd.Printf("Would add %d issue(s) to project:\n\n", len(untrackedIssues))
-		return outputIntakeTable(cmd, untrackedIssues)
+		return outputIntakeTable(cmd, untrackedIssues, opts.format)
 	}
 
 	// Apply - add issues to project
@@ -229,8 +247,8 @@ func runIntake(cmd *cobra.Command, opts *intakeOptions) error {
 			added = append(added, issue)
 		}
 
-		if opts.json {
-			return outputIntakeJSON(cmd, added, "applied")
+		if opts.export.enabled() {
+			return outputIntakeJSON(cmd, added, "applied", opts.export)
 		}
 
 		cmd.Printf("Added %d issue(s) to project", len(added))
@@ -242,20 +260,27 @@ func runIntake(cmd *cobra.Command, opts *intakeOptions) error {
 	}
 
 	// Default - just list untracked issues
-	if opts.json {
-		return outputIntakeJSON(cmd, untrackedIssues, "untracked")
+	if opts.export.enabled() {
+		return outputIntakeJSON(cmd, untrackedIssues, "untracked", opts.export)
 	}
 
+	if opts.format.dataOnly() {
+		return opts.format.writeIssueRows(cmd, untrackedIssues)
+	}
 	cmd.Printf("Found %d untracked issue(s):\n\n", len(untrackedIssues))
-	if err := outputIntakeTable(cmd, untrackedIssues); err != nil {
+	if err := outputIntakeTable(cmd, untrackedIssues, opts.format); err != nil {
 		return err
 	}
 	cmd.Println("\nUse --apply to add these issues to the project")
 	return nil
 }
 
-func outputIntakeTable(cmd *cobra.Command, issues []api.Issue) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+func outputIntakeTable(cmd *cobra.Command, issues []api.Issue, format *formatOptions) error {
+	if format.enabled() {
+		return format.writeIssueRows(cmd, issues)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "NUMBER\tTITLE\tREPOSITORY\tSTATE")
 
 	for _, issue := range issues {
@@ -284,7 +309,7 @@ type intakeJSONIssue struct {
 	Repository string `json:"repository"`
 }
 
-func outputIntakeJSON(cmd *cobra.Command, issues []api.Issue, status string) error {
+func outputIntakeJSON(cmd *cobra.Command, issues []api.Issue, status string, export *exportOptions) error {
 	output := intakeJSONOutput{
 		Status: status,
 		Count:  len(issues),
@@ -301,9 +326,7 @@ func outputIntakeJSON(cmd *cobra.Command, issues []api.Issue, status string) err
 		})
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return export.write(cmd, output)
 }
 
 // filterIntakeByLabel filters issues to only those with at least one of the specified labels
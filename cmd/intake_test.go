@@ -89,7 +89,7 @@ func TestIntakeOptions(t *testing.T) {
 		if opts.dryRun {
 			t.Error("dryRun should be false by default")
 		}
-		if opts.json {
+		if opts.export.enabled() {
 			t.Error("json should be false by default")
 		}
 		if len(opts.label) > 0 {
@@ -122,7 +122,7 @@ func TestOutputIntakeTable(t *testing.T) {
 			},
 		}
 
-		err := outputIntakeTable(cmd, issues)
+		err := outputIntakeTable(cmd, issues, nil)
 		if err != nil {
 			t.Fatalf("outputIntakeTable failed: %v", err)
 		}
@@ -146,7 +146,7 @@ func TestOutputIntakeTable(t *testing.T) {
 		}
 
 		// outputIntakeTable writes to os.Stdout, so we just verify no error
-		err := outputIntakeTable(cmd, issues)
+		err := outputIntakeTable(cmd, issues, nil)
 		if err != nil {
 			t.Fatalf("outputIntakeTable failed with long title: %v", err)
 		}
@@ -156,7 +156,7 @@ func TestOutputIntakeTable(t *testing.T) {
 		cmd := newIntakeCommand()
 		issues := []api.Issue{}
 
-		err := outputIntakeTable(cmd, issues)
+		err := outputIntakeTable(cmd, issues, nil)
 		if err != nil {
 			t.Fatalf("outputIntakeTable failed with empty list: %v", err)
 		}
@@ -179,7 +179,7 @@ func TestOutputIntakeJSON(t *testing.T) {
 
 		// Capture stdout for JSON output
 		// Note: outputIntakeJSON writes to os.Stdout via json.NewEncoder
-		err := outputIntakeJSON(cmd, issues, "dry-run")
+		err := outputIntakeJSON(cmd, issues, "dry-run", nil)
 		if err != nil {
 			t.Fatalf("outputIntakeJSON failed: %v", err)
 		}
@@ -192,7 +192,7 @@ func TestOutputIntakeJSON(t *testing.T) {
 			cmd := newIntakeCommand()
 			issues := []api.Issue{}
 
-			err := outputIntakeJSON(cmd, issues, status)
+			err := outputIntakeJSON(cmd, issues, status, nil)
 			if err != nil {
 				t.Fatalf("outputIntakeJSON failed with status %q: %v", status, err)
 			}
@@ -208,7 +208,7 @@ func TestOutputIntakeJSON(t *testing.T) {
 			{Number: 3, Title: "Issue 3", Repository: api.Repository{Owner: "o", Name: "r"}},
 		}
 
-		err := outputIntakeJSON(cmd, issues, "test")
+		err := outputIntakeJSON(cmd, issues, "test", nil)
 		if err != nil {
 			t.Fatalf("outputIntakeJSON failed: %v", err)
 		}
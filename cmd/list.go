@@ -1,11 +1,11 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -19,10 +19,18 @@ type listOptions struct {
 	priority     string
 	assignee     string
 	label        string
+	issueType    string
 	search       string
+	filter       string
+	view         string
 	limit        int
 	hasSubIssues bool
-	json         bool
+	export       *exportOptions
+	format       *formatOptions
+	sort         string
+	groupBy      string
+	repos        []string
+	allRepos     bool
 	web          bool
 }
 
@@ -34,46 +42,117 @@ func newListCommand() *cobra.Command {
 		Short: "List issues from the configured project",
 		Long: `List issues from the configured GitHub project with their field values.
 
-By default, displays Title, Status, Priority, and Assignees for each issue.
-Use filters to narrow down the results.`,
+By default, displays Title, Status, Priority, and Assignees for each issue
+from every configured repository, with a Repo column when the issues come
+from more than one. Use filters to narrow down the results.
+
+Use --repo to list other repositories: an owner/repo, an owner for all of
+its repositories, or a wildcard such as owner/api-*. Use --all-repos to list
+every issue on the project board.
+
+Use --columns to choose the columns: any of number, title, state, url,
+repository, author, assignees, labels, milestone, type, created, updated,
+closed, comments, and body, or any project field by name or config alias.
+Use --format to write the rows as csv, tsv, markdown, json, or yaml. Issue
+details such as labels and dates are fetched only when a column, --json
+field, --sort, or --group-by uses them.
+
+Use --sort to order the issues by one or more columns, prefixing a column with
+"-" to reverse it. Single-select fields such as Status sort in the order of
+their options on the board. Use --group-by to print a section per option,
+with counts, like a text-mode board.
+
+Use --view to run a query saved in the views: section of .gh-pmu.yml with
+'gh pmu view-save'; flags given on the command line override the view.
+Use --filter to filter with the same filter strings as the project board.`,
+		Example: `  # Export the backlog as CSV with a custom field
+  gh pmu list --status backlog --format csv --columns number,title,priority,"Story Points"
+
+  # Paste a Markdown table into a report
+  gh pmu list --format markdown --columns number,title,status,assignees
+
+  # Only issues from two repositories
+  gh pmu list --repo acme/api --repo acme/web
+
+  # Issues from every repository on the board
+  gh pmu list --all-repos
+
+  # Sort by status in board order, then highest priority first
+  gh pmu list --sort status,-priority,number
+
+  # Show the board as a section per status
+  gh pmu list --group-by status
+
+  # Run a saved view, overriding its limit
+  gh pmu list --view my-wip --limit 5
+
+  # Filter as on the board
+  gh pmu list --filter "status:Todo -label:blocked"`,
 		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(cmd, opts)
 		},
 	}
+	needsConfig(cmd)
 
+	addListQueryFlags(cmd, opts)
+	cmd.Flags().StringVar(&opts.view, "view", "", "Run a saved `view` from the views: section of .gh-pmu.yml; flags override its settings")
+	opts.export = addExportFlags(cmd, JSONItem{}, "items")
+	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open project board in browser")
+
+	return cmd
+}
+
+// addListQueryFlags adds the flags that make up a list query, shared by
+// list and view-save
+func addListQueryFlags(cmd *cobra.Command, opts *listOptions) {
 	cmd.Flags().StringVarP(&opts.status, "status", "s", "", "Filter by status (e.g., backlog, in_progress, done)")
 	cmd.Flags().StringVarP(&opts.priority, "priority", "p", "", "Filter by priority (e.g., p0, p1, p2)")
-	cmd.Flags().StringVarP(&opts.assignee, "assignee", "a", "", "Filter by assignee login")
+	cmd.Flags().StringVarP(&opts.assignee, "assignee", "a", "", "Filter by assignee `login`, @me, or @org/team (comma-separated for any of several)")
 	cmd.Flags().StringVarP(&opts.label, "label", "l", "", "Filter by label name")
+	cmd.Flags().StringVar(&opts.issueType, "type", "", "Filter by issue type (e.g., Bug, Feature, Task)")
 	cmd.Flags().StringVarP(&opts.search, "search", "q", "", "Search in issue title and body")
+	cmd.Flags().StringVar(&opts.filter, "filter", "", "Filter with a project `filter` string, as typed on the board (e.g., \"status:Todo -label:bug\")")
 	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 0, "Limit number of results (0 for no limit)")
 	cmd.Flags().BoolVar(&opts.hasSubIssues, "has-sub-issues", false, "Filter to only show parent issues (issues with sub-issues)")
-	cmd.Flags().BoolVar(&opts.json, "json", false, "Output in JSON format")
-	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open project board in browser")
+	cmd.Flags().StringArrayVarP(&opts.repos, "repo", "R", nil, "List issues from `owner/repo`, an owner, or a wildcard such as owner/api-* (can be specified multiple times)")
+	cmd.Flags().BoolVar(&opts.allRepos, "all-repos", false, "List issues from every repository on the project board")
+	opts.format = addFormatFlags(cmd, "number", "title", "status", "priority", "assignees")
+	cmd.Flags().StringVar(&opts.sort, "sort", "", "Sort by `columns` (e.g., status,-priority,number; \"-\" reverses a column)")
+	cmd.Flags().StringVar(&opts.groupBy, "group-by", "", "Group issues by a `column`, with a section per option (e.g., status)")
+}
 
-	return cmd
+// listClient is the part of the API client used by list
+type listClient interface {
+	GetProject(owner string, number int) (*api.Project, error)
+	GetProjectItems(projectID string, filter *api.ProjectItemsFilter) ([]api.ProjectItem, error)
+	GetSubIssues(owner, repo string, number int) ([]api.SubIssue, error)
+	GetProjectFields(projectID string) ([]api.ProjectField, error)
+	userClient
 }
 
 func runList(cmd *cobra.Command, opts *listOptions) error {
-	// Load configuration from current directory
-	cwd, err := os.Getwd()
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
+	return runListWithDeps(cmd, opts, cc.cfg, cc.client)
+}
 
-	cfg, err := config.LoadFromDirectory(cwd)
-	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
+func runListWithDeps(cmd *cobra.Command, opts *listOptions, cfg *config.Config, client listClient) error {
+	if opts.view != "" {
+		if err := applyView(cmd, opts, cfg); err != nil {
+			return err
+		}
 	}
-
-	// Validate config
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+	if err := opts.format.validate(opts.export); err != nil {
+		return err
+	}
+	filter, err := listRepositoryFilter(opts, cfg)
+	if err != nil {
+		return err
 	}
-
-	// Create API client
-	client := api.NewClient()
 
 	// Get project
 	project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
@@ -86,13 +165,32 @@ func runList(cmd *cobra.Command, opts *listOptions) error {
 		return openInBrowser(project.URL)
 	}
 
-	// Build filter
-	var filter *api.ProjectItemsFilter
-	if len(cfg.Repositories) > 0 {
-		filter = &api.ProjectItemsFilter{
-			Repository: cfg.Repositories[0],
+	// Filter on the host where it can; the same filters are applied to the
+	// items below when it cannot
+	if filter == nil {
+		filter = &api.ProjectItemsFilter{}
+	}
+	fieldValues := make(map[string]string)
+	if opts.status != "" {
+		fieldValues["Status"] = cfg.ResolveFieldValue("status", opts.status)
+	}
+	if opts.priority != "" {
+		fieldValues["Priority"] = cfg.ResolveFieldValue("priority", opts.priority)
+	}
+	if len(fieldValues) > 0 {
+		filter.FieldValues = fieldValues
+	}
+	if opts.assignee != "" {
+		logins, err := resolveLogins(client, []string{opts.assignee})
+		if err != nil {
+			return err
 		}
+		filter.Assignee = strings.Join(logins, ",")
 	}
+	filter.Label = opts.label
+	filter.Search = opts.search
+	filter.Filter = opts.filter
+	filter.Include = listDetails(opts)
 
 	// Fetch project items
 	items, err := client.GetProjectItems(project.ID, filter)
@@ -100,49 +198,399 @@ func runList(cmd *cobra.Command, opts *listOptions) error {
 		return fmt.Errorf("failed to get project items: %w", err)
 	}
 
-	// Apply status filter
-	if opts.status != "" {
-		targetStatus := cfg.ResolveFieldValue("status", opts.status)
-		items = filterByFieldValue(items, "Status", targetStatus)
+	if !filteredByHost(client, filter) {
+		if opts.filter != "" {
+			return fmt.Errorf("cannot filter by %q: the host does not support project item queries", opts.filter)
+		}
+		for field, value := range filter.FieldValues {
+			items = filterByFieldValue(items, field, value)
+		}
+		if filter.Assignee != "" {
+			items = filterByAssignee(items, filter.Assignee)
+		}
+		if opts.label != "" {
+			items = filterByLabel(items, opts.label)
+		}
 	}
-
-	// Apply priority filter
-	if opts.priority != "" {
-		targetPriority := cfg.ResolveFieldValue("priority", opts.priority)
-		items = filterByFieldValue(items, "Priority", targetPriority)
+	if opts.search != "" {
+		items = filterBySearch(items, opts.search)
 	}
 
-	// Apply assignee filter
-	if opts.assignee != "" {
-		items = filterByAssignee(items, opts.assignee)
+	// Apply issue type filter
+	if opts.issueType != "" {
+		items = filterByType(items, opts.issueType)
 	}
 
-	// Apply label filter
-	if opts.label != "" {
-		items = filterByLabel(items, opts.label)
+	// Apply has-sub-issues filter
+	if opts.hasSubIssues {
+		if items, err = filterByHasSubIssues(client, items); err != nil {
+			return fmt.Errorf("--has-sub-issues: %w", err)
+		}
 	}
 
-	// Apply search filter
-	if opts.search != "" {
-		items = filterBySearch(items, opts.search)
-	}
+	// Apply sort and grouping, in the option order of the project's fields
+	var groups []itemGroup
+	if opts.sort != "" || opts.groupBy != "" {
+		fields, err := client.GetProjectFields(project.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get project fields: %w", err)
+		}
+		order := &itemOrder{cfg: cfg, fields: fields}
 
-	// Apply has-sub-issues filter
-	if opts.hasSubIssues {
-		items = filterByHasSubIssues(client, items)
+		if opts.sort != "" {
+			keys, err := parseSortKeys(opts.sort, order)
+			if err != nil {
+				return err
+			}
+			sortItems(items, keys, order)
+		}
+		if opts.groupBy != "" {
+			if !order.known(opts.groupBy) {
+				return fmt.Errorf("unknown --group-by column %q", opts.groupBy)
+			}
+			groups = groupItems(items, opts.groupBy, order)
+			items = items[:0]
+			for _, group := range groups {
+				items = append(items, group.items...)
+			}
+		}
 	}
 
 	// Apply limit
 	if opts.limit > 0 && len(items) > opts.limit {
 		items = items[:opts.limit]
+		groups = limitGroups(groups, opts.limit)
 	}
 
 	// Output
-	if opts.json {
-		return outputJSON(cmd, items)
+	if opts.export.enabled() {
+		return outputJSON(cmd, items, opts.export)
+	}
+
+	// Show a Repo column when issues come from more than one repository
+	showRepo := spansRepositories(items)
+	if showRepo && opts.format != nil {
+		opts.format.defaults = append([]string{opts.format.defaults[0], "repo"}, opts.format.defaults[1:]...)
+	}
+
+	if groups != nil && !opts.format.dataOnly() {
+		return outputGroups(cmd, cfg, groups, opts.format, showRepo)
+	}
+	if opts.format.enabled() {
+		return outputItemRows(cmd, cfg, items, opts.format)
+	}
+
+	return writeItemTable(cmd, items, showRepo)
+}
+
+// sortKey is one column of --sort
+type sortKey struct {
+	column string
+	desc   bool
+}
+
+// parseSortKeys parses --sort, a comma-separated list of columns where a
+// leading "-" sorts a column in descending order
+func parseSortKeys(spec string, order *itemOrder) ([]sortKey, error) {
+	var keys []sortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		key := sortKey{column: strings.TrimPrefix(part, "-"), desc: strings.HasPrefix(part, "-")}
+		if key.column == "" {
+			continue
+		}
+		if !order.known(key.column) {
+			return nil, fmt.Errorf("unknown --sort column %q", key.column)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// itemOrder orders project items by column. Single-select fields follow
+// the order of their options, numbers compare numerically, and anything
+// else compares as text.
+type itemOrder struct {
+	cfg    *config.Config
+	fields []api.ProjectField
+}
+
+// field returns the project field a column names, directly or by config alias
+func (o *itemOrder) field(column string) *api.ProjectField {
+	name := o.cfg.GetFieldName(column)
+	for i := range o.fields {
+		if strings.EqualFold(o.fields[i].Name, name) || strings.EqualFold(o.fields[i].Name, column) {
+			return &o.fields[i]
+		}
+	}
+	return nil
+}
+
+// known reports whether column is an issue column or a project field
+func (o *itemOrder) known(column string) bool {
+	return isIssueColumn(column) || o.field(column) != nil
+}
+
+// compare compares two items by column. Items without a value sort last in
+// either direction.
+func (o *itemOrder) compare(a, b api.ProjectItem, column string, desc bool) int {
+	va, vb := itemCell(o.cfg, a, column), itemCell(o.cfg, b, column)
+	switch {
+	case va == vb:
+		return 0
+	case va == "":
+		return 1
+	case vb == "":
+		return -1
+	}
+
+	c := o.compareValues(va, vb, column)
+	if desc {
+		return -c
+	}
+	return c
+}
+
+func (o *itemOrder) compareValues(va, vb, column string) int {
+	if field := o.field(column); field != nil && len(field.Options) > 0 {
+		ia, ib := optionIndex(field, va), optionIndex(field, vb)
+		if ia != ib {
+			return ia - ib
+		}
+	}
+	if na, err := strconv.ParseFloat(va, 64); err == nil {
+		if nb, err := strconv.ParseFloat(vb, 64); err == nil {
+			switch {
+			case na < nb:
+				return -1
+			case na > nb:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(va), strings.ToLower(vb))
+}
+
+// optionIndex returns the position of value among a field's options, or
+// the number of options when it is not one of them
+func optionIndex(field *api.ProjectField, value string) int {
+	for i, option := range field.Options {
+		if strings.EqualFold(option.Name, value) {
+			return i
+		}
+	}
+	return len(field.Options)
+}
+
+// sortItems sorts items by keys, keeping the project order of equal items
+func sortItems(items []api.ProjectItem, keys []sortKey, order *itemOrder) {
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, key := range keys {
+			if c := order.compare(items[i], items[j], key.column, key.desc); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// itemGroup is one section of --group-by
+type itemGroup struct {
+	name  string
+	items []api.ProjectItem
+}
+
+// groupItems splits items into a group per value of column. A single-select
+// field has a group for every option, in board order, even when empty;
+// other values follow in sorted order, and items without a value come last.
+func groupItems(items []api.ProjectItem, column string, order *itemOrder) []itemGroup {
+	var groups []itemGroup
+	index := make(map[string]int)
+	add := func(name string) int {
+		key := strings.ToLower(name)
+		if i, ok := index[key]; ok {
+			return i
+		}
+		groups = append(groups, itemGroup{name: name})
+		index[key] = len(groups) - 1
+		return len(groups) - 1
+	}
+
+	field := order.field(column)
+	if field != nil {
+		for _, option := range field.Options {
+			add(option.Name)
+		}
+	}
+	options := len(groups)
+
+	var missing []api.ProjectItem
+	for _, item := range items {
+		if item.Issue == nil {
+			continue
+		}
+		value := itemCell(order.cfg, item, column)
+		if value == "" {
+			missing = append(missing, item)
+			continue
+		}
+		i := add(value)
+		groups[i].items = append(groups[i].items, item)
+	}
+
+	extra := groups[options:]
+	sort.SliceStable(extra, func(i, j int) bool {
+		return order.compareValues(extra[i].name, extra[j].name, column) < 0
+	})
+
+	if len(missing) > 0 {
+		name := column
+		if field != nil {
+			name = field.Name
+		}
+		groups = append(groups, itemGroup{name: "No " + name, items: missing})
+	}
+	return groups
+}
+
+// limitGroups keeps the first limit items across groups
+func limitGroups(groups []itemGroup, limit int) []itemGroup {
+	for i := range groups {
+		if len(groups[i].items) > limit {
+			groups[i].items = groups[i].items[:limit]
+		}
+		limit -= len(groups[i].items)
+	}
+	return groups
+}
+
+// outputGroups prints a section per group with its count, followed by the
+// group's issues in the selected columns
+func outputGroups(cmd *cobra.Command, cfg *config.Config, groups []itemGroup, format *formatOptions, showRepo bool) error {
+	out := cmd.OutOrStdout()
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "%s (%d)\n", group.name, len(group.items))
+		if len(group.items) == 0 {
+			continue
+		}
+
+		var err error
+		if format.enabled() {
+			err = outputItemRows(cmd, cfg, group.items, format)
+		} else {
+			err = writeItemTable(cmd, group.items, showRepo)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listRepositoryFilter returns the repositories list shows: the --repo
+// patterns, every configured repository by default, or no filter with
+// --all-repos
+func listRepositoryFilter(opts *listOptions, cfg *config.Config) (*api.ProjectItemsFilter, error) {
+	if opts.allRepos {
+		if len(opts.repos) > 0 {
+			return nil, fmt.Errorf("cannot use --repo with --all-repos")
+		}
+		return nil, nil
+	}
+
+	for _, repo := range opts.repos {
+		owner, name, hasName := strings.Cut(repo, "/")
+		if owner == "" || (hasName && (name == "" || strings.Contains(name, "/"))) {
+			return nil, fmt.Errorf("invalid --repo %q: expected owner/repo, an owner, or a wildcard such as owner/api-*", repo)
+		}
 	}
 
-	return outputTable(cmd, items)
+	repos := opts.repos
+	if len(repos) == 0 {
+		repos = cfg.Repositories
+	}
+	if len(repos) == 0 {
+		return nil, nil
+	}
+	return &api.ProjectItemsFilter{Repositories: repos}, nil
+}
+
+// itemJSONDetails maps JSONItem fields to the project item details they
+// need fetched
+var itemJSONDetails = map[string]string{
+	"author":    api.DetailAuthor,
+	"labels":    api.DetailLabels,
+	"milestone": api.DetailMilestone,
+	"createdAt": api.DetailDates,
+	"updatedAt": api.DetailDates,
+	"closedAt":  api.DetailDates,
+	"comments":  api.DetailComments,
+	"body":      api.DetailBody,
+}
+
+// allDetails are the issue details JSON output without a field list needs
+var allDetails = []string{api.DetailLabels, api.DetailMilestone, api.DetailAuthor, api.DetailDates, api.DetailComments, api.DetailBody}
+
+// listDetails returns the issue details list needs fetched with project
+// items: those shown by --columns or selected with --json, or sorted or
+// grouped by. JSON output without a field list, including plain --jq and
+// --template, writes every field and so needs them all. The labels and body
+// that --label and --search need are fetched by GetProjectItems.
+func listDetails(opts *listOptions) []string {
+	var details []string
+	add := func(detail string) {
+		if detail != "" && !containsString(details, detail) {
+			details = append(details, detail)
+		}
+	}
+
+	if opts.format.enabled() {
+		for _, column := range opts.format.selected() {
+			add(columnDetails[strings.ToLower(column)])
+		}
+	}
+	if opts.export.enabled() {
+		if len(opts.export.fields) == 0 {
+			return allDetails
+		}
+		for _, field := range opts.export.fields {
+			add(itemJSONDetails[field])
+		}
+	}
+	for _, part := range strings.Split(opts.sort, ",") {
+		add(columnDetails[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(part), "-"))])
+	}
+	add(columnDetails[strings.ToLower(opts.groupBy)])
+	return details
+}
+
+// filteredByHost reports whether client filtered project items by the
+// field, assignee, label, and search predicates of filter. Clients that
+// cannot tell (such as test mocks) are assumed not to have.
+func filteredByHost(client interface{}, filter *api.ProjectItemsFilter) bool {
+	fc, ok := client.(featureClient)
+	return ok && filter.Query() != "" && fc.Supports(api.FeatureItemQuery)
+}
+
+// spansRepositories reports whether items come from more than one repository
+func spansRepositories(items []api.ProjectItem) bool {
+	first := ""
+	for _, item := range items {
+		if item.Issue == nil || item.Issue.Repository.Owner == "" {
+			continue
+		}
+		repo := item.Issue.Repository.Owner + "/" + item.Issue.Repository.Name
+		if first == "" {
+			first = repo
+		} else if !strings.EqualFold(repo, first) {
+			return true
+		}
+	}
+	return false
 }
 
 // filterByFieldValue filters items by a specific field value
@@ -159,8 +607,9 @@ func filterByFieldValue(items []api.ProjectItem, fieldName, value string) []api.
 	return filtered
 }
 
-// filterByHasSubIssues filters items to only those with sub-issues
-func filterByHasSubIssues(client *api.Client, items []api.ProjectItem) []api.ProjectItem {
+// filterByHasSubIssues filters items to only those with sub-issues. It fails
+// when the host does not support sub-issues.
+func filterByHasSubIssues(client listClient, items []api.ProjectItem) ([]api.ProjectItem, error) {
 	var filtered []api.ProjectItem
 	for _, item := range items {
 		if item.Issue == nil {
@@ -173,6 +622,9 @@ func filterByHasSubIssues(client *api.Client, items []api.ProjectItem) []api.Pro
 			item.Issue.Repository.Name,
 			item.Issue.Number,
 		)
+		if api.IsUnsupported(err) {
+			return nil, err
+		}
 		if err != nil {
 			// Skip issues where we can't fetch sub-issues
 			continue
@@ -182,7 +634,7 @@ func filterByHasSubIssues(client *api.Client, items []api.ProjectItem) []api.Pro
 			filtered = append(filtered, item)
 		}
 	}
-	return filtered
+	return filtered, nil
 }
 
 // getFieldValue gets a field value from an item
@@ -197,13 +649,23 @@ func getFieldValue(item api.ProjectItem, fieldName string) string {
 
 // outputTable outputs items in a table format
 func outputTable(cmd *cobra.Command, items []api.ProjectItem) error {
+	return writeItemTable(cmd, items, spansRepositories(items))
+}
+
+// writeItemTable outputs items in a table format, with a REPO column when
+// showRepo is set
+func writeItemTable(cmd *cobra.Command, items []api.ProjectItem, showRepo bool) error {
 	if len(items) == 0 {
 		cmd.Println("No issues found")
 		return nil
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "NUMBER\tTITLE\tSTATUS\tPRIORITY\tASSIGNEES")
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	if showRepo {
+		fmt.Fprintln(w, "NUMBER\tREPO\tTITLE\tSTATUS\tPRIORITY\tASSIGNEES")
+	} else {
+		fmt.Fprintln(w, "NUMBER\tTITLE\tSTATUS\tPRIORITY\tASSIGNEES")
+	}
 
 	for _, item := range items {
 		if item.Issue == nil {
@@ -230,8 +692,13 @@ func outputTable(cmd *cobra.Command, items []api.ProjectItem) error {
 			title = title[:47] + "..."
 		}
 
-		fmt.Fprintf(w, "#%d\t%s\t%s\t%s\t%s\n",
-			item.Issue.Number,
+		number := fmt.Sprintf("#%d", item.Issue.Number)
+		if showRepo {
+			number += "\t" + item.Issue.Repository.Owner + "/" + item.Issue.Repository.Name
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			number,
 			title,
 			status,
 			priority,
@@ -243,6 +710,39 @@ func outputTable(cmd *cobra.Command, items []api.ProjectItem) error {
 	return nil
 }
 
+// outputItemRows outputs items with the --columns and --format flags.
+// Columns other than issueColumns are project fields.
+func outputItemRows(cmd *cobra.Command, cfg *config.Config, items []api.ProjectItem, format *formatOptions) error {
+	columns := format.selected()
+	fields := projectColumns(cfg, items)
+	if cfg.Metadata != nil && len(cfg.Metadata.Fields) > 0 {
+		known := func(column string) bool {
+			return isIssueColumn(column) || containsFold(fields, column) || containsFold(fields, cfg.GetFieldName(column))
+		}
+		if err := checkColumns(columns, known, append(append([]string{}, issueColumns...), fields...)); err != nil {
+			return err
+		}
+	}
+
+	var rows [][]string
+	for _, item := range items {
+		if item.Issue == nil {
+			continue
+		}
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = itemCell(cfg, item, column)
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 && !format.dataOnly() {
+		cmd.Println("No issues found")
+		return nil
+	}
+	return format.write(cmd, columns, rows)
+}
+
 // JSONOutput represents the JSON output structure
 type JSONOutput struct {
 	Items []JSONItem `json:"items"`
@@ -255,12 +755,22 @@ type JSONItem struct {
 	State       string            `json:"state"`
 	URL         string            `json:"url"`
 	Repository  string            `json:"repository"`
+	Type        string            `json:"type,omitempty"`
 	Assignees   []string          `json:"assignees"`
 	FieldValues map[string]string `json:"fieldValues"`
+	// Fetched only when selected with --json, so empty otherwise
+	Author    string   `json:"author,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Milestone string   `json:"milestone,omitempty"`
+	CreatedAt string   `json:"createdAt,omitempty"`
+	UpdatedAt string   `json:"updatedAt,omitempty"`
+	ClosedAt  string   `json:"closedAt,omitempty"`
+	Comments  int      `json:"comments,omitempty"`
+	Body      string   `json:"body,omitempty"`
 }
 
 // outputJSON outputs items in JSON format
-func outputJSON(cmd *cobra.Command, items []api.ProjectItem) error {
+func outputJSON(cmd *cobra.Command, items []api.ProjectItem, export *exportOptions) error {
 	output := JSONOutput{
 		Items: make([]JSONItem, 0, len(items)),
 	}
@@ -276,8 +786,21 @@ func outputJSON(cmd *cobra.Command, items []api.ProjectItem) error {
 			State:       item.Issue.State,
 			URL:         item.Issue.URL,
 			Repository:  fmt.Sprintf("%s/%s", item.Issue.Repository.Owner, item.Issue.Repository.Name),
+			Type:        item.Issue.Type,
 			Assignees:   make([]string, 0),
 			FieldValues: make(map[string]string),
+			Author:      item.Issue.Author.Login,
+			CreatedAt:   item.Issue.CreatedAt,
+			UpdatedAt:   item.Issue.UpdatedAt,
+			ClosedAt:    item.Issue.ClosedAt,
+			Comments:    item.Issue.Comments,
+			Body:        item.Issue.Body,
+		}
+		if item.Issue.Milestone != nil {
+			jsonItem.Milestone = item.Issue.Milestone.Title
+		}
+		for _, l := range item.Issue.Labels {
+			jsonItem.Labels = append(jsonItem.Labels, l.Name)
 		}
 
 		for _, a := range item.Issue.Assignees {
@@ -291,28 +814,37 @@ func outputJSON(cmd *cobra.Command, items []api.ProjectItem) error {
 		output.Items = append(output.Items, jsonItem)
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return export.write(cmd, output)
 }
 
-// filterByAssignee filters items by assignee login
+// filterByAssignee filters items by assignee login, or any of several
+// comma-separated logins
 func filterByAssignee(items []api.ProjectItem, assignee string) []api.ProjectItem {
+	logins := strings.Split(assignee, ",")
 	var filtered []api.ProjectItem
 	for _, item := range items {
 		if item.Issue == nil {
 			continue
 		}
-		for _, a := range item.Issue.Assignees {
-			if strings.EqualFold(a.Login, assignee) {
-				filtered = append(filtered, item)
-				break
-			}
+		if assignedToAny(item.Issue.Assignees, logins) {
+			filtered = append(filtered, item)
 		}
 	}
 	return filtered
 }
 
+// assignedToAny reports whether any of assignees has one of logins
+func assignedToAny(assignees []api.Actor, logins []string) bool {
+	for _, a := range assignees {
+		for _, login := range logins {
+			if strings.EqualFold(a.Login, strings.TrimSpace(login)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // filterByLabel filters items by label name
 func filterByLabel(items []api.ProjectItem, label string) []api.ProjectItem {
 	var filtered []api.ProjectItem
@@ -330,6 +862,17 @@ func filterByLabel(items []api.ProjectItem, label string) []api.ProjectItem {
 	return filtered
 }
 
+// filterByType filters items by issue type name
+func filterByType(items []api.ProjectItem, issueType string) []api.ProjectItem {
+	var filtered []api.ProjectItem
+	for _, item := range items {
+		if item.Issue != nil && strings.EqualFold(item.Issue.Type, issueType) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
 // filterBySearch filters items by searching in title and body
 func filterBySearch(items []api.ProjectItem, search string) []api.ProjectItem {
 	var filtered []api.ProjectItem
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -532,7 +536,7 @@ func TestOutputJSON_EmptyItems(t *testing.T) {
 
 	// outputJSON writes to os.Stdout, not cmd buffer
 	// But we can verify structure by checking for error
-	err := outputJSON(cmd, []api.ProjectItem{})
+	err := outputJSON(cmd, []api.ProjectItem{}, nil)
 	if err != nil {
 		t.Fatalf("outputJSON() error = %v", err)
 	}
@@ -565,7 +569,7 @@ func TestOutputJSON_WithItems(t *testing.T) {
 		},
 	}
 
-	err := outputJSON(cmd, items)
+	err := outputJSON(cmd, items, nil)
 	if err != nil {
 		t.Fatalf("outputJSON() error = %v", err)
 	}
@@ -579,7 +583,7 @@ func TestOutputJSON_NilIssue(t *testing.T) {
 		{ID: "1", Issue: nil},
 	}
 
-	err := outputJSON(cmd, items)
+	err := outputJSON(cmd, items, nil)
 	if err != nil {
 		t.Fatalf("outputJSON() error = %v", err)
 	}
@@ -872,6 +876,20 @@ func TestFilterByLabel(t *testing.T) {
 // These tests cover the function's behavior for edge cases and structural patterns.
 // Full integration testing with actual GitHub API is done in integration tests.
 
+func TestFilterByType(t *testing.T) {
+	items := []api.ProjectItem{
+		{ID: "1", Issue: &api.Issue{Number: 1, Type: "Bug"}},
+		{ID: "2", Issue: &api.Issue{Number: 2, Type: "Feature"}},
+		{ID: "3", Issue: &api.Issue{Number: 3}},
+		{ID: "4"},
+	}
+
+	filtered := filterByType(items, "bug")
+	if len(filtered) != 1 || filtered[0].Issue.Number != 1 {
+		t.Errorf("Expected only #1, got %+v", filtered)
+	}
+}
+
 func TestFilterByHasSubIssues_NilClient(t *testing.T) {
 	// Test that the function handles nil client gracefully
 	// Note: In production, this would panic, so we just test the function exists
@@ -879,7 +897,7 @@ func TestFilterByHasSubIssues_NilClient(t *testing.T) {
 
 	// We can't call with nil client as it would panic,
 	// but we can verify the function signature
-	var _ func(*api.Client, []api.ProjectItem) []api.ProjectItem = filterByHasSubIssues
+	var _ func(listClient, []api.ProjectItem) ([]api.ProjectItem, error) = filterByHasSubIssues
 }
 
 func TestFilterByHasSubIssues_EmptyItems(t *testing.T) {
@@ -891,8 +909,11 @@ func TestFilterByHasSubIssues_EmptyItems(t *testing.T) {
 	}
 
 	// Empty items should return empty result without any API calls
-	result := filterByHasSubIssues(client, []api.ProjectItem{})
+	result, err := filterByHasSubIssues(client, []api.ProjectItem{})
 
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 	if len(result) != 0 {
 		t.Errorf("Expected empty result for empty input, got %d items", len(result))
 	}
@@ -911,8 +932,11 @@ func TestFilterByHasSubIssues_NilIssueItems(t *testing.T) {
 		{ID: "2", Issue: nil},
 	}
 
-	result := filterByHasSubIssues(client, items)
+	result, err := filterByHasSubIssues(client, items)
 
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 	if len(result) != 0 {
 		t.Errorf("Expected empty result for items with nil Issue, got %d items", len(result))
 	}
@@ -921,10 +945,28 @@ func TestFilterByHasSubIssues_NilIssueItems(t *testing.T) {
 func TestFilterByHasSubIssues_FunctionSignature(t *testing.T) {
 	// Verify the function has the expected signature
 	// This is a compile-time check that the function exists and has correct types
-	type filterFunc func(*api.Client, []api.ProjectItem) []api.ProjectItem
+	type filterFunc func(listClient, []api.ProjectItem) ([]api.ProjectItem, error)
 	var _ filterFunc = filterByHasSubIssues
 }
 
+func TestFilterByHasSubIssues_UnsupportedHost(t *testing.T) {
+	client := &unsupportedSubIssuesFake{fakeAPI: newFakeAPI()}
+
+	_, err := filterByHasSubIssues(client, client.items)
+	if !api.IsUnsupported(err) {
+		t.Errorf("Expected unsupported error, got %v", err)
+	}
+}
+
+// unsupportedSubIssuesFake is a host without sub-issue support
+type unsupportedSubIssuesFake struct {
+	*fakeAPI
+}
+
+func (f *unsupportedSubIssuesFake) GetSubIssues(owner, repo string, number int) ([]api.SubIssue, error) {
+	return nil, &api.UnsupportedError{Feature: api.FeatureSubIssues, Host: "github.example.com"}
+}
+
 func TestFilterBySearch(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -1040,3 +1082,371 @@ func TestFilterBySearch(t *testing.T) {
 		})
 	}
 }
+
+// newBoardAPI returns the fake API with items across statuses and priorities
+func newBoardAPI() *fakeAPI {
+	client := newFakeAPI()
+	item := func(number int, title, status, priority string) api.ProjectItem {
+		values := []api.FieldValue{}
+		if status != "" {
+			values = append(values, api.FieldValue{Field: "Status", Value: status})
+		}
+		if priority != "" {
+			values = append(values, api.FieldValue{Field: "Priority", Value: priority})
+		}
+		return api.ProjectItem{
+			ID:          fmt.Sprintf("ITEM_%d", number),
+			Issue:       &api.Issue{Number: number, Title: title, State: "OPEN"},
+			FieldValues: values,
+		}
+	}
+	client.items = []api.ProjectItem{
+		item(1, "Alpha", "Done", "P2"),
+		item(2, "Bravo", "Todo", "P2"),
+		item(3, "Charlie", "In progress", "P0"),
+		item(4, "Delta", "Todo", "P0"),
+		item(5, "Echo", "", "P1"),
+	}
+	return client
+}
+
+func listNumbers(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := runRootWith(t, newBoardAPI(), append([]string{"list", "--format", "tsv", "--columns", "number"}, args...)...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return strings.Join(strings.Split(strings.TrimSpace(out), "\n")[1:], ",")
+}
+
+func TestList_SortFollowsOptionOrder(t *testing.T) {
+	tests := []struct {
+		sort string
+		want string
+	}{
+		{"status", "2,4,3,1,5"},
+		{"status,-priority", "2,4,3,1,5"},
+		{"status,priority", "4,2,3,1,5"},
+		{"-status,number", "1,3,2,4,5"},
+		{"priority,-number", "4,3,5,2,1"},
+		{"title", "1,2,3,4,5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			if got := listNumbers(t, "--sort", tt.sort); got != tt.want {
+				t.Errorf("--sort %s = %s, want %s", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestList_SortBeforeLimit(t *testing.T) {
+	if got := listNumbers(t, "--sort", "priority", "--limit", "2"); got != "3,4" {
+		t.Errorf("Expected the two P0 issues, got %s", got)
+	}
+}
+
+func TestList_SortUnknownColumn(t *testing.T) {
+	_, err := runRootWith(t, newBoardAPI(), "list", "--sort", "estimate")
+	if err == nil || !strings.Contains(err.Error(), `unknown --sort column "estimate"`) {
+		t.Errorf("Expected unknown column error, got %v", err)
+	}
+}
+
+func TestList_GroupByStatus(t *testing.T) {
+	out, err := runRootWith(t, newBoardAPI(), "list", "--group-by", "status", "--columns", "number,priority")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := `Todo (2)
+NUMBER  PRIORITY
+2       P2
+4       P0
+
+In progress (1)
+NUMBER  PRIORITY
+3       P0
+
+Done (1)
+NUMBER  PRIORITY
+1       P2
+
+No Status (1)
+NUMBER  PRIORITY
+5       P1
+`
+	if out != want {
+		t.Errorf("Unexpected grouped output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestList_GroupByShowsEmptyOptions(t *testing.T) {
+	out, err := runRootWith(t, newBoardAPI(), "list", "--group-by", "priority", "--status", "done")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out, "P0 (0)\n\nP1 (0)\n\nP2 (1)\nNUMBER") || !strings.Contains(out, "#1") {
+		t.Errorf("Expected a section per priority with the default table, got:\n%s", out)
+	}
+}
+
+func TestList_GroupByDataFormatOrdersRows(t *testing.T) {
+	if got := listNumbers(t, "--group-by", "status", "--sort", "-priority"); got != "2,4,3,1,5" {
+		t.Errorf("Expected rows ordered by group, got %s", got)
+	}
+}
+
+func TestGroupItems_NonSelectColumn(t *testing.T) {
+	order := &itemOrder{cfg: &config.Config{}}
+	items := []api.ProjectItem{
+		{Issue: &api.Issue{Number: 1, Assignees: []api.Actor{{Login: "zoe"}}}},
+		{Issue: &api.Issue{Number: 2}},
+		{Issue: &api.Issue{Number: 3, Assignees: []api.Actor{{Login: "amy"}}}},
+		{Issue: &api.Issue{Number: 4, Assignees: []api.Actor{{Login: "Zoe"}}}},
+	}
+
+	groups := groupItems(items, "assignees", order)
+	var names []string
+	for _, g := range groups {
+		names = append(names, fmt.Sprintf("%s=%d", g.name, len(g.items)))
+	}
+	if got := strings.Join(names, ","); got != "amy=1,zoe=2,No assignees=1" {
+		t.Errorf("Unexpected groups %s", got)
+	}
+}
+
+func TestLimitGroups(t *testing.T) {
+	groups := []itemGroup{
+		{name: "a", items: make([]api.ProjectItem, 2)},
+		{name: "b", items: make([]api.ProjectItem, 3)},
+		{name: "c", items: make([]api.ProjectItem, 1)},
+	}
+	groups = limitGroups(groups, 3)
+	if len(groups[0].items) != 2 || len(groups[1].items) != 1 || len(groups[2].items) != 0 {
+		t.Errorf("Unexpected group sizes after limit: %d %d %d", len(groups[0].items), len(groups[1].items), len(groups[2].items))
+	}
+}
+
+// newMultiRepoAPI returns the fake API with issues from three repositories
+func newMultiRepoAPI() *fakeAPI {
+	client := newFakeAPI()
+	client.items = append(client.items,
+		api.ProjectItem{ID: "ITEM_3", Issue: &api.Issue{Number: 3, Title: "Landing page", State: "OPEN", Repository: api.Repository{Owner: "acme", Name: "web"}}},
+		api.ProjectItem{ID: "ITEM_4", Issue: &api.Issue{Number: 4, Title: "CLI flags", State: "OPEN", Repository: api.Repository{Owner: "tools", Name: "cli"}}},
+	)
+	return client
+}
+
+func TestList_DefaultsToConfiguredRepositories(t *testing.T) {
+	client := newMultiRepoAPI()
+	cc := newTestCommandContext(client)
+	cc.cfg.Repositories = []string{"acme/api", "acme/web"}
+
+	buf := new(bytes.Buffer)
+	root := NewRootCommand()
+	root.SetOut(buf)
+	root.SetContext(withCommandContext(context.Background(), cc))
+	root.SetArgs([]string{"list"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "REPO") || !strings.Contains(out, "acme/web") || !strings.Contains(out, "Login page") {
+		t.Errorf("Expected issues from both configured repositories with a Repo column, got:\n%s", out)
+	}
+	if strings.Contains(out, "CLI flags") {
+		t.Errorf("Expected issues outside the configured repositories to be hidden, got:\n%s", out)
+	}
+}
+
+func TestList_SingleRepositoryHidesRepoColumn(t *testing.T) {
+	out, err := runRootWith(t, newMultiRepoAPI(), "list")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(out, "REPO") || strings.Contains(out, "Landing page") {
+		t.Errorf("Expected only acme/api issues without a Repo column, got:\n%s", out)
+	}
+}
+
+func TestList_RepoFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"exact", []string{"--repo", "acme/web"}, "3"},
+		{"repeated", []string{"--repo", "acme/web", "--repo", "tools/cli"}, "3,4"},
+		{"owner", []string{"--repo", "acme"}, "1,2,3"},
+		{"wildcard", []string{"-R", "*/c*"}, "4"},
+		{"all repos", []string{"--all-repos"}, "1,2,3,4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"list", "--format", "csv", "--columns", "number"}, tt.args...)
+			out, err := runRootWith(t, newMultiRepoAPI(), args...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := strings.Join(strings.Split(strings.TrimSpace(out), "\n")[1:], ","); got != tt.want {
+				t.Errorf("Got issues %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestList_RepoColumnInFormats(t *testing.T) {
+	out, err := runRootWith(t, newMultiRepoAPI(), "list", "--all-repos", "--format", "csv")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out, "number,repo,title,status,priority,assignees\n") || !strings.Contains(out, "4,tools/cli,CLI flags") {
+		t.Errorf("Expected a repo column in the default columns, got:\n%s", out)
+	}
+}
+
+func TestListRepositoryFilter_Errors(t *testing.T) {
+	cfg := &config.Config{Repositories: []string{"acme/api"}}
+
+	if _, err := listRepositoryFilter(&listOptions{repos: []string{"acme/api"}, allRepos: true}, cfg); err == nil || !strings.Contains(err.Error(), "cannot use --repo with --all-repos") {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+	for _, repo := range []string{"/api", "acme/", "acme/api/extra"} {
+		if _, err := listRepositoryFilter(&listOptions{repos: []string{repo}}, cfg); err == nil || !strings.Contains(err.Error(), "invalid --repo") {
+			t.Errorf("Expected invalid --repo error for %q, got %v", repo, err)
+		}
+	}
+	if filter, err := listRepositoryFilter(&listOptions{allRepos: true}, cfg); err != nil || filter != nil {
+		t.Errorf("Expected no filter with --all-repos, got %+v, %v", filter, err)
+	}
+}
+
+// hostFilteringAPI is the fake API on a host that may filter project items
+// itself; it records the filter and returns every item, as if the host had
+// matched them all
+type hostFilteringAPI struct {
+	*fakeAPI
+	itemQuery bool
+	filter    *api.ProjectItemsFilter
+}
+
+func (h *hostFilteringAPI) GetProjectItems(projectID string, filter *api.ProjectItemsFilter) ([]api.ProjectItem, error) {
+	h.filter = filter
+	return h.fakeAPI.items, nil
+}
+
+func (h *hostFilteringAPI) Supports(feature string) bool {
+	return feature != api.FeatureItemQuery || h.itemQuery
+}
+
+func (h *hostFilteringAPI) Host() string {
+	return "github.com"
+}
+
+func TestList_SendsFiltersToHost(t *testing.T) {
+	client := &hostFilteringAPI{fakeAPI: newFakeAPI(), itemQuery: true}
+
+	out, err := runRootWith(t, client, "list", "--status", "done", "--assignee", "octocat", "--label", "bug")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := &api.ProjectItemsFilter{
+		Repositories: []string{"acme/api"},
+		FieldValues:  map[string]string{"Status": "Done"},
+		Assignee:     "octocat",
+		Label:        "bug",
+	}
+	if !reflect.DeepEqual(client.filter, want) {
+		t.Errorf("Unexpected filter %+v", client.filter)
+	}
+	if !strings.Contains(out, "Login page") || !strings.Contains(out, "Signup flow") {
+		t.Errorf("Expected the host's results without filtering them again, got:\n%s", out)
+	}
+}
+
+func TestList_SearchesTitleAndBodyOnHostFilteringHosts(t *testing.T) {
+	client := &hostFilteringAPI{fakeAPI: newFakeAPI(), itemQuery: true}
+	client.items[1].Issue.Body = "Mentions the login PAGE"
+
+	out, err := runRootWith(t, client, "list", "--status", "todo", "--search", "page")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query := client.filter.Query(); strings.Contains(query, "page") {
+		t.Errorf("Expected the search to stay client-side, got query %q", query)
+	}
+	if !strings.Contains(out, "Login page") || !strings.Contains(out, "Signup flow") {
+		t.Errorf("Expected matches in the title and the body, got:\n%s", out)
+	}
+
+	out, err = runRootWith(t, &hostFilteringAPI{fakeAPI: newFakeAPI(), itemQuery: true}, "list", "--search", "signup")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(out, "Login page") || !strings.Contains(out, "Signup flow") {
+		t.Errorf("Expected only the matching issue, got:\n%s", out)
+	}
+}
+
+func TestList_FiltersItemsWhenHostCannot(t *testing.T) {
+	client := &hostFilteringAPI{fakeAPI: newFakeAPI()}
+
+	out, err := runRootWith(t, client, "list", "--status", "todo", "--search", "page")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Login page") || strings.Contains(out, "Signup flow") {
+		t.Errorf("Expected client-side filtering, got:\n%s", out)
+	}
+}
+
+func TestListDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"default table", nil, nil},
+		{"plain json", []string{"--json"}, allDetails},
+		{"jq", []string{"--jq", ".items[].labels"}, allDetails},
+		{"template", []string{"--template", "{{range .items}}{{.milestone}}{{end}}"}, allDetails},
+		{"jq over json fields", []string{"--json", "number,labels", "--jq", ".items"}, []string{api.DetailLabels}},
+		{"columns", []string{"--columns", "number,Labels,created,closed"}, []string{api.DetailLabels, api.DetailDates}},
+		{"json fields", []string{"--json", "number,milestone,comments"}, []string{api.DetailMilestone, api.DetailComments}},
+		{"sort and group", []string{"--sort", "-updated,status", "--group-by", "author"}, []string{api.DetailDates, api.DetailAuthor}},
+		{"filters", []string{"--label", "bug", "--search", "login"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &hostFilteringAPI{fakeAPI: newFakeAPI()}
+			if _, err := runRootWith(t, client, append([]string{"list"}, tt.args...)...); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(client.filter.Include, tt.want) {
+				t.Errorf("Expected details %v, got %v", tt.want, client.filter.Include)
+			}
+		})
+	}
+}
+
+func TestList_DetailColumns(t *testing.T) {
+	fake := newFakeAPI()
+	fake.items[0].Issue.Labels = []api.Label{{Name: "bug"}, {Name: "ui"}}
+	fake.items[0].Issue.CreatedAt = "2026-01-02T03:04:05Z"
+	fake.items[0].Issue.Comments = 4
+
+	out, err := runRootWith(t, fake, "list", "--format", "csv", "--columns", "number,labels,created,comments", "--limit", "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := "number,labels,created,comments\n" + fmt.Sprintf("%d", fake.items[0].Issue.Number) + ",\"bug, ui\",2026-01-02T03:04:05Z,4\n"
+	if out != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out)
+	}
+}
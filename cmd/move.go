@@ -71,6 +71,7 @@ Examples:
 			return runMove(cmd, args, opts)
 		},
 	}
+	needsConfig(cmd)
 
 	cmd.Flags().StringVarP(&opts.status, "status", "s", "", "Set project status field")
 	cmd.Flags().StringVarP(&opts.priority, "priority", "p", "", "Set project priority field")
@@ -84,10 +85,13 @@ Examples:
 
 // issueInfo holds information about an issue to be updated
 type issueInfo struct {
+	ID     string
 	Owner  string
 	Repo   string
 	Number int
 	Title  string
+	State  string
+	URL    string
 	ItemID string
 	Depth  int
 }
@@ -98,25 +102,12 @@ func runMove(cmd *cobra.Command, args []string, opts *moveOptions) error {
 		return fmt.Errorf("at least one of --status or --priority is required")
 	}
 
-	// Load configuration
-	cwd, err := os.Getwd()
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-
-	cfg, err := config.LoadFromDirectory(cwd)
-	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
 
-	// Create API client
-	client := api.NewClient()
-
-	return runMoveWithDeps(cmd, args, opts, cfg, client)
+	return runMoveWithDeps(cmd, args, opts, cc.cfg, cc.client)
 }
 
 // runMoveWithDeps is the testable implementation of runMove
@@ -129,15 +120,9 @@ func runMoveWithDeps(cmd *cobra.Command, args []string, opts *moveOptions, cfg *
 
 	// If owner/repo not specified, use first repo from config
 	if owner == "" || repo == "" {
-		if len(cfg.Repositories) == 0 {
-			return fmt.Errorf("no repository specified and none configured")
-		}
-		parts := strings.Split(cfg.Repositories[0], "/")
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid repository format in config: %s", cfg.Repositories[0])
+		if owner, repo, err = defaultRepository(cfg); err != nil {
+			return err
 		}
-		owner = parts[0]
-		repo = parts[1]
 	}
 
 	// Get issue to verify it exists
@@ -179,6 +164,7 @@ func runMoveWithDeps(cmd *cobra.Command, args []string, opts *moveOptions, cfg *
 		Repo:   repo,
 		Number: number,
 		Title:  issue.Title,
+		URL:    issue.URL,
 		ItemID: rootItemID,
 		Depth:  0,
 	}}
@@ -186,10 +172,15 @@ func runMoveWithDeps(cmd *cobra.Command, args []string, opts *moveOptions, cfg *
 	// If recursive, collect all sub-issues
 	if opts.recursive {
 		subIssues, err := collectSubIssuesRecursive(client, owner, repo, number, itemIDMap, 1, opts.depth)
-		if err != nil {
+		switch {
+		case api.IsUnsupported(err):
+			// Older GitHub Enterprise Server: update the issue itself
+			fmt.Fprintf(os.Stderr, "Warning: %v; updating #%d only\n", err, number)
+		case err != nil:
 			return fmt.Errorf("failed to collect sub-issues: %w", err)
+		default:
+			issuesToUpdate = append(issuesToUpdate, subIssues...)
 		}
-		issuesToUpdate = append(issuesToUpdate, subIssues...)
 	}
 
 	// Resolve field values
@@ -279,7 +270,11 @@ func runMoveWithDeps(cmd *cobra.Command, args []string, opts *moveOptions, cfg *
 			for _, desc := range changeDescriptions {
 				fmt.Printf("  • %s\n", desc)
 			}
-			fmt.Printf("🔗 https://github.com/%s/%s/issues/%d\n", info.Owner, info.Repo, info.Number)
+			link := info.URL
+			if link == "" {
+				link = issueURL(cfg.Host, info.Owner, info.Repo, info.Number)
+			}
+			fmt.Printf("🔗 %s\n", link)
 		}
 	}
 
@@ -322,10 +317,13 @@ func collectSubIssuesRecursive(client moveClient, owner, repo string, number int
 		itemID := itemIDMap[key] // may be empty if not in project
 
 		info := issueInfo{
+			ID:     sub.ID,
 			Owner:  subOwner,
 			Repo:   subRepo,
 			Number: sub.Number,
 			Title:  sub.Title,
+			State:  sub.State,
+			URL:    sub.URL,
 			ItemID: itemID,
 			Depth:  currentDepth,
 		}
@@ -1114,3 +1114,22 @@ func TestCollectSubIssuesRecursive_MaxDepthZero(t *testing.T) {
 		t.Errorf("Expected 0 sub-issues with maxDepth=0, got %d", len(result))
 	}
 }
+
+func TestRunMoveWithDeps_RecursiveOnHostWithoutSubIssues(t *testing.T) {
+	mock := setupMockWithIssue(123, "Test Issue", "item-123")
+	mock.getSubIssuesErr = fmt.Errorf("failed to get sub-issues: %w",
+		&api.UnsupportedError{Feature: api.FeatureSubIssues, Host: "ghe.example.com"})
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+
+	opts := &moveOptions{status: "done", recursive: true, yes: true, depth: 10}
+	if err := runMoveWithDeps(cmd, []string{"123"}, opts, testMoveConfig(), mock); err != nil {
+		t.Fatalf("Expected the issue itself to be moved, got %v", err)
+	}
+
+	if len(mock.fieldUpdates) != 1 || mock.fieldUpdates[0].itemID != "item-123" {
+		t.Errorf("Expected only item-123 to be updated, got %+v", mock.fieldUpdates)
+	}
+}
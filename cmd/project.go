@@ -0,0 +1,736 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	tmpl "github.com/scooter-indie/gh-pmu/internal/template"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newProjectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "Manage GitHub projects",
+		Long: `Create and manage GitHub Projects (v2).
+
+Projects can be created from the YAML templates bundled with gh-pmu
+(kanban, scrum, bug-tracker, and the manuscript templates) or from
+your own template files.`,
+	}
+
+	cmd.AddCommand(newProjectCreateCommand())
+	cmd.AddCommand(newProjectExportTemplateCommand())
+
+	return cmd
+}
+
+type projectCreateOptions struct {
+	fromTemplate string
+	title        string
+	owner        string
+	vars         []string
+	dryRun       bool
+	noConfig     bool
+	force        bool
+}
+
+// projectCreateClient defines the interface for API methods used by project create.
+// This allows for easier testing with mock implementations.
+type projectCreateClient interface {
+	GetOwnerID(login string) (string, error)
+	CreateProject(ownerID, title string) (*api.Project, error)
+	UpdateProjectDetails(projectID, shortDescription, readme string, public bool) error
+	GetProjectFields(projectID string) ([]api.ProjectField, error)
+	CreateProjectField(projectID, name, dataType string, options []api.FieldOption) (*api.ProjectField, error)
+	CreateIterationField(projectID, name, startDate string, duration, count int) (*api.ProjectField, error)
+	UpdateSingleSelectOptions(fieldID string, options []api.FieldOption) (*api.ProjectField, error)
+	LinkProjectToRepository(projectID, owner, repo string) error
+}
+
+// defaultIterationDuration is used for iteration fields that do not declare a duration
+const defaultIterationDuration = 14
+
+// defaultIterationCount is the number of iterations created for a new iteration field
+const defaultIterationCount = 3
+
+func newProjectCreateCommand() *cobra.Command {
+	opts := &projectCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a project from a template",
+		Long: `Create a new GitHub project from a YAML template.
+
+The template may be a file path or the name of a built-in template
+(kanban, scrum, bug-tracker, simple-manuscript, fiction-manuscript,
+nonfiction-manuscript).
+
+Placeholders such as {{.ProjectName}} and {{.Repository}} are rendered
+before the project is created. Built-in variables:
+  ProjectName  the --title value
+  Repository   the repository detected from git remote (owner/repo)
+  Owner        the project owner
+  User         the project owner login
+  Date         today's date (YYYY-MM-DD)
+
+Use --var Key=Value to set or override variables.
+
+The project's single-select, text, number, date, and iteration fields
+are created, the template's repositories are linked, and a matching
+.gh-pmu.yml is written with the template's field aliases.
+
+Views and workflows cannot be created through the GitHub API and must
+be configured in the web UI.`,
+		Example: `  # Create a Kanban board for the current repository
+  gh pmu project create --from-template kanban --title "Team Board"
+
+  # Create from a local template with variables
+  gh pmu project create --from-template ./scrum.yml --var Repository=my-org/api
+
+  # Preview without creating anything
+  gh pmu project create --from-template scrum --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectCreate(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.fromTemplate, "from-template", "", "Template file path or built-in template name (required)")
+	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Project title (defaults to the template's title)")
+	cmd.Flags().StringVar(&opts.owner, "owner", "", "Project owner (user or organization, defaults to the repository owner)")
+	cmd.Flags().StringArrayVar(&opts.vars, "var", nil, "Set a template variable (Key=Value, can be specified multiple times)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be created without making changes")
+	cmd.Flags().BoolVar(&opts.noConfig, "no-config", false, "Do not write a .gh-pmu.yml for the new project")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite an existing .gh-pmu.yml")
+
+	_ = cmd.MarkFlagRequired("from-template")
+
+	return cmd
+}
+
+func runProjectCreate(cmd *cobra.Command, opts *projectCreateOptions) error {
+	data, err := tmpl.ReadSource(opts.fromTemplate)
+	if err != nil {
+		return err
+	}
+
+	remoteHost, detectedRepo := detectRemote()
+	vars, err := buildTemplateVars(opts, detectedRepo, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if vars["Owner"] == "" {
+		return fmt.Errorf("project owner is required (use --owner or run from a repository with a GitHub remote)")
+	}
+
+	varNames := make([]string, 0, len(vars))
+	for name := range vars {
+		varNames = append(varNames, name)
+	}
+	problems, err := tmpl.Validate(data, varNames)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		printTemplateProblems(cmd, opts.fromTemplate, problems)
+		return fmt.Errorf("template has %d problem(s); run 'gh pmu template validate' for details", len(problems))
+	}
+
+	t, err := tmpl.Parse(data, vars)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	host := resolveHost(cmd, cwd, remoteHost)
+	client := api.NewClientForHost(host)
+
+	return runProjectCreateWithDeps(cmd, opts, t, vars["Owner"], host, cwd, client)
+}
+
+// resolveHost returns the API host for commands that run with or without a
+// configuration file: the configured host, with GH_HOST applied, or else the
+// host of the git remote. It is empty for github.com.
+func resolveHost(cmd *cobra.Command, dir, remoteHost string) string {
+	host := remoteHost
+	if cfg, err := loadConfig(cmd, dir); err == nil {
+		if cfg.Host != "" {
+			host = cfg.Host
+		}
+	} else if env, err := config.EnvOverrides(); err == nil && env.Host != "" {
+		host = env.Host
+	}
+	if strings.EqualFold(host, api.DefaultHost) {
+		return ""
+	}
+	return host
+}
+
+// buildTemplateVars builds the variables available to template placeholders.
+// --var values take precedence over the built-in variables.
+func buildTemplateVars(opts *projectCreateOptions, detectedRepo string, now time.Time) (map[string]string, error) {
+	owner := opts.owner
+	if owner == "" && detectedRepo != "" {
+		owner, _ = splitRepository(detectedRepo)
+	}
+
+	vars := map[string]string{
+		"ProjectName": opts.title,
+		"Repository":  detectedRepo,
+		"Owner":       owner,
+		"User":        owner,
+		"Date":        now.Format("2006-01-02"),
+	}
+
+	for _, v := range opts.vars {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected Key=Value", v)
+		}
+		vars[strings.TrimSpace(parts[0])] = parts[1]
+	}
+
+	return vars, nil
+}
+
+// runProjectCreateWithDeps is the testable implementation of runProjectCreate
+func runProjectCreateWithDeps(cmd *cobra.Command, opts *projectCreateOptions, t *tmpl.Template, owner, host, dir string, client projectCreateClient) error {
+	out := cmd.OutOrStdout()
+	u := ui.New(out)
+
+	title := opts.title
+	if title == "" {
+		title = t.Project.Title
+	}
+	if title == "" {
+		return fmt.Errorf("project title is required (use --title or set project.title in the template)")
+	}
+
+	repos := templateRepositories(t)
+
+	if opts.dryRun {
+		describeProjectTemplate(cmd, t, title, owner, repos)
+		return nil
+	}
+
+	ownerID, err := client.GetOwnerID(owner)
+	if err != nil {
+		return fmt.Errorf("failed to resolve owner: %w", err)
+	}
+
+	project, err := client.CreateProject(ownerID, title)
+	if err != nil {
+		return err
+	}
+	u.Success(fmt.Sprintf("Created project: %s (#%d)", project.Title, project.Number))
+
+	if t.Project.Description != "" || t.Project.Readme != "" || t.Project.Visibility != "" {
+		public := strings.EqualFold(t.Project.Visibility, "public")
+		if err := client.UpdateProjectDetails(project.ID, shortDescription(t.Project.Description), t.Project.Readme, public); err != nil {
+			u.Warning(fmt.Sprintf("Could not set project description and readme: %v", err))
+		}
+	}
+
+	existing, err := client.GetProjectFields(project.ID)
+	if err != nil {
+		u.Warning(fmt.Sprintf("Could not fetch default project fields: %v", err))
+	}
+
+	created := 0
+	for _, f := range t.Fields {
+		if err := createTemplateField(client, project.ID, f, existing, time.Now()); err != nil {
+			u.Warning(err.Error())
+			continue
+		}
+		created++
+	}
+	u.Success(fmt.Sprintf("Configured %d of %d field(s)", created, len(t.Fields)))
+
+	var linked []string
+	for _, repo := range repos {
+		repoOwner, repoName := splitRepository(repo)
+		if err := client.LinkProjectToRepository(project.ID, repoOwner, repoName); err != nil {
+			u.Warning(fmt.Sprintf("Could not link %s: %v", repo, err))
+			continue
+		}
+		linked = append(linked, repo)
+	}
+	if len(linked) > 0 {
+		u.Success(fmt.Sprintf("Linked repositories: %s", strings.Join(linked, ", ")))
+	}
+
+	if len(t.Views) > 0 || len(t.Workflows) > 0 {
+		u.Info(fmt.Sprintf("%d view(s) and %d workflow(s) must be configured in the web UI (not supported by the GitHub API)", len(t.Views), len(t.Workflows)))
+	}
+
+	configStatus := "skipped (--no-config)"
+	if !opts.noConfig {
+		configStatus, err = writeTemplateConfig(client, t, project, owner, host, repos, dir, opts.force)
+		if err != nil {
+			return err
+		}
+	}
+
+	u.SummaryBox("Project created", map[string]string{
+		"Project": fmt.Sprintf("%s (#%d)", project.Title, project.Number),
+		"URL":     project.URL,
+		"Fields":  fmt.Sprintf("%d configured", created),
+		"Config":  configStatus,
+	}, []string{"Project", "URL", "Fields", "Config"})
+
+	return nil
+}
+
+// createTemplateField creates a template field, or updates the options of a
+// built-in field of the same name (such as Status) when one already exists.
+func createTemplateField(client projectCreateClient, projectID string, f tmpl.Field, existing []api.ProjectField, now time.Time) error {
+	options := templateFieldOptions(f)
+
+	for _, ef := range existing {
+		if !strings.EqualFold(ef.Name, f.Name) {
+			continue
+		}
+		if ef.DataType == "SINGLE_SELECT" && f.Type == tmpl.FieldTypeSingleSelect {
+			if _, err := client.UpdateSingleSelectOptions(ef.ID, options); err != nil {
+				return fmt.Errorf("could not update options of %q: %v", f.Name, err)
+			}
+			return nil
+		}
+		return fmt.Errorf("skipping %q: project already has a %s field with that name", f.Name, ef.DataType)
+	}
+
+	switch f.Type {
+	case tmpl.FieldTypeSingleSelect, tmpl.FieldTypeText, tmpl.FieldTypeNumber, tmpl.FieldTypeDate:
+		if _, err := client.CreateProjectField(projectID, f.Name, f.DataType(), options); err != nil {
+			return fmt.Errorf("could not create %q: %v", f.Name, err)
+		}
+	case tmpl.FieldTypeIteration:
+		duration := f.Duration
+		if duration <= 0 {
+			duration = defaultIterationDuration
+		}
+		start := iterationStartDate(now, f.StartDay)
+		if _, err := client.CreateIterationField(projectID, f.Name, start, duration, defaultIterationCount); err != nil {
+			return fmt.Errorf("could not create %q: %v", f.Name, err)
+		}
+	default:
+		return fmt.Errorf("skipping %q: unsupported field type %q", f.Name, f.Type)
+	}
+
+	return nil
+}
+
+// templateFieldOptions converts template options to API field options
+func templateFieldOptions(f tmpl.Field) []api.FieldOption {
+	var options []api.FieldOption
+	for _, opt := range f.Options {
+		options = append(options, api.FieldOption{
+			Name:        opt.Name,
+			Color:       strings.ToUpper(opt.Color),
+			Description: opt.Description,
+		})
+	}
+	return options
+}
+
+// iterationStartDate returns the first date on or after now that falls on
+// startDay (e.g. "monday"). An empty or unknown day starts today.
+func iterationStartDate(now time.Time, startDay string) string {
+	for i := 0; i < 7; i++ {
+		d := now.AddDate(0, 0, i)
+		if strings.EqualFold(d.Weekday().String(), startDay) {
+			return d.Format("2006-01-02")
+		}
+	}
+	return now.Format("2006-01-02")
+}
+
+// shortDescription returns the first line of a project description
+func shortDescription(desc string) string {
+	desc = strings.TrimSpace(desc)
+	if idx := strings.Index(desc, "\n"); idx >= 0 {
+		desc = strings.TrimSpace(desc[:idx])
+	}
+	return desc
+}
+
+// templateRepositories returns the rendered owner/repo entries of a template,
+// skipping entries left empty by unset variables.
+func templateRepositories(t *tmpl.Template) []string {
+	var repos []string
+	for _, r := range t.Repositories {
+		r = strings.TrimSpace(r)
+		if o, n := splitRepository(r); o == "" || n == "" {
+			continue
+		}
+		repos = append(repos, r)
+	}
+	return repos
+}
+
+// describeProjectTemplate prints what project create would do
+func describeProjectTemplate(cmd *cobra.Command, t *tmpl.Template, title, owner string, repos []string) {
+	cmd.Println("Dry run - no changes will be made")
+	cmd.Println()
+	cmd.Printf("Would create project %q for %s with:\n", title, owner)
+
+	cmd.Printf("  Fields (%d):\n", len(t.Fields))
+	for _, f := range t.Fields {
+		if len(f.Options) > 0 {
+			cmd.Printf("    • %s (%s, %d options)\n", f.Name, f.Type, len(f.Options))
+		} else {
+			cmd.Printf("    • %s (%s)\n", f.Name, f.Type)
+		}
+	}
+
+	if len(repos) > 0 {
+		cmd.Printf("  Linked repositories: %s\n", strings.Join(repos, ", "))
+	}
+
+	if len(t.Aliases) > 0 {
+		keys := make([]string, 0, len(t.Aliases))
+		for k := range t.Aliases {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		cmd.Printf("  Field aliases: %s\n", strings.Join(keys, ", "))
+	}
+
+	if len(t.Views) > 0 || len(t.Workflows) > 0 {
+		cmd.Printf("  Not created (no API support): %d view(s), %d workflow(s)\n", len(t.Views), len(t.Workflows))
+	}
+}
+
+// writeTemplateConfig writes a .gh-pmu.yml for a project created from a template.
+// Returns a short description of what was written for the summary.
+func writeTemplateConfig(client projectCreateClient, t *tmpl.Template, project *api.Project, owner, host string, repos []string, dir string, force bool) (string, error) {
+	path := filepath.Join(dir, config.ConfigFileName)
+	if _, err := os.Stat(path); err == nil && !force {
+		return "skipped (existing .gh-pmu.yml, use --force to overwrite)", nil
+	}
+
+	fields, err := client.GetProjectFields(project.ID)
+	if err != nil {
+		fields = nil
+	}
+
+	cfg := buildTemplateConfig(t, project, owner, host, repos, fields)
+	if err := cfg.Save(path); err != nil {
+		return "", err
+	}
+
+	return config.ConfigFileName, nil
+}
+
+// buildTemplateConfig builds the configuration for a project created from a template
+// (host is empty for github.com)
+func buildTemplateConfig(t *tmpl.Template, project *api.Project, owner, host string, repos []string, fields []api.ProjectField) *config.Config {
+	cfg := &config.Config{
+		Host: host,
+		Project: config.Project{
+			Name:   project.Title,
+			Number: project.Number,
+			Owner:  owner,
+		},
+		Repositories: repos,
+		Fields:       make(map[string]config.Field),
+		Metadata:     metadataFromFields(project.ID, fields),
+	}
+
+	for key, alias := range t.Aliases {
+		fieldName := t.AliasFieldName(key)
+		if fieldName == "" {
+			fieldName = key
+		}
+		cfg.Fields[key] = config.Field{
+			Field:  fieldName,
+			Values: alias.Values,
+		}
+	}
+
+	// Default new issues to the first status option, using its alias
+	if status := t.FindField("Status"); status != nil && len(status.Options) > 0 {
+		if alias := aliasForValue(t.Aliases["status"].Values, status.Options[0].Name); alias != "" {
+			cfg.Defaults.Status = alias
+		}
+	}
+
+	return cfg
+}
+
+// aliasForValue returns the first alias (in sorted order) that maps to value
+func aliasForValue(aliases map[string]string, value string) string {
+	keys := make([]string, 0, len(aliases))
+	for k := range aliases {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if aliases[k] == value {
+			return k
+		}
+	}
+	return ""
+}
+
+// metadataFromFields converts project fields into cached config metadata
+func metadataFromFields(projectID string, fields []api.ProjectField) *config.Metadata {
+	metadata := &config.Metadata{
+		Project: config.ProjectMetadata{ID: projectID},
+	}
+
+	for _, f := range fields {
+		fm := config.FieldMetadata{
+			Name:     f.Name,
+			ID:       f.ID,
+			DataType: f.DataType,
+		}
+		for _, opt := range f.Options {
+			fm.Options = append(fm.Options, config.OptionMetadata{
+				Name: opt.Name,
+				ID:   opt.ID,
+			})
+		}
+		metadata.Fields = append(metadata.Fields, fm)
+	}
+
+	return metadata
+}
+
+type projectExportOptions struct {
+	owner  string
+	output string
+	name   string
+}
+
+// projectExportClient defines the interface for API methods used by project export-template.
+// This allows for easier testing with mock implementations.
+type projectExportClient interface {
+	GetProject(owner string, number int) (*api.Project, error)
+	GetProjectFields(projectID string) ([]api.ProjectField, error)
+	GetProjectDetails(projectID string) (*api.ProjectDetails, error)
+}
+
+// builtinFieldTypes are field data types GitHub creates for every project.
+// They are not exported to templates.
+var builtinFieldTypes = map[string]bool{
+	"ASSIGNEES":            true,
+	"LABELS":               true,
+	"LINKED_PULL_REQUESTS": true,
+	"MILESTONE":            true,
+	"PARENT_ISSUE":         true,
+	"REPOSITORY":           true,
+	"REVIEWERS":            true,
+	"SUB_ISSUES_PROGRESS":  true,
+	"TITLE":                true,
+	"TRACKED_BY":           true,
+	"TRACKS":               true,
+}
+
+func newProjectExportTemplateCommand() *cobra.Command {
+	opts := &projectExportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export-template [project-number]",
+		Short: "Export a project as a reusable template",
+		Long: `Export an existing project's fields, options, iterations, and readme
+as a YAML template in the same format as the built-in templates.
+
+Without a project number, the project from .gh-pmu.yml is exported
+and its field aliases are included in the template.
+
+The exported template can be used with 'gh pmu project create --from-template'.`,
+		Example: `  # Export the configured project to a file
+  gh pmu project export-template -o team-board.yml
+
+  # Export another project
+  gh pmu project export-template 7 --owner my-org -o board.yml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectExportTemplate(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.owner, "owner", "", "Project owner (defaults to the configured project owner)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write the template to a file instead of stdout")
+	cmd.Flags().StringVar(&opts.name, "name", "", "Template name (defaults to the project title)")
+
+	return cmd
+}
+
+func runProjectExportTemplate(cmd *cobra.Command, args []string, opts *projectExportOptions) error {
+	// The config is optional: it supplies the default project and aliases
+	var cfg *config.Config
+	host := ""
+	if cwd, err := os.Getwd(); err == nil {
+		cfg, _ = loadConfig(cmd, cwd)
+		host = resolveHost(cmd, cwd, "")
+	}
+
+	owner := opts.owner
+	number := 0
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid project number: %s", args[0])
+		}
+		number = n
+	}
+
+	if cfg != nil {
+		if owner == "" {
+			owner = cfg.Project.Owner
+		}
+		if number == 0 {
+			number = cfg.Project.Number
+		}
+		// Only include aliases when exporting the configured project
+		if owner != cfg.Project.Owner || number != cfg.Project.Number {
+			cfg = nil
+		}
+	}
+
+	if owner == "" || number == 0 {
+		return fmt.Errorf("project number and owner are required (pass a project number and --owner, or run 'gh pmu init')")
+	}
+
+	client := api.NewClientForHost(host)
+
+	return runProjectExportTemplateWithDeps(cmd, opts, owner, number, cfg, client)
+}
+
+// runProjectExportTemplateWithDeps is the testable implementation of runProjectExportTemplate
+func runProjectExportTemplateWithDeps(cmd *cobra.Command, opts *projectExportOptions, owner string, number int, cfg *config.Config, client projectExportClient) error {
+	project, err := client.GetProject(owner, number)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	fields, err := client.GetProjectFields(project.ID)
+	if err != nil {
+		return err
+	}
+
+	details, err := client.GetProjectDetails(project.ID)
+	if err != nil {
+		return err
+	}
+
+	t := buildProjectTemplate(project, details, fields, cfg)
+	if opts.name != "" {
+		t.Template.Name = opts.name
+	}
+
+	data, err := tmpl.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	header := fmt.Sprintf("# %s Project Template\n# Exported from %s\n#\n# Usage:\n#   gh pmu project create --from-template <file> --title \"My Project\"\n\n", t.Template.Name, project.URL)
+	data = append([]byte(header), data...)
+
+	if opts.output == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(opts.output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+
+	u := ui.New(cmd.OutOrStdout())
+	u.Success(fmt.Sprintf("Exported %s (#%d) to %s", project.Title, project.Number, opts.output))
+
+	return nil
+}
+
+// buildProjectTemplate converts an existing project into a template.
+// Aliases are taken from cfg when it is non-nil.
+func buildProjectTemplate(project *api.Project, details *api.ProjectDetails, fields []api.ProjectField, cfg *config.Config) *tmpl.Template {
+	visibility := "private"
+	if details.Public {
+		visibility = "public"
+	}
+
+	t := &tmpl.Template{
+		SchemaVersion: "1.0",
+		Template: tmpl.Info{
+			Name:        project.Title,
+			Description: details.ShortDescription,
+			Version:     "1.0.0",
+			Author:      project.Owner.Login,
+		},
+		Project: tmpl.Project{
+			Title:       fmt.Sprintf("{{.ProjectName | default %q}}", project.Title),
+			Description: details.ShortDescription,
+			Visibility:  visibility,
+			Readme:      details.Readme,
+		},
+		Repositories: []string{"{{.Repository}}"},
+	}
+
+	for _, f := range fields {
+		if builtinFieldTypes[f.DataType] {
+			continue
+		}
+
+		field := tmpl.Field{
+			Name: f.Name,
+			Type: strings.ToLower(f.DataType),
+		}
+		for _, opt := range f.Options {
+			field.Options = append(field.Options, tmpl.Option{
+				Name:        opt.Name,
+				Color:       opt.Color,
+				Description: opt.Description,
+			})
+		}
+		if f.Iteration != nil {
+			field.Duration = f.Iteration.Duration
+			field.StartDay = iterationStartDay(f.Iteration)
+		}
+
+		t.Fields = append(t.Fields, field)
+	}
+
+	if cfg != nil && len(cfg.Fields) > 0 {
+		t.Aliases = make(map[string]tmpl.Alias)
+		for key, f := range cfg.Fields {
+			alias := tmpl.Alias{Values: f.Values}
+			// Name the field explicitly when the alias key would not resolve to it
+			if !strings.EqualFold(t.AliasFieldName(key), f.Field) {
+				alias.Field = f.Field
+			}
+			t.Aliases[key] = alias
+		}
+	}
+
+	return t
+}
+
+// iterationStartDay returns the lowercase weekday iterations start on,
+// taken from the first iteration's start date.
+func iterationStartDay(cfg *api.IterationConfig) string {
+	if len(cfg.Iterations) == 0 {
+		return ""
+	}
+	start, err := time.Parse("2006-01-02", cfg.Iterations[0].StartDate)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(start.Weekday().String())
+}
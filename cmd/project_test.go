@@ -0,0 +1,440 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	tmpl "github.com/scooter-indie/gh-pmu/internal/template"
+	"github.com/spf13/cobra"
+)
+
+// mockProjectCreateClient implements projectCreateClient for testing
+type mockProjectCreateClient struct {
+	fields        []api.ProjectField
+	createdFields []string // "Name:DATA_TYPE"
+	updatedFields []string // field IDs whose options were replaced
+	linkedRepos   []string
+	iterStart     string
+
+	createProjectErr error
+	linkErr          error
+}
+
+func (m *mockProjectCreateClient) GetOwnerID(login string) (string, error) {
+	return "owner-" + login, nil
+}
+
+func (m *mockProjectCreateClient) CreateProject(ownerID, title string) (*api.Project, error) {
+	if m.createProjectErr != nil {
+		return nil, m.createProjectErr
+	}
+	return &api.Project{ID: "proj-1", Number: 12, Title: title, URL: "https://github.com/users/acme/projects/12"}, nil
+}
+
+func (m *mockProjectCreateClient) UpdateProjectDetails(projectID, shortDescription, readme string, public bool) error {
+	return nil
+}
+
+func (m *mockProjectCreateClient) GetProjectFields(projectID string) ([]api.ProjectField, error) {
+	return m.fields, nil
+}
+
+func (m *mockProjectCreateClient) CreateProjectField(projectID, name, dataType string, options []api.FieldOption) (*api.ProjectField, error) {
+	m.createdFields = append(m.createdFields, name+":"+dataType)
+	f := api.ProjectField{ID: "field-" + name, Name: name, DataType: dataType, Options: options}
+	m.fields = append(m.fields, f)
+	return &f, nil
+}
+
+func (m *mockProjectCreateClient) CreateIterationField(projectID, name, startDate string, duration, count int) (*api.ProjectField, error) {
+	m.createdFields = append(m.createdFields, name+":ITERATION")
+	m.iterStart = startDate
+	return &api.ProjectField{ID: "field-" + name, Name: name, DataType: "ITERATION"}, nil
+}
+
+func (m *mockProjectCreateClient) UpdateSingleSelectOptions(fieldID string, options []api.FieldOption) (*api.ProjectField, error) {
+	m.updatedFields = append(m.updatedFields, fieldID)
+	for i := range m.fields {
+		if m.fields[i].ID == fieldID {
+			m.fields[i].Options = options
+			return &m.fields[i], nil
+		}
+	}
+	return nil, fmt.Errorf("field not found: %s", fieldID)
+}
+
+func (m *mockProjectCreateClient) LinkProjectToRepository(projectID, owner, repo string) error {
+	if m.linkErr != nil {
+		return m.linkErr
+	}
+	m.linkedRepos = append(m.linkedRepos, owner+"/"+repo)
+	return nil
+}
+
+func newMockProjectCreateClient() *mockProjectCreateClient {
+	return &mockProjectCreateClient{
+		fields: []api.ProjectField{
+			{ID: "field-title", Name: "Title", DataType: "TITLE"},
+			{ID: "field-status", Name: "Status", DataType: "SINGLE_SELECT", Options: []api.FieldOption{
+				{ID: "opt-todo", Name: "Todo"},
+			}},
+		},
+	}
+}
+
+func parseTestTemplate(t *testing.T, name string, vars map[string]string) *tmpl.Template {
+	t.Helper()
+	data, err := tmpl.ReadSource(name)
+	if err != nil {
+		t.Fatalf("ReadSource error: %v", err)
+	}
+	parsed, err := tmpl.Parse(data, vars)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	return parsed
+}
+
+func TestProjectCommand_HasCreateSubcommand(t *testing.T) {
+	cmd := NewRootCommand()
+	projectCmd, _, err := cmd.Find([]string{"project", "create"})
+	if err != nil {
+		t.Fatalf("project create command not found: %v", err)
+	}
+	if projectCmd.Flags().Lookup("from-template") == nil {
+		t.Error("Expected --from-template flag")
+	}
+	if projectCmd.Flags().Lookup("var") == nil {
+		t.Error("Expected --var flag")
+	}
+}
+
+func TestBuildTemplateVars(t *testing.T) {
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	opts := &projectCreateOptions{title: "Board", vars: []string{"Repository=other/repo", "Team=core"}}
+
+	vars, err := buildTemplateVars(opts, "acme/api", now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if vars["Owner"] != "acme" {
+		t.Errorf("Expected owner from detected repo 'acme', got %q", vars["Owner"])
+	}
+	if vars["Repository"] != "other/repo" {
+		t.Errorf("Expected --var to override Repository, got %q", vars["Repository"])
+	}
+	if vars["Team"] != "core" {
+		t.Errorf("Expected custom variable Team, got %q", vars["Team"])
+	}
+	if vars["Date"] != "2025-01-15" {
+		t.Errorf("Expected Date 2025-01-15, got %q", vars["Date"])
+	}
+}
+
+func TestBuildTemplateVars_InvalidVar(t *testing.T) {
+	opts := &projectCreateOptions{vars: []string{"NoEquals"}}
+
+	if _, err := buildTemplateVars(opts, "", time.Now()); err == nil {
+		t.Error("Expected error for --var without '='")
+	}
+}
+
+func TestIterationStartDate(t *testing.T) {
+	wednesday := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		day  string
+		want string
+	}{
+		{"monday", "2025-01-20"},
+		{"Wednesday", "2025-01-15"},
+		{"", "2025-01-15"},
+	}
+
+	for _, tt := range tests {
+		if got := iterationStartDate(wednesday, tt.day); got != tt.want {
+			t.Errorf("iterationStartDate(%q) = %q, want %q", tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestRunProjectCreateWithDeps_DryRun(t *testing.T) {
+	mock := newMockProjectCreateClient()
+	tpl := parseTestTemplate(t, "kanban", map[string]string{"Repository": "acme/api"})
+
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	opts := &projectCreateOptions{dryRun: true}
+	if err := runProjectCreateWithDeps(cmd, opts, tpl, "acme", "", t.TempDir(), mock); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Dry run") || !strings.Contains(output, "Kanban Board") {
+		t.Errorf("Expected dry run summary, got:\n%s", output)
+	}
+	if len(mock.createdFields) != 0 || len(mock.linkedRepos) != 0 {
+		t.Error("Expected no API changes in dry run")
+	}
+}
+
+func TestRunProjectCreateWithDeps_WritesHost(t *testing.T) {
+	mock := newMockProjectCreateClient()
+	tpl := parseTestTemplate(t, "kanban", map[string]string{"Repository": "acme/api"})
+	dir := t.TempDir()
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+
+	if err := runProjectCreateWithDeps(cmd, &projectCreateOptions{title: "Board"}, tpl, "acme", "github.example.com", dir, mock); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cfg, err := config.Load(filepath.Join(dir, config.ConfigFileName))
+	if err != nil {
+		t.Fatalf("Expected config to be written: %v", err)
+	}
+	if cfg.Host != "github.example.com" {
+		t.Errorf("Expected host github.example.com, got %q", cfg.Host)
+	}
+}
+
+func TestResolveHost(t *testing.T) {
+	dir := t.TempDir()
+	cmd := &cobra.Command{}
+
+	t.Setenv(config.EnvHost, "")
+	if got := resolveHost(cmd, dir, "github.com"); got != "" {
+		t.Errorf("Expected no host for github.com, got %q", got)
+	}
+	if got := resolveHost(cmd, dir, "git.corp.example"); got != "git.corp.example" {
+		t.Errorf("Expected the remote's host, got %q", got)
+	}
+
+	t.Setenv(config.EnvHost, "github.example.com")
+	if got := resolveHost(cmd, dir, "git.corp.example"); got != "github.example.com" {
+		t.Errorf("Expected GH_HOST to win, got %q", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, config.ConfigFileName), []byte("host: ghe.acme.dev\nproject:\n  owner: acme\n  number: 1\nrepositories: [acme/api]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvHost, "")
+	if got := resolveHost(cmd, dir, "git.corp.example"); got != "ghe.acme.dev" {
+		t.Errorf("Expected the configured host, got %q", got)
+	}
+}
+
+func TestRunProjectCreateWithDeps_CreatesFieldsAndConfig(t *testing.T) {
+	mock := newMockProjectCreateClient()
+	tpl := parseTestTemplate(t, "scrum", map[string]string{"Repository": "acme/api"})
+	dir := t.TempDir()
+
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	opts := &projectCreateOptions{title: "Sprint Board"}
+	if err := runProjectCreateWithDeps(cmd, opts, tpl, "acme", "", dir, mock); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Status already exists in a new project, so its options are replaced
+	if len(mock.updatedFields) != 1 || mock.updatedFields[0] != "field-status" {
+		t.Errorf("Expected Status options to be updated, got %v", mock.updatedFields)
+	}
+	for _, f := range mock.createdFields {
+		if strings.HasPrefix(f, "Status:") {
+			t.Error("Expected Status not to be created again")
+		}
+	}
+	if !containsString(mock.createdFields, "Sprint:ITERATION") {
+		t.Errorf("Expected iteration field to be created, got %v", mock.createdFields)
+	}
+	if !containsString(mock.createdFields, "Story Points:NUMBER") {
+		t.Errorf("Expected number field to be created, got %v", mock.createdFields)
+	}
+	if len(mock.linkedRepos) != 1 || mock.linkedRepos[0] != "acme/api" {
+		t.Errorf("Expected acme/api to be linked, got %v", mock.linkedRepos)
+	}
+
+	cfg, err := config.Load(filepath.Join(dir, config.ConfigFileName))
+	if err != nil {
+		t.Fatalf("Expected config to be written: %v", err)
+	}
+	if cfg.Project.Number != 12 || cfg.Project.Owner != "acme" {
+		t.Errorf("Unexpected project config: %+v", cfg.Project)
+	}
+	if cfg.Fields["status"].Field != "Status" {
+		t.Errorf("Expected status alias group for Status field, got %+v", cfg.Fields["status"])
+	}
+	if cfg.Metadata == nil || cfg.Metadata.Project.ID != "proj-1" {
+		t.Error("Expected metadata with project ID")
+	}
+}
+
+func TestRunProjectCreateWithDeps_KeepsExistingConfig(t *testing.T) {
+	mock := newMockProjectCreateClient()
+	tpl := parseTestTemplate(t, "kanban", nil)
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, config.ConfigFileName)
+	if err := os.WriteFile(path, []byte("existing: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+
+	if err := runProjectCreateWithDeps(cmd, &projectCreateOptions{}, tpl, "acme", "", dir, mock); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "existing: true\n" {
+		t.Error("Expected existing config to be kept without --force")
+	}
+}
+
+func TestRunProjectCreateWithDeps_CreateProjectError(t *testing.T) {
+	mock := newMockProjectCreateClient()
+	mock.createProjectErr = fmt.Errorf("insufficient scopes")
+	tpl := parseTestTemplate(t, "kanban", nil)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+
+	err := runProjectCreateWithDeps(cmd, &projectCreateOptions{}, tpl, "acme", "", t.TempDir(), mock)
+	if err == nil || !strings.Contains(err.Error(), "insufficient scopes") {
+		t.Errorf("Expected create error, got %v", err)
+	}
+}
+
+// mockProjectExportClient implements projectExportClient for testing
+type mockProjectExportClient struct {
+	project *api.Project
+	fields  []api.ProjectField
+	details *api.ProjectDetails
+}
+
+func (m *mockProjectExportClient) GetProject(owner string, number int) (*api.Project, error) {
+	if m.project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+	return m.project, nil
+}
+
+func (m *mockProjectExportClient) GetProjectFields(projectID string) ([]api.ProjectField, error) {
+	return m.fields, nil
+}
+
+func (m *mockProjectExportClient) GetProjectDetails(projectID string) (*api.ProjectDetails, error) {
+	return m.details, nil
+}
+
+func newMockProjectExportClient() *mockProjectExportClient {
+	return &mockProjectExportClient{
+		project: &api.Project{ID: "proj-1", Number: 3, Title: "Team Board", URL: "https://github.com/orgs/acme/projects/3",
+			Owner: api.ProjectOwner{Type: "Organization", Login: "acme"}},
+		fields: []api.ProjectField{
+			{ID: "f1", Name: "Title", DataType: "TITLE"},
+			{ID: "f2", Name: "Status", DataType: "SINGLE_SELECT", Options: []api.FieldOption{
+				{ID: "o1", Name: "Backlog", Color: "GRAY", Description: "Not started"},
+				{ID: "o2", Name: "Done", Color: "GREEN"},
+			}},
+			{ID: "f3", Name: "Estimate", DataType: "NUMBER"},
+			{ID: "f4", Name: "Sprint", DataType: "ITERATION", Iteration: &api.IterationConfig{
+				Duration:   14,
+				Iterations: []api.Iteration{{Title: "Sprint 1", StartDate: "2025-01-06", Duration: 14}},
+			}},
+		},
+		details: &api.ProjectDetails{ShortDescription: "Our board", Readme: "# Team Board\n"},
+	}
+}
+
+func TestProjectCommand_HasExportTemplateSubcommand(t *testing.T) {
+	cmd := NewRootCommand()
+	exportCmd, _, err := cmd.Find([]string{"project", "export-template"})
+	if err != nil {
+		t.Fatalf("project export-template command not found: %v", err)
+	}
+	if exportCmd.Flags().Lookup("output") == nil {
+		t.Error("Expected --output flag")
+	}
+}
+
+func TestRunProjectExportTemplateWithDeps_RoundTrip(t *testing.T) {
+	mock := newMockProjectExportClient()
+	cfg := &config.Config{
+		Fields: map[string]config.Field{
+			"status": {Field: "Status", Values: map[string]string{"backlog": "Backlog"}},
+			"size":   {Field: "Estimate", Values: map[string]string{"s": "1"}},
+		},
+	}
+
+	cmd := &cobra.Command{}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	if err := runProjectExportTemplateWithDeps(cmd, &projectExportOptions{}, "acme", 3, cfg, mock); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The exported template must parse with the same schema used by project create
+	exported, err := tmpl.Parse(buf.Bytes(), map[string]string{"Repository": "acme/api"})
+	if err != nil {
+		t.Fatalf("Exported template does not parse: %v\n%s", err, buf.String())
+	}
+
+	if exported.Project.Title != "Team Board" {
+		t.Errorf("Expected default title 'Team Board', got %q", exported.Project.Title)
+	}
+	if exported.FindField("Title") != nil {
+		t.Error("Expected built-in Title field to be skipped")
+	}
+	status := exported.FindField("Status")
+	if status == nil || len(status.Options) != 2 || status.Options[0].Color != "GRAY" || status.Options[0].Description != "Not started" {
+		t.Errorf("Unexpected Status field: %+v", status)
+	}
+	sprint := exported.FindField("Sprint")
+	if sprint == nil || sprint.Type != tmpl.FieldTypeIteration || sprint.Duration != 14 || sprint.StartDay != "monday" {
+		t.Errorf("Unexpected Sprint field: %+v", sprint)
+	}
+	if got := exported.AliasFieldName("size"); got != "Estimate" {
+		t.Errorf("Expected size alias to name Estimate, got %q", got)
+	}
+	if len(exported.Repositories) != 1 || exported.Repositories[0] != "acme/api" {
+		t.Errorf("Expected repository placeholder, got %v", exported.Repositories)
+	}
+}
+
+func TestRunProjectExportTemplateWithDeps_WritesFile(t *testing.T) {
+	mock := newMockProjectExportClient()
+	path := filepath.Join(t.TempDir(), "board.yml")
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+
+	opts := &projectExportOptions{output: path, name: "Standard Board"}
+	if err := runProjectExportTemplateWithDeps(cmd, opts, "acme", 3, nil, mock); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected template file: %v", err)
+	}
+	if !strings.Contains(string(data), "name: Standard Board") {
+		t.Errorf("Expected template name override, got:\n%s", data)
+	}
+}
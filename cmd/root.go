@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/scooter-indie/gh-pmu/internal/config"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var version = "dev"
@@ -18,21 +23,139 @@ This extension combines and replaces:
 
 Use 'gh pmu <command> --help' for more information about a command.`,
 		Version: version,
+
+		PersistentPreRunE: prepareCommandContext,
 	}
 
+	cmd.PersistentFlags().StringArray("project", nil, "Use the project with this `name` from the projects: section of the config (create accepts it multiple times)")
+	cmd.PersistentFlags().String("owner", "", "Override the project owner")
+	cmd.PersistentFlags().Int("project-number", 0, "Override the project number")
+	cmd.PersistentFlags().StringArray("repo", nil, "Override the configured repositories with `owner/repo` (can be specified multiple times)")
+	cmd.PersistentFlags().String("config", "", "Use this configuration `file` instead of searching for .gh-pmu.yml")
+
 	cmd.AddCommand(newInitCommand())
 	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newViewSaveCommand())
 	cmd.AddCommand(newViewCommand())
 	cmd.AddCommand(newCreateCommand())
+	cmd.AddCommand(newEditCommand())
+	cmd.AddCommand(newCloseCommand())
+	cmd.AddCommand(newReopenCommand())
 	cmd.AddCommand(newMoveCommand())
 	cmd.AddCommand(newSubCommand())
 	cmd.AddCommand(newIntakeCommand())
 	cmd.AddCommand(newTriageCommand())
 	cmd.AddCommand(newSplitCommand())
+	cmd.AddCommand(newProjectCommand())
+	cmd.AddCommand(newTemplateCommand())
+	cmd.AddCommand(newFieldCommand())
+	cmd.AddCommand(newTypesCommand())
+	cmd.AddCommand(newConfigCommand())
 
 	return cmd
 }
 
 func Execute() error {
-	return NewRootCommand().Execute()
+	root := NewRootCommand()
+	root.SetArgs(normalizeJSONArgs(root, os.Args[1:]))
+	return root.Execute()
+}
+
+// globalFlag returns the root persistent flag with the given name, or nil
+// when cmd is not attached to the root command or defines its own flag of
+// that name (such as create --repo)
+func globalFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	flag := cmd.Flag(name)
+	if flag == nil || flag != cmd.Root().PersistentFlags().Lookup(name) {
+		return nil
+	}
+	return flag
+}
+
+// flagOverrides returns the configuration overrides given with the global
+// --owner, --project-number, --repo, and --config flags
+func flagOverrides(cmd *cobra.Command) config.Overrides {
+	var o config.Overrides
+	sources := make(map[string]string)
+
+	if flag := globalFlag(cmd, "config"); flag != nil && flag.Changed {
+		o.ConfigPath = flag.Value.String()
+		sources["config"] = "flag --config"
+	}
+	if flag := globalFlag(cmd, "owner"); flag != nil && flag.Changed {
+		o.Owner = flag.Value.String()
+		sources["project.owner"] = "flag --owner"
+	}
+	if flag := globalFlag(cmd, "project-number"); flag != nil && flag.Changed {
+		o.ProjectNumber, _ = cmd.Flags().GetInt("project-number")
+		sources["project.number"] = "flag --project-number"
+	}
+	if flag := globalFlag(cmd, "repo"); flag != nil && flag.Changed {
+		o.Repositories, _ = cmd.Flags().GetStringArray("repo")
+		sources["repositories"] = "flag --repo"
+	}
+
+	o.Sources = sources
+	return o
+}
+
+// loadConfig loads the configuration for dir with GH_PMU_* environment
+// variables and global flags applied on top. Flags take precedence over the
+// environment, which takes precedence over configuration files.
+func loadConfig(cmd *cobra.Command, dir string) (*config.Config, error) {
+	env, err := config.EnvOverrides()
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadWithOverrides(dir, env.Merge(flagOverrides(cmd)))
+}
+
+// projectNames returns the project profiles named with the global --project
+// flag, or in GH_PMU_PROJECT when the flag is not given
+func projectNames(cmd *cobra.Command) []string {
+	if flag := globalFlag(cmd, "project"); flag != nil && flag.Changed {
+		names, _ := cmd.Flags().GetStringArray("project")
+		return names
+	}
+	return config.SplitList(os.Getenv(config.EnvProject))
+}
+
+// selectProject returns the configuration for the project named with the
+// global --project flag, or the default project when none is named
+func selectProject(cmd *cobra.Command, cfg *config.Config) (*config.Config, error) {
+	names := projectNames(cmd)
+	if len(names) > 1 {
+		return nil, fmt.Errorf("--project can only be given once for %s", cmd.CommandPath())
+	}
+
+	name := ""
+	if len(names) == 1 {
+		name = names[0]
+	}
+	return cfg.ForProject(name)
+}
+
+// selectProjects returns the configuration for each project named with the
+// global --project flag, or for the default project when none is named
+func selectProjects(cmd *cobra.Command, cfg *config.Config) ([]*config.Config, error) {
+	names := projectNames(cmd)
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	var selected []*config.Config
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		projectCfg, err := cfg.ForProject(name)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, projectCfg)
+	}
+	return selected, nil
 }
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/config"
 )
 
 func TestRootCommandHelp(t *testing.T) {
@@ -46,3 +49,101 @@ func TestRootCommandVersion(t *testing.T) {
 		t.Errorf("Expected version output to contain 'gh-pm', got: %s", output)
 	}
 }
+
+func newProfilesConfig() *config.Config {
+	return &config.Config{
+		Project:      config.Project{Owner: "acme", Number: 1},
+		Repositories: []string{"acme/api"},
+		Defaults:     config.Defaults{Status: "todo"},
+		Fields: map[string]config.Field{
+			"status": {Field: "Status", Values: map[string]string{"todo": "Todo"}},
+		},
+		Projects: map[string]config.Profile{
+			"roadmap": {
+				Project:  config.Project{Owner: "acme-org", Number: 7},
+				Defaults: &config.Defaults{Status: "later"},
+				Fields: map[string]config.Field{
+					"status": {Field: "Status", Values: map[string]string{"later": "Later"}},
+				},
+			},
+		},
+	}
+}
+
+func TestSelectProjects_FromGlobalFlag(t *testing.T) {
+	root := NewRootCommand()
+	if err := root.ParseFlags([]string{"--project", "roadmap", "--project", "roadmap"}); err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := selectProjects(root, newProfilesConfig())
+	if err != nil {
+		t.Fatalf("selectProjects error: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Project.Number != 7 {
+		t.Errorf("Expected the roadmap project once, got %d projects", len(projects))
+	}
+}
+
+func TestSelectProject_RejectsSeveralProjects(t *testing.T) {
+	root := NewRootCommand()
+	if err := root.ParseFlags([]string{"--project", "roadmap", "--project", "team"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := selectProject(root, newProfilesConfig())
+	if err == nil || !strings.Contains(err.Error(), "only be given once") {
+		t.Errorf("Expected error for several projects, got %v", err)
+	}
+}
+
+func TestSelectProject_NoFlagSelectsDefault(t *testing.T) {
+	cfg := newProfilesConfig()
+
+	selected, err := selectProject(newCreateCommand(), cfg)
+	if err != nil {
+		t.Fatalf("selectProject error: %v", err)
+	}
+	if selected != cfg {
+		t.Errorf("Expected the top-level project")
+	}
+}
+
+func TestLoadConfig_GlobalFlagsOverrideEnvironment(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GH_PMU_OWNER", "env-owner")
+	t.Setenv("GH_PMU_PROJECT_NUMBER", "3")
+	t.Setenv("GH_PMU_REPO", "acme/api")
+
+	root := NewRootCommand()
+	if err := root.ParseFlags([]string{"--owner", "flag-owner", "--repo", "acme/web"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(root, t.TempDir())
+	if err != nil {
+		t.Fatalf("loadConfig error: %v", err)
+	}
+
+	if cfg.Project.Owner != "flag-owner" || cfg.Project.Number != 3 {
+		t.Errorf("Expected flag-owner #3, got %s #%d", cfg.Project.Owner, cfg.Project.Number)
+	}
+	if len(cfg.Repositories) != 1 || cfg.Repositories[0] != "acme/web" {
+		t.Errorf("Expected --repo to replace GH_PMU_REPO, got %v", cfg.Repositories)
+	}
+}
+
+func TestLoadConfig_LocalFlagShadowsGlobal(t *testing.T) {
+	root := NewRootCommand()
+	create, _, err := root.Find([]string{"create"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := create.ParseFlags([]string{"--repo", "acme/api"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if o := flagOverrides(create); len(o.Repositories) != 0 {
+		t.Errorf("Expected create --repo not to override repositories, got %v", o.Repositories)
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
@@ -16,7 +15,7 @@ import (
 type splitOptions struct {
 	from   string
 	dryRun bool
-	json   bool
+	export *exportOptions
 }
 
 func newSplitCommand() *cobra.Command {
@@ -50,49 +49,49 @@ Completed items (- [x]) are skipped.`,
 			return runSplit(cmd, args, opts)
 		},
 	}
+	needsConfig(cmd)
 
 	cmd.Flags().StringVar(&opts.from, "from", "", "Source for tasks: 'body' (issue body) or file path")
 	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be created without making changes")
-	cmd.Flags().BoolVar(&opts.json, "json", false, "Output in JSON format")
+	opts.export = addExportFlags(cmd, splitJSONIssue{}, "created")
 
 	return cmd
 }
 
+// splitClient is the part of the API client used by split
+type splitClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	CreateIssue(owner, repo, title, body string, labels []string) (*api.Issue, error)
+	AddSubIssue(parentIssueID, childIssueID string) error
+}
+
 func runSplit(cmd *cobra.Command, args []string, opts *splitOptions) error {
 	// Parse issue number
-	issueNum, err := strconv.Atoi(args[0])
-	if err != nil {
+	if _, err := strconv.Atoi(args[0]); err != nil {
 		return fmt.Errorf("invalid issue number: %s", args[0])
 	}
 
-	// Load configuration
-	cwd, err := os.Getwd()
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
+	return runSplitWithDeps(cmd, args, opts, cc.cfg, cc.client)
+}
 
-	cfg, err := config.LoadFromDirectory(cwd)
+func runSplitWithDeps(cmd *cobra.Command, args []string, opts *splitOptions, cfg *config.Config, client splitClient) error {
+	// Parse issue number
+	issueNum, err := strconv.Atoi(args[0])
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return fmt.Errorf("invalid issue number: %s", args[0])
 	}
 
 	if len(cfg.Repositories) == 0 {
 		return fmt.Errorf("no repositories configured in .gh-pmu.yml")
 	}
-
-	// Parse repository
-	repoParts := strings.SplitN(cfg.Repositories[0], "/", 2)
-	if len(repoParts) != 2 {
-		return fmt.Errorf("invalid repository format: %s", cfg.Repositories[0])
+	owner, repo, err := defaultRepository(cfg)
+	if err != nil {
+		return err
 	}
-	owner, repo := repoParts[0], repoParts[1]
-
-	// Create API client
-	client := api.NewClient()
 
 	// Get the parent issue
 	parentIssue, err := client.GetIssue(owner, repo, issueNum)
@@ -123,8 +122,8 @@ func runSplit(cmd *cobra.Command, args []string, opts *splitOptions) error {
 	}
 
 	if len(tasks) == 0 {
-		if opts.json {
-			return outputSplitJSON(cmd, parentIssue, nil, "no-tasks")
+		if opts.export.enabled() {
+			return outputSplitJSON(cmd, parentIssue, nil, "no-tasks", opts.export)
 		}
 		cmd.Println("No tasks found to create as sub-issues")
 		return nil
@@ -132,8 +131,8 @@ func runSplit(cmd *cobra.Command, args []string, opts *splitOptions) error {
 
 	// Dry run - just show what would be created
 	if opts.dryRun {
-		if opts.json {
-			return outputSplitJSON(cmd, parentIssue, tasks, "dry-run")
+		if opts.export.enabled() {
+			return outputSplitJSON(cmd, parentIssue, tasks, "dry-run", opts.export)
 		}
 		cmd.Printf("Would create %d sub-issue(s) under #%d: %s\n\n", len(tasks), parentIssue.Number, parentIssue.Title)
 		for i, task := range tasks {
@@ -145,6 +144,7 @@ func runSplit(cmd *cobra.Command, args []string, opts *splitOptions) error {
 	// Create sub-issues
 	var created []api.Issue
 	var failed []string
+	link := true // false once the host turns out not to support sub-issues
 
 	for _, task := range tasks {
 		// Create the issue
@@ -156,10 +156,15 @@ func runSplit(cmd *cobra.Command, args []string, opts *splitOptions) error {
 		}
 
 		// Link as sub-issue
-		err = client.AddSubIssue(parentIssue.ID, newIssue.ID)
-		if err != nil {
-			cmd.PrintErrf("Created #%d but failed to link as sub-issue: %v\n", newIssue.Number, err)
-			// Still count as created since issue exists
+		if link {
+			err = client.AddSubIssue(parentIssue.ID, newIssue.ID)
+			if api.IsUnsupported(err) {
+				cmd.PrintErrf("Warning: %v; created issues are not linked to #%d\n", err, parentIssue.Number)
+				link = false
+			} else if err != nil {
+				cmd.PrintErrf("Created #%d but failed to link as sub-issue: %v\n", newIssue.Number, err)
+				// Still count as created since issue exists
+			}
 		}
 
 		created = append(created, *newIssue)
@@ -167,8 +172,8 @@ func runSplit(cmd *cobra.Command, args []string, opts *splitOptions) error {
 	}
 
 	// Summary
-	if opts.json {
-		return outputSplitJSONCreated(cmd, parentIssue, created, failed)
+	if opts.export.enabled() {
+		return outputSplitJSONCreated(cmd, parentIssue, created, failed, opts.export)
 	}
 
 	cmd.Printf("\nSplit complete: %d sub-issue(s) created under #%d", len(created), parentIssue.Number)
@@ -176,6 +181,9 @@ func runSplit(cmd *cobra.Command, args []string, opts *splitOptions) error {
 		cmd.Printf(" (%d failed)", len(failed))
 	}
 	cmd.Println()
+	if note := subIssueMarkerNote(client); note != "" && link {
+		cmd.Println(note)
+	}
 
 	return nil
 }
@@ -201,7 +209,7 @@ func parseChecklist(text string) []string {
 	return tasks
 }
 
-func outputSplitJSON(cmd *cobra.Command, parent *api.Issue, tasks []string, status string) error {
+func outputSplitJSON(cmd *cobra.Command, parent *api.Issue, tasks []string, status string, export *exportOptions) error {
 	output := map[string]interface{}{
 		"status": status,
 		"parent": map[string]interface{}{
@@ -213,18 +221,23 @@ func outputSplitJSON(cmd *cobra.Command, parent *api.Issue, tasks []string, stat
 		"tasks":     tasks,
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return export.write(cmd, output)
+}
+
+// splitJSONIssue is a created sub-issue in split's JSON output
+type splitJSONIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
 }
 
-func outputSplitJSONCreated(cmd *cobra.Command, parent *api.Issue, created []api.Issue, failed []string) error {
-	createdJSON := make([]map[string]interface{}, 0, len(created))
+func outputSplitJSONCreated(cmd *cobra.Command, parent *api.Issue, created []api.Issue, failed []string, export *exportOptions) error {
+	createdJSON := make([]splitJSONIssue, 0, len(created))
 	for _, issue := range created {
-		createdJSON = append(createdJSON, map[string]interface{}{
-			"number": issue.Number,
-			"title":  issue.Title,
-			"url":    issue.URL,
+		createdJSON = append(createdJSON, splitJSONIssue{
+			Number: issue.Number,
+			Title:  issue.Title,
+			URL:    issue.URL,
 		})
 	}
 
@@ -241,7 +254,5 @@ func outputSplitJSONCreated(cmd *cobra.Command, parent *api.Issue, created []api
 		"failed":       failed,
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return export.write(cmd, output)
 }
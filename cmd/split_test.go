@@ -65,7 +65,7 @@ func TestSplitOptions(t *testing.T) {
 		if opts.dryRun {
 			t.Error("dryRun should be false by default")
 		}
-		if opts.json {
+		if opts.export.enabled() {
 			t.Error("json should be false by default")
 		}
 	})
@@ -154,7 +154,7 @@ func TestOutputSplitJSON(t *testing.T) {
 		tasks := []string{"Task 1", "Task 2", "Task 3"}
 
 		// Note: outputSplitJSON writes to os.Stdout
-		err := outputSplitJSON(cmd, parent, tasks, "dry-run")
+		err := outputSplitJSON(cmd, parent, tasks, "dry-run", nil)
 		if err != nil {
 			t.Fatalf("outputSplitJSON failed: %v", err)
 		}
@@ -169,7 +169,7 @@ func TestOutputSplitJSON(t *testing.T) {
 			URL:    "https://github.com/owner/repo/issues/1",
 		}
 
-		err := outputSplitJSON(cmd, parent, nil, "no-tasks")
+		err := outputSplitJSON(cmd, parent, nil, "no-tasks", nil)
 		if err != nil {
 			t.Fatalf("outputSplitJSON failed with nil tasks: %v", err)
 		}
@@ -181,7 +181,7 @@ func TestOutputSplitJSON(t *testing.T) {
 
 		statuses := []string{"dry-run", "no-tasks", "completed"}
 		for _, status := range statuses {
-			err := outputSplitJSON(cmd, parent, []string{}, status)
+			err := outputSplitJSON(cmd, parent, []string{}, status, nil)
 			if err != nil {
 				t.Fatalf("outputSplitJSON failed with status %q: %v", status, err)
 			}
@@ -206,7 +206,7 @@ func TestOutputSplitJSONCreated(t *testing.T) {
 		}
 		failed := []string{"Failed task 1"}
 
-		err := outputSplitJSONCreated(cmd, parent, created, failed)
+		err := outputSplitJSONCreated(cmd, parent, created, failed, nil)
 		if err != nil {
 			t.Fatalf("outputSplitJSONCreated failed: %v", err)
 		}
@@ -216,7 +216,7 @@ func TestOutputSplitJSONCreated(t *testing.T) {
 		cmd := newSplitCommand()
 		parent := &api.Issue{Number: 1, Title: "Parent"}
 
-		err := outputSplitJSONCreated(cmd, parent, []api.Issue{}, []string{"all", "failed"})
+		err := outputSplitJSONCreated(cmd, parent, []api.Issue{}, []string{"all", "failed"}, nil)
 		if err != nil {
 			t.Fatalf("outputSplitJSONCreated failed with empty created: %v", err)
 		}
@@ -230,7 +230,7 @@ func TestOutputSplitJSONCreated(t *testing.T) {
 			{Number: 2, Title: "Sub", URL: "url"},
 		}
 
-		err := outputSplitJSONCreated(cmd, parent, created, []string{})
+		err := outputSplitJSONCreated(cmd, parent, created, []string{}, nil)
 		if err != nil {
 			t.Fatalf("outputSplitJSONCreated failed with empty failed: %v", err)
 		}
@@ -29,6 +29,20 @@ useful for breaking down epics into smaller tasks.`,
 	return cmd
 }
 
+// subClient is the part of the API client used by the sub commands
+type subClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	GetParentIssue(owner, repo string, number int) (*api.Issue, error)
+	GetSubIssues(owner, repo string, number int) ([]api.SubIssue, error)
+	CreateIssueWithOptions(owner, repo, title, body string, labels, assignees []string, milestone string) (*api.Issue, error)
+	AddSubIssue(parentIssueID, childIssueID string) error
+	RemoveSubIssue(parentIssueID, childIssueID string) error
+	GetProject(owner string, number int) (*api.Project, error)
+	AddIssueToProject(projectID, issueID string) (string, error)
+	issueTypeClient
+	userClient
+}
+
 type subAddOptions struct {
 	repo string
 }
@@ -57,6 +71,7 @@ Examples:
 			return runSubAdd(cmd, args, opts)
 		},
 	}
+	needsConfig(cmd)
 
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Default repository for issues (owner/repo format)")
 
@@ -64,21 +79,14 @@ Examples:
 }
 
 func runSubAdd(cmd *cobra.Command, args []string, opts *subAddOptions) error {
-	// Load configuration
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-
-	cfg, err := config.LoadFromDirectory(cwd)
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
+	return runSubAddWithDeps(cmd, args, opts, cc.cfg, cc.client)
+}
 
+func runSubAddWithDeps(cmd *cobra.Command, args []string, opts *subAddOptions, cfg *config.Config, client subClient) error {
 	// Parse parent issue reference
 	parentOwner, parentRepo, parentNumber, err := parseIssueReference(args[0])
 	if err != nil {
@@ -99,11 +107,8 @@ func runSubAdd(cmd *cobra.Command, args []string, opts *subAddOptions) error {
 			return fmt.Errorf("invalid --repo format: expected owner/repo, got %s", opts.repo)
 		}
 		defaultOwner, defaultRepo = parts[0], parts[1]
-	} else if len(cfg.Repositories) > 0 {
-		parts := strings.Split(cfg.Repositories[0], "/")
-		if len(parts) == 2 {
-			defaultOwner, defaultRepo = parts[0], parts[1]
-		}
+	} else {
+		defaultOwner, defaultRepo, _ = defaultRepository(cfg)
 	}
 
 	// Apply defaults if not specified in reference
@@ -123,9 +128,6 @@ func runSubAdd(cmd *cobra.Command, args []string, opts *subAddOptions) error {
 		childRepo = defaultRepo
 	}
 
-	// Create API client
-	client := api.NewClient()
-
 	// Validate parent issue exists
 	parentIssue, err := client.GetIssue(parentOwner, parentRepo, parentNumber)
 	if err != nil {
@@ -162,6 +164,9 @@ func runSubAdd(cmd *cobra.Command, args []string, opts *subAddOptions) error {
 		fmt.Printf("  Parent: %s\n", parentIssue.Title)
 		fmt.Printf("  Child:  %s\n", childIssue.Title)
 	}
+	if note := subIssueMarkerNote(client); note != "" {
+		fmt.Println(note)
+	}
 
 	return nil
 }
@@ -174,6 +179,7 @@ type subCreateOptions struct {
 	labels           []string
 	assignees        []string
 	milestone        string
+	issueType        string
 	project          int
 	inheritLabels    bool
 	inheritAssign    bool
@@ -202,19 +208,22 @@ Examples:
   gh pmu sub create --parent 10 --title "Implement feature X"
   gh pmu sub create --parent #10 --title "Task" --body "Description"
   gh pmu sub create -p 10 -t "Task" --no-inherit-labels
+  gh pmu sub create -p 10 -t "Fix crash" --type Bug
   gh pmu sub create --parent owner/repo1#10 --repo owner/repo2 --title "Cross-repo task"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSubCreate(cmd, opts)
 		},
 	}
+	needsConfig(cmd)
 
 	cmd.Flags().StringVarP(&opts.parent, "parent", "p", "", "Parent issue number or reference (required)")
 	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Issue title (required)")
 	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Issue body")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository for the new issue (owner/repo format, defaults to parent's repo)")
 	cmd.Flags().StringArrayVarP(&opts.labels, "label", "l", nil, "Add labels to the sub-issue (can be specified multiple times)")
-	cmd.Flags().StringArrayVarP(&opts.assignees, "assignee", "a", nil, "Assign users to the sub-issue (can be specified multiple times)")
+	cmd.Flags().StringArrayVarP(&opts.assignees, "assignee", "a", nil, "Assign users to the sub-issue by `login`, @me, or @org/team (can be specified multiple times)")
 	cmd.Flags().StringVarP(&opts.milestone, "milestone", "m", "", "Set milestone (title or number)")
+	cmd.Flags().StringVar(&opts.issueType, "type", "", "Set the issue type (e.g., Bug, Feature, Task)")
 	cmd.Flags().IntVar(&opts.project, "project", 0, "Add to project (project number)")
 	cmd.Flags().BoolVar(&opts.inheritLabels, "inherit-labels", true, "Inherit labels from parent (same repo only)")
 	cmd.Flags().BoolVar(&opts.inheritAssign, "inherit-assignees", false, "Inherit assignees from parent (same repo only)")
@@ -227,21 +236,14 @@ Examples:
 }
 
 func runSubCreate(cmd *cobra.Command, opts *subCreateOptions) error {
-	// Load configuration
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-
-	cfg, err := config.LoadFromDirectory(cwd)
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
+	return runSubCreateWithDeps(cmd, opts, cc.cfg, cc.client)
+}
 
+func runSubCreateWithDeps(cmd *cobra.Command, opts *subCreateOptions, cfg *config.Config, client subClient) error {
 	// Parse parent issue reference
 	parentOwner, parentRepo, parentNumber, err := parseIssueReference(opts.parent)
 	if err != nil {
@@ -250,15 +252,9 @@ func runSubCreate(cmd *cobra.Command, opts *subCreateOptions) error {
 
 	// Default to configured repo if not specified
 	if parentOwner == "" || parentRepo == "" {
-		if len(cfg.Repositories) == 0 {
-			return fmt.Errorf("no repository specified and none configured")
+		if parentOwner, parentRepo, err = defaultRepository(cfg); err != nil {
+			return err
 		}
-		parts := strings.Split(cfg.Repositories[0], "/")
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid repository format in config: %s", cfg.Repositories[0])
-		}
-		parentOwner = parts[0]
-		parentRepo = parts[1]
 	}
 
 	// Determine target repository for new issue
@@ -277,9 +273,6 @@ func runSubCreate(cmd *cobra.Command, opts *subCreateOptions) error {
 		isCrossRepo = (targetOwner != parentOwner || targetRepo != parentRepo)
 	}
 
-	// Create API client
-	client := api.NewClient()
-
 	// Get parent issue to validate and optionally inherit from
 	parentIssue, err := client.GetIssue(parentOwner, parentRepo, parentNumber)
 	if err != nil {
@@ -307,11 +300,21 @@ func runSubCreate(cmd *cobra.Command, opts *subCreateOptions) error {
 		}
 	}
 
+	assignees, err := resolveAssignees(client, opts.assignees)
+	if err != nil {
+		return err
+	}
+	issueType, err := lookupIssueType(client, targetOwner, opts.issueType)
+	if err != nil {
+		return err
+	}
+
 	// Create the new issue in target repository with extended options
-	newIssue, err := client.CreateIssueWithOptions(targetOwner, targetRepo, opts.title, opts.body, labels, opts.assignees, opts.milestone)
+	newIssue, err := client.CreateIssueWithOptions(targetOwner, targetRepo, opts.title, opts.body, labels, assignees, opts.milestone)
 	if err != nil {
 		return fmt.Errorf("failed to create issue in %s/%s: %w", targetOwner, targetRepo, err)
 	}
+	setCreatedIssueType(client, newIssue, issueType)
 
 	// Link as sub-issue
 	err = client.AddSubIssue(parentIssue.ID, newIssue.ID)
@@ -349,11 +352,14 @@ func runSubCreate(cmd *cobra.Command, opts *subCreateOptions) error {
 	if isCrossRepo {
 		fmt.Printf("  Repo:   %s/%s\n", targetOwner, targetRepo)
 	}
+	if newIssue.Type != "" {
+		fmt.Printf("  Type:   %s\n", newIssue.Type)
+	}
 	if len(labels) > 0 {
 		fmt.Printf("  Labels: %s\n", strings.Join(labels, ", "))
 	}
-	if len(opts.assignees) > 0 {
-		fmt.Printf("  Assignees: @%s\n", strings.Join(opts.assignees, ", @"))
+	if len(assignees) > 0 {
+		fmt.Printf("  Assignees: @%s\n", strings.Join(assignees, ", @"))
 	}
 	if opts.milestone != "" {
 		fmt.Printf("  Milestone: %s\n", opts.milestone)
@@ -362,12 +368,16 @@ func runSubCreate(cmd *cobra.Command, opts *subCreateOptions) error {
 		fmt.Printf("  Project: #%d\n", opts.project)
 	}
 	fmt.Printf("🔗 %s\n", newIssue.URL)
+	if note := subIssueMarkerNote(client); note != "" {
+		fmt.Println(note)
+	}
 
 	return nil
 }
 
 type subListOptions struct {
-	json     bool
+	export   *exportOptions
+	format   *formatOptions
 	state    string
 	limit    int
 	web      bool
@@ -400,14 +410,17 @@ Examples:
   gh pmu sub list 10 --web        # Open parent issue in browser
   gh pmu sub list 10 --relation parent    # Show parent issue
   gh pmu sub list 10 --relation siblings  # Show sibling issues
-  gh pmu sub list 10 --relation all       # Show parent, siblings, and children`,
+  gh pmu sub list 10 --relation all       # Show parent, siblings, and children
+  gh pmu sub list 10 --format csv         # Export sub-issues as CSV`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSubList(cmd, args, opts)
 		},
 	}
+	needsConfig(cmd)
 
-	cmd.Flags().BoolVar(&opts.json, "json", false, "Output in JSON format")
+	opts.export = addExportFlags(cmd, SubListItem{}, "children", "siblings")
+	opts.format = addFormatFlags(cmd, "number", "title", "state", "repository")
 	cmd.Flags().StringVarP(&opts.state, "state", "s", "all", "Filter by state: open, closed, all")
 	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 0, "Maximum number of items to display (0 for no limit)")
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open issue in browser")
@@ -428,22 +441,18 @@ func runSubList(cmd *cobra.Command, args []string, opts *subListOptions) error {
 	if opts.relation != "children" && opts.relation != "parent" && opts.relation != "siblings" && opts.relation != "all" {
 		return fmt.Errorf("invalid relation: %s (must be children, parent, siblings, or all)", opts.relation)
 	}
-
-	// Load configuration
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+	if err := opts.format.validate(opts.export); err != nil {
+		return err
 	}
 
-	cfg, err := config.LoadFromDirectory(cwd)
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
+	return runSubListWithDeps(cmd, args, opts, cc.cfg, cc.client)
+}
 
+func runSubListWithDeps(cmd *cobra.Command, args []string, opts *subListOptions, cfg *config.Config, client subClient) error {
 	// Parse issue reference
 	issueOwner, issueRepo, issueNumber, err := parseIssueReference(args[0])
 	if err != nil {
@@ -452,20 +461,11 @@ func runSubList(cmd *cobra.Command, args []string, opts *subListOptions) error {
 
 	// Default to configured repo if not specified
 	if issueOwner == "" || issueRepo == "" {
-		if len(cfg.Repositories) == 0 {
-			return fmt.Errorf("no repository specified and none configured")
-		}
-		parts := strings.Split(cfg.Repositories[0], "/")
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid repository format in config: %s", cfg.Repositories[0])
+		if issueOwner, issueRepo, err = defaultRepository(cfg); err != nil {
+			return err
 		}
-		issueOwner = parts[0]
-		issueRepo = parts[1]
 	}
 
-	// Create API client
-	client := api.NewClient()
-
 	// Get the issue to validate it exists
 	issue, err := client.GetIssue(issueOwner, issueRepo, issueNumber)
 	if err != nil {
@@ -536,13 +536,63 @@ func runSubList(cmd *cobra.Command, args []string, opts *subListOptions) error {
 	}
 
 	// Output
-	if opts.json {
-		return outputSubListJSONExtended(result, opts.relation)
+	if opts.export.enabled() {
+		return outputSubListJSONExtended(cmd, result, opts.relation, opts.export)
+	}
+	if opts.format.enabled() {
+		return outputSubListRows(cmd, result, opts.relation, opts.format)
 	}
 
 	return outputSubListTableExtended(result, opts.relation)
 }
 
+// subListColumns are the columns of sub list rows
+var subListColumns = []string{"number", "title", "state", "url", "repository", "relation"}
+
+// outputSubListRows outputs the related issues with the --columns and
+// --format flags, one row per issue: the parent, then siblings, then
+// children. With --relation all, a relation column is shown by default.
+func outputSubListRows(cmd *cobra.Command, result SubListResult, relation string, format *formatOptions) error {
+	columns := format.selected()
+	if relation == "all" && len(format.columns) == 0 {
+		columns = append([]string{"relation"}, columns...)
+	}
+	known := func(column string) bool { return containsString(subListColumns, strings.ToLower(column)) }
+	if err := checkColumns(columns, known, subListColumns); err != nil {
+		return err
+	}
+
+	var rows [][]string
+	addRow := func(issue api.Issue, rel string) {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			if strings.EqualFold(column, "relation") {
+				row[i] = rel
+				continue
+			}
+			row[i], _ = issueCell(&issue, column)
+		}
+		rows = append(rows, row)
+	}
+	addSubIssues := func(subIssues []api.SubIssue, rel string) {
+		for _, sub := range subIssues {
+			addRow(api.Issue{Number: sub.Number, Title: sub.Title, State: sub.State, URL: sub.URL, Repository: sub.Repository}, rel)
+		}
+	}
+
+	if (relation == "parent" || relation == "all") && result.Parent != nil {
+		addRow(*result.Parent, "parent")
+	}
+	if relation == "siblings" || relation == "all" {
+		addSubIssues(result.Siblings, "sibling")
+	}
+	if relation == "children" || relation == "all" {
+		addSubIssues(result.Children, "child")
+	}
+
+	return format.write(cmd, columns, rows)
+}
+
 // SubListResult holds all the data for sub list output
 type SubListResult struct {
 	Issue    *api.Issue
@@ -696,7 +746,7 @@ type SubListParentJSON struct {
 	URL    string `json:"url"`
 }
 
-func outputSubListJSONExtended(result SubListResult, relation string) error {
+func outputSubListJSONExtended(cmd *cobra.Command, result SubListResult, relation string, export *exportOptions) error {
 	output := SubListJSONExtended{
 		Issue: SubListIssueJSON{
 			Number: result.Issue.Number,
@@ -762,9 +812,7 @@ func outputSubListJSONExtended(result SubListResult, relation string) error {
 		}
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return export.write(cmd, output)
 }
 
 func outputSubListTableExtended(result SubListResult, relation string) error {
@@ -882,6 +930,7 @@ Examples:
 			return runSubRemove(cmd, args, opts)
 		},
 	}
+	needsConfig(cmd)
 
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompts")
 
@@ -889,21 +938,14 @@ Examples:
 }
 
 func runSubRemove(cmd *cobra.Command, args []string, opts *subRemoveOptions) error {
-	// Load configuration
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-
-	cfg, err := config.LoadFromDirectory(cwd)
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
+	return runSubRemoveWithDeps(cmd, args, opts, cc.cfg, cc.client)
+}
 
+func runSubRemoveWithDeps(cmd *cobra.Command, args []string, opts *subRemoveOptions, cfg *config.Config, client subClient) error {
 	// Parse parent issue reference
 	parentOwner, parentRepo, parentNumber, err := parseIssueReference(args[0])
 	if err != nil {
@@ -911,13 +953,7 @@ func runSubRemove(cmd *cobra.Command, args []string, opts *subRemoveOptions) err
 	}
 
 	// Default to configured repo if not specified for parent
-	defaultOwner, defaultRepo := "", ""
-	if len(cfg.Repositories) > 0 {
-		parts := strings.Split(cfg.Repositories[0], "/")
-		if len(parts) == 2 {
-			defaultOwner, defaultRepo = parts[0], parts[1]
-		}
-	}
+	defaultOwner, defaultRepo, _ := defaultRepository(cfg)
 
 	if parentOwner == "" || parentRepo == "" {
 		if defaultOwner == "" || defaultRepo == "" {
@@ -927,9 +963,6 @@ func runSubRemove(cmd *cobra.Command, args []string, opts *subRemoveOptions) err
 		parentRepo = defaultRepo
 	}
 
-	// Create API client
-	client := api.NewClient()
-
 	// Validate parent issue exists
 	parentIssue, err := client.GetIssue(parentOwner, parentRepo, parentNumber)
 	if err != nil {
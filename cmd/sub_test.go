@@ -1170,7 +1170,7 @@ func TestOutputSubListJSONExtended_ChildrenOnly(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := outputSubListJSONExtended(result, "children")
+	err := outputSubListJSONExtended(newSubListCommand(), result, "children", nil)
 
 	w.Close()
 	os.Stdout = oldStdout
@@ -1227,7 +1227,7 @@ func TestOutputSubListJSONExtended_WithParent(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := outputSubListJSONExtended(result, "parent")
+	err := outputSubListJSONExtended(newSubListCommand(), result, "parent", nil)
 
 	w.Close()
 	os.Stdout = oldStdout
@@ -1279,7 +1279,7 @@ func TestOutputSubListJSONExtended_WithSiblings(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := outputSubListJSONExtended(result, "siblings")
+	err := outputSubListJSONExtended(newSubListCommand(), result, "siblings", nil)
 
 	w.Close()
 	os.Stdout = oldStdout
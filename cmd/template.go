@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	tmpl "github.com/scooter-indie/gh-pmu/internal/template"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newTemplateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Work with project templates",
+		Long:  `Inspect and validate the YAML templates used by 'gh pmu project create'.`,
+	}
+
+	cmd.AddCommand(newTemplateValidateCommand())
+
+	return cmd
+}
+
+type templateValidateOptions struct {
+	vars []string
+}
+
+func newTemplateValidateCommand() *cobra.Command {
+	opts := &templateValidateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate <file>...",
+		Short: "Validate project templates",
+		Long: `Validate one or more project templates.
+
+Checks the schema version, field types, option names and colors,
+template placeholders, and that fields referenced by views, aliases,
+and draft issues are defined. Problems are reported as file:line:column.
+
+Built-in templates can be validated by name (e.g. "scrum").`,
+		Example: `  # Validate a template file
+  gh pmu template validate ./team-board.yml
+
+  # Allow a custom placeholder variable
+  gh pmu template validate ./team-board.yml --var Team`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTemplateValidate(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.vars, "var", nil, "Declare a custom template variable (Key or Key=Value, can be specified multiple times)")
+
+	return cmd
+}
+
+func runTemplateValidate(cmd *cobra.Command, args []string, opts *templateValidateOptions) error {
+	u := ui.New(cmd.OutOrStdout())
+
+	var vars []string
+	for _, v := range opts.vars {
+		vars = append(vars, strings.SplitN(v, "=", 2)[0])
+	}
+
+	total := 0
+	for _, source := range args {
+		data, err := tmpl.ReadSource(source)
+		if err != nil {
+			return err
+		}
+
+		problems, err := tmpl.Validate(data, vars)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+
+		if len(problems) == 0 {
+			u.Success(fmt.Sprintf("%s is valid", source))
+			continue
+		}
+
+		printTemplateProblems(cmd, source, problems)
+		total += len(problems)
+	}
+
+	if total > 0 {
+		return fmt.Errorf("found %d problem(s)", total)
+	}
+
+	return nil
+}
+
+// printTemplateProblems writes template problems as file:line:column: message
+func printTemplateProblems(cmd *cobra.Command, source string, problems []tmpl.Problem) {
+	for _, p := range problems {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s:%s\n", source, p)
+	}
+}
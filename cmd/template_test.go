@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplateValidateCommand_BuiltinTemplate(t *testing.T) {
+	cmd := NewRootCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"template", "validate", "kanban"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "kanban is valid") {
+		t.Errorf("Expected success message, got: %s", buf.String())
+	}
+}
+
+func TestTemplateValidateCommand_ReportsFileLineColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.yml")
+	content := "project:\n  title: Board\nfields:\n  - name: Size\n    type: enum\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewRootCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"template", "validate", path})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error for invalid template")
+	}
+	if !strings.Contains(buf.String(), path+":5:11:") {
+		t.Errorf("Expected file:line:column in output, got: %s", buf.String())
+	}
+}
@@ -16,7 +16,8 @@ import (
 type triageOptions struct {
 	dryRun      bool
 	interactive bool
-	json        bool
+	export      *exportOptions
+	format      *formatOptions
 	list        bool
 	repo        string
 	query       string
@@ -31,6 +32,7 @@ type triageClient interface {
 	AddIssueToProject(projectID, issueID string) (string, error)
 	AddLabelToIssue(issueID, labelName string) error
 	SetProjectItemField(projectID, itemID, fieldName, value string) error
+	issueTypeClient
 }
 
 func newTriageCommand() *cobra.Command {
@@ -63,15 +65,24 @@ Each triage config has a query to match issues and rules to apply.`,
   gh pmu triage --query "is:open -label:triaged" --apply status:backlog
 
   # Ad-hoc bulk update with multiple fields
-  gh pmu triage --query "label:bug" --apply status:in_progress,priority:p1`,
+  gh pmu triage --query "label:bug" --apply status:in_progress,priority:p1
+
+  # Set the issue type of untyped issues labeled bug
+  gh pmu triage --query "label:bug -type:Bug" --apply type:Bug
+
+  # Export the issues a rule would process as a Markdown table
+  gh pmu triage tracked --dry-run --format markdown --columns number,title,labels`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runTriage(cmd, args, opts)
 		},
 	}
+	needsConfig(cmd)
+	takesWordArgs(cmd)
 
 	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be changed without making changes")
 	cmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", false, "Prompt before processing each issue")
-	cmd.Flags().BoolVar(&opts.json, "json", false, "Output in JSON format")
+	opts.export = addExportFlags(cmd, triageJSONIssue{}, "issues")
+	opts.format = addFormatFlags(cmd, "number", "title", "state", "labels")
 	cmd.Flags().BoolVarP(&opts.list, "list", "l", false, "List available triage configurations")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Target specific repository (owner/repo format)")
 	cmd.Flags().StringVarP(&opts.query, "query", "q", "", "Ad-hoc query (e.g., \"is:open -label:triaged\")")
@@ -81,32 +92,23 @@ Each triage config has a query to match issues and rules to apply.`,
 }
 
 func runTriage(cmd *cobra.Command, args []string, opts *triageOptions) error {
-	// Load configuration
-	cwd, err := os.Getwd()
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-
-	cfg, err := config.LoadFromDirectory(cwd)
-	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
 
-	// Create API client
-	client := api.NewClient()
-
-	return runTriageWithDeps(cmd, args, opts, cfg, client, os.Stdin)
+	return runTriageWithDeps(cmd, args, opts, cc.cfg, cc.client, os.Stdin)
 }
 
 // runTriageWithDeps is the testable implementation of runTriage
 func runTriageWithDeps(cmd *cobra.Command, args []string, opts *triageOptions, cfg *config.Config, client triageClient, stdin *os.File) error {
+	if err := opts.format.validate(opts.export); err != nil {
+		return err
+	}
+
 	// List mode
 	if opts.list {
-		return listTriageConfigs(cmd, cfg, opts.json)
+		return listTriageConfigs(cmd, cfg, opts.export.enabled())
 	}
 
 	// Ad-hoc mode with --query flag
@@ -138,8 +140,11 @@ func runTriageWithDeps(cmd *cobra.Command, args []string, opts *triageOptions, c
 	}
 
 	if len(matchingIssues) == 0 {
-		if opts.json {
-			return outputTriageJSON(cmd, nil, "no-matches", configName)
+		if opts.export.enabled() {
+			return outputTriageJSON(cmd, nil, "no-matches", configName, opts.export)
+		}
+		if opts.dryRun && opts.format.dataOnly() {
+			return opts.format.writeIssueRows(cmd, nil)
 		}
 		cmd.Printf("No issues match the triage query for %q\n", configName)
 		return nil
@@ -147,11 +152,16 @@ func runTriageWithDeps(cmd *cobra.Command, args []string, opts *triageOptions, c
 
 	// Dry run - just show what would be changed
 	if opts.dryRun {
-		if opts.json {
-			return outputTriageJSON(cmd, matchingIssues, "dry-run", configName)
+		if opts.export.enabled() {
+			return outputTriageJSON(cmd, matchingIssues, "dry-run", configName, opts.export)
+		}
+		if opts.format.dataOnly() {
+			return opts.format.writeIssueRows(cmd, matchingIssues)
 		}
 		cmd.Printf("Would process %d issue(s) with triage config %q:\n\n", len(matchingIssues), configName)
-		_ = outputTriageTable(cmd, matchingIssues)
+		if err := outputTriageTable(cmd, matchingIssues, opts.format); err != nil {
+			return err
+		}
 		cmd.Println()
 		describeTriageActions(cmd, cfg, &triageCfg)
 		return nil
@@ -193,8 +203,8 @@ func runTriageWithDeps(cmd *cobra.Command, args []string, opts *triageOptions, c
 	}
 
 	// Summary
-	if opts.json {
-		return outputTriageJSON(cmd, matchingIssues, "completed", configName)
+	if opts.export.enabled() {
+		return outputTriageJSON(cmd, matchingIssues, "completed", configName, opts.export)
 	}
 
 	cmd.Printf("\nTriage complete: %d processed", processed)
@@ -212,7 +222,7 @@ func runTriageWithDeps(cmd *cobra.Command, args []string, opts *triageOptions, c
 func listTriageConfigs(cmd *cobra.Command, cfg *config.Config, jsonOutput bool) error {
 	if len(cfg.Triage) == 0 {
 		if jsonOutput {
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := json.NewEncoder(cmd.OutOrStdout())
 			encoder.SetIndent("", "  ")
 			return encoder.Encode(map[string]interface{}{"configs": []interface{}{}})
 		}
@@ -225,6 +235,7 @@ func listTriageConfigs(cmd *cobra.Command, cfg *config.Config, jsonOutput bool)
 			Name        string            `json:"name"`
 			Query       string            `json:"query"`
 			ApplyLabels []string          `json:"applyLabels,omitempty"`
+			ApplyType   string            `json:"applyType,omitempty"`
 			ApplyFields map[string]string `json:"applyFields,omitempty"`
 		}
 
@@ -234,16 +245,17 @@ func listTriageConfigs(cmd *cobra.Command, cfg *config.Config, jsonOutput bool)
 				Name:        name,
 				Query:       tc.Query,
 				ApplyLabels: tc.Apply.Labels,
+				ApplyType:   tc.Apply.Type,
 				ApplyFields: tc.Apply.Fields,
 			})
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := json.NewEncoder(cmd.OutOrStdout())
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(map[string]interface{}{"configs": configs})
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "NAME\tQUERY\tACTIONS")
 
 	for name, tc := range cfg.Triage {
@@ -265,6 +277,10 @@ func describeActions(tc *config.Triage) string {
 		actions = append(actions, fmt.Sprintf("labels: %s", strings.Join(tc.Apply.Labels, ", ")))
 	}
 
+	if tc.Apply.Type != "" {
+		actions = append(actions, fmt.Sprintf("type: %s", tc.Apply.Type))
+	}
+
 	for field, value := range tc.Apply.Fields {
 		actions = append(actions, fmt.Sprintf("%s: %s", field, value))
 	}
@@ -286,6 +302,10 @@ func describeTriageActions(cmd *cobra.Command, cfg *config.Config, tc *config.Tr
 		cmd.Printf("  • Add labels: %s\n", strings.Join(tc.Apply.Labels, ", "))
 	}
 
+	if tc.Apply.Type != "" {
+		cmd.Printf("  • Set type: %s\n", tc.Apply.Type)
+	}
+
 	for field, value := range tc.Apply.Fields {
 		resolved := cfg.ResolveFieldValue(field, value)
 		cmd.Printf("  • Set %s: %s\n", field, resolved)
@@ -387,6 +407,11 @@ func matchesTriageQuery(issue api.Issue, query string) bool {
 		}
 	}
 
+	// Check issue type
+	if !matchesTriageTypes(issue, query) {
+		return false
+	}
+
 	// Check state
 	if strings.Contains(query, "is:open") && issue.State != "OPEN" {
 		return false
@@ -398,6 +423,34 @@ func matchesTriageQuery(issue api.Issue, query string) bool {
 	return true
 }
 
+// matchesTriageTypes checks the type: and -type: qualifiers of a query
+// against the issue type. As in GitHub search, type:issue matches every issue
+// and type:pr none.
+func matchesTriageTypes(issue api.Issue, query string) bool {
+	for _, term := range strings.Fields(query) {
+		negated := strings.HasPrefix(term, "-")
+		qualifier, value, ok := strings.Cut(strings.TrimPrefix(term, "-"), ":")
+		if !ok || !strings.EqualFold(qualifier, "type") {
+			continue
+		}
+		value = strings.Trim(value, "\"")
+
+		var matched bool
+		switch strings.ToLower(value) {
+		case "issue":
+			matched = true
+		case "pr", "pull-request":
+			matched = false
+		default:
+			matched = strings.EqualFold(issue.Type, value)
+		}
+		if matched == negated {
+			return false
+		}
+	}
+	return true
+}
+
 func applyTriageRules(client triageClient, cfg *config.Config, project *api.Project, issue *api.Issue, tc *config.Triage) error {
 	// First, ensure issue is in the project
 	itemID, err := ensureIssueInProject(client, project.ID, issue.ID)
@@ -415,6 +468,13 @@ func applyTriageRules(client triageClient, cfg *config.Config, project *api.Proj
 		}
 	}
 
+	// Apply issue type
+	if tc.Apply.Type != "" {
+		if err := applyTriageType(client, issue, tc.Apply.Type); err != nil {
+			return err
+		}
+	}
+
 	// Apply fields
 	for field, value := range tc.Apply.Fields {
 		fieldName := cfg.GetFieldName(field)
@@ -428,6 +488,22 @@ func applyTriageRules(client triageClient, cfg *config.Config, project *api.Proj
 	return nil
 }
 
+// applyTriageType sets the issue type of issue unless it already has it
+func applyTriageType(client issueTypeClient, issue *api.Issue, name string) error {
+	if strings.EqualFold(issue.Type, name) {
+		return nil
+	}
+	issueType, err := resolveIssueType(client, issue.Repository.Owner, name)
+	if err != nil {
+		return fmt.Errorf("failed to set type: %w", err)
+	}
+	if err := client.SetIssueType(issue.ID, issueType.ID); err != nil {
+		return fmt.Errorf("failed to set type: %w", err)
+	}
+	issue.Type = issueType.Name
+	return nil
+}
+
 func ensureIssueInProject(client triageClient, projectID, issueID string) (string, error) {
 	// Try to add - if already exists, this should return the existing item ID
 	itemID, err := client.AddIssueToProject(projectID, issueID)
@@ -442,8 +518,12 @@ func ensureIssueInProject(client triageClient, projectID, issueID string) (strin
 	return itemID, nil
 }
 
-func outputTriageTable(cmd *cobra.Command, issues []api.Issue) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+func outputTriageTable(cmd *cobra.Command, issues []api.Issue, format *formatOptions) error {
+	if format.enabled() {
+		return format.writeIssueRows(cmd, issues)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "NUMBER\tTITLE\tSTATE\tLABELS")
 
 	for _, issue := range issues {
@@ -482,7 +562,7 @@ type triageJSONIssue struct {
 	Labels []string `json:"labels"`
 }
 
-func outputTriageJSON(cmd *cobra.Command, issues []api.Issue, status, configName string) error {
+func outputTriageJSON(cmd *cobra.Command, issues []api.Issue, status, configName string, export *exportOptions) error {
 	output := triageJSONOutput{
 		Status:     status,
 		ConfigName: configName,
@@ -505,9 +585,7 @@ func outputTriageJSON(cmd *cobra.Command, issues []api.Issue, status, configName
 		})
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return export.write(cmd, output)
 }
 
 // runAdHocTriage runs a triage operation using --query and --apply flags instead of a config file entry
@@ -525,8 +603,11 @@ func runAdHocTriage(cmd *cobra.Command, opts *triageOptions, cfg *config.Config,
 	}
 
 	if len(matchingIssues) == 0 {
-		if opts.json {
-			return outputTriageJSON(cmd, nil, "no-matches", "ad-hoc")
+		if opts.export.enabled() {
+			return outputTriageJSON(cmd, nil, "no-matches", "ad-hoc", opts.export)
+		}
+		if opts.dryRun && opts.format.dataOnly() {
+			return opts.format.writeIssueRows(cmd, nil)
 		}
 		cmd.Println("No issues match the query")
 		return nil
@@ -537,15 +618,24 @@ func runAdHocTriage(cmd *cobra.Command, opts *triageOptions, cfg *config.Config,
 
 	// Dry run - show what would be changed
 	if opts.dryRun {
-		if opts.json {
-			return outputTriageJSON(cmd, matchingIssues, "dry-run", "ad-hoc")
+		if opts.export.enabled() {
+			return outputTriageJSON(cmd, matchingIssues, "dry-run", "ad-hoc", opts.export)
+		}
+		if opts.format.dataOnly() {
+			return opts.format.writeIssueRows(cmd, matchingIssues)
 		}
 		cmd.Printf("Would process %d issue(s) with query %q:\n\n", len(matchingIssues), opts.query)
-		_ = outputTriageTable(cmd, matchingIssues)
+		if err := outputTriageTable(cmd, matchingIssues, opts.format); err != nil {
+			return err
+		}
 		cmd.Println()
 		if len(applyFields) > 0 {
 			cmd.Println("Actions to apply:")
 			for field, value := range applyFields {
+				if isIssueTypeKey(cfg, field) {
+					cmd.Printf("  • Set type: %s\n", value)
+					continue
+				}
 				resolved := cfg.ResolveFieldValue(field, value)
 				cmd.Printf("  • Set %s: %s\n", field, resolved)
 			}
@@ -589,8 +679,8 @@ func runAdHocTriage(cmd *cobra.Command, opts *triageOptions, cfg *config.Config,
 	}
 
 	// Summary
-	if opts.json {
-		return outputTriageJSON(cmd, matchingIssues, "completed", "ad-hoc")
+	if opts.export.enabled() {
+		return outputTriageJSON(cmd, matchingIssues, "completed", "ad-hoc", opts.export)
 	}
 
 	cmd.Printf("\nTriage complete: %d processed", processed)
@@ -613,8 +703,15 @@ func applyAdHocTriageRules(client triageClient, cfg *config.Config, project *api
 		return fmt.Errorf("failed to add issue to project: %w", err)
 	}
 
-	// Apply fields
+	// Apply fields; "type" sets the issue type unless it names a project field
 	for field, value := range applyFields {
+		if isIssueTypeKey(cfg, field) {
+			if err := applyTriageType(client, issue, value); err != nil {
+				return err
+			}
+			continue
+		}
+
 		fieldName := cfg.GetFieldName(field)
 		resolvedValue := cfg.ResolveFieldValue(field, value)
 
@@ -626,6 +723,16 @@ func applyAdHocTriageRules(client triageClient, cfg *config.Config, project *api
 	return nil
 }
 
+// isIssueTypeKey reports whether an --apply key sets the issue type rather
+// than a project field aliased as "type"
+func isIssueTypeKey(cfg *config.Config, key string) bool {
+	if !strings.EqualFold(key, "type") {
+		return false
+	}
+	_, aliased := cfg.Fields[key]
+	return !aliased
+}
+
 // parseTriageApplyFields parses a comma-separated list of key:value pairs
 // Example: "status:backlog,priority:p1" -> {"status": "backlog", "priority": "p1"}
 func parseTriageApplyFields(s string) map[string]string {
@@ -26,6 +26,8 @@ type mockTriageClient struct {
 	addToProjectCalled bool
 	addLabelCalls      []string
 	setFieldCalls      []struct{ field, value string }
+	issueTypes         []api.IssueType
+	setTypeCalls       []string
 }
 
 func (m *mockTriageClient) GetRepositoryIssues(owner, repo, state string) ([]api.Issue, error) {
@@ -53,6 +55,15 @@ func (m *mockTriageClient) SetProjectItemField(projectID, itemID, fieldName, val
 	return m.setFieldError
 }
 
+func (m *mockTriageClient) ListIssueTypes(owner string) ([]api.IssueType, error) {
+	return m.issueTypes, nil
+}
+
+func (m *mockTriageClient) SetIssueType(issueID, typeID string) error {
+	m.setTypeCalls = append(m.setTypeCalls, typeID)
+	return nil
+}
+
 func TestTriageCommand(t *testing.T) {
 	t.Run("has correct command structure", func(t *testing.T) {
 		cmd := newTriageCommand()
@@ -143,7 +154,7 @@ func TestTriageOptions(t *testing.T) {
 		if opts.interactive {
 			t.Error("interactive should be false by default")
 		}
-		if opts.json {
+		if opts.export.enabled() {
 			t.Error("json should be false by default")
 		}
 		if opts.list {
@@ -310,6 +321,34 @@ func TestMatchesTriageQuery(t *testing.T) {
 	}
 }
 
+func TestMatchesTriageQuery_IssueTypes(t *testing.T) {
+	bug := api.Issue{State: "OPEN", Type: "Bug"}
+	untyped := api.Issue{State: "OPEN"}
+
+	tests := []struct {
+		name   string
+		issue  api.Issue
+		query  string
+		expect bool
+	}{
+		{"type matches", bug, "is:open type:Bug", true},
+		{"type matches ignoring case", bug, "type:bug", true},
+		{"type differs", bug, "type:Feature", false},
+		{"untyped issue", untyped, "type:Bug", false},
+		{"excluded type", bug, "-type:Bug", false},
+		{"excluded type on untyped issue", untyped, "-type:Bug", true},
+		{"type:issue matches every issue", untyped, "type:issue", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesTriageQuery(tt.issue, tt.query); got != tt.expect {
+				t.Errorf("matchesTriageQuery(%q) = %v, want %v", tt.query, got, tt.expect)
+			}
+		})
+	}
+}
+
 func TestDescribeActions(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -520,7 +559,7 @@ func TestOutputTriageTable(t *testing.T) {
 
 		cmd := newTriageCommand()
 		// Output goes to os.Stdout, verify no error
-		err := outputTriageTable(cmd, issues)
+		err := outputTriageTable(cmd, issues, nil)
 		if err != nil {
 			t.Fatalf("outputTriageTable() error = %v", err)
 		}
@@ -530,7 +569,7 @@ func TestOutputTriageTable(t *testing.T) {
 		issues := []api.Issue{}
 
 		cmd := newTriageCommand()
-		err := outputTriageTable(cmd, issues)
+		err := outputTriageTable(cmd, issues, nil)
 		if err != nil {
 			t.Fatalf("outputTriageTable() error = %v", err)
 		}
@@ -547,7 +586,7 @@ func TestOutputTriageTable(t *testing.T) {
 		}
 
 		cmd := newTriageCommand()
-		err := outputTriageTable(cmd, issues)
+		err := outputTriageTable(cmd, issues, nil)
 		if err != nil {
 			t.Fatalf("outputTriageTable() error = %v", err)
 		}
@@ -849,6 +888,86 @@ func TestApplyTriageRules(t *testing.T) {
 	})
 }
 
+func TestApplyTriageRules_IssueType(t *testing.T) {
+	t.Run("sets the configured type", func(t *testing.T) {
+		mock := &mockTriageClient{
+			addToProjectItemID: "item-123",
+			issueTypes:         []api.IssueType{{ID: "IT_bug", Name: "Bug", IsEnabled: true}},
+		}
+		issue := &api.Issue{ID: "issue-1", Number: 1, Repository: api.Repository{Owner: "acme", Name: "api"}}
+		triage := &config.Triage{Apply: config.TriageApply{Type: "bug"}}
+
+		if err := applyTriageRules(mock, &config.Config{}, &api.Project{ID: "proj-1"}, issue, triage); err != nil {
+			t.Fatalf("applyTriageRules() error = %v", err)
+		}
+		if len(mock.setTypeCalls) != 1 || mock.setTypeCalls[0] != "IT_bug" {
+			t.Errorf("expected type IT_bug to be set, got %v", mock.setTypeCalls)
+		}
+		if issue.Type != "Bug" {
+			t.Errorf("expected issue type Bug, got %q", issue.Type)
+		}
+	})
+
+	t.Run("skips issues that already have the type", func(t *testing.T) {
+		mock := &mockTriageClient{addToProjectItemID: "item-123"}
+		issue := &api.Issue{ID: "issue-1", Number: 1, Type: "Bug"}
+		triage := &config.Triage{Apply: config.TriageApply{Type: "Bug"}}
+
+		if err := applyTriageRules(mock, &config.Config{}, &api.Project{ID: "proj-1"}, issue, triage); err != nil {
+			t.Fatalf("applyTriageRules() error = %v", err)
+		}
+		if len(mock.setTypeCalls) != 0 {
+			t.Errorf("expected no type change, got %v", mock.setTypeCalls)
+		}
+	})
+
+	t.Run("unknown type is an error", func(t *testing.T) {
+		mock := &mockTriageClient{
+			addToProjectItemID: "item-123",
+			issueTypes:         []api.IssueType{{ID: "IT_bug", Name: "Bug", IsEnabled: true}},
+		}
+		issue := &api.Issue{ID: "issue-1", Number: 1}
+		triage := &config.Triage{Apply: config.TriageApply{Type: "Epic"}}
+
+		err := applyTriageRules(mock, &config.Config{}, &api.Project{ID: "proj-1"}, issue, triage)
+		if err == nil || !strings.Contains(err.Error(), "expected one of Bug") {
+			t.Errorf("expected unknown type error, got %v", err)
+		}
+	})
+}
+
+func TestApplyAdHocTriageRules_IssueType(t *testing.T) {
+	t.Run("type sets the issue type", func(t *testing.T) {
+		mock := &mockTriageClient{
+			addToProjectItemID: "item-123",
+			issueTypes:         []api.IssueType{{ID: "IT_task", Name: "Task", IsEnabled: true}},
+		}
+		issue := &api.Issue{ID: "issue-1", Number: 1}
+
+		err := applyAdHocTriageRules(mock, &config.Config{}, &api.Project{ID: "proj-1"}, issue, map[string]string{"type": "Task"})
+		if err != nil {
+			t.Fatalf("applyAdHocTriageRules() error = %v", err)
+		}
+		if len(mock.setTypeCalls) != 1 || len(mock.setFieldCalls) != 0 {
+			t.Errorf("expected the issue type to be set, got types %v and fields %v", mock.setTypeCalls, mock.setFieldCalls)
+		}
+	})
+
+	t.Run("a type field alias sets the project field", func(t *testing.T) {
+		mock := &mockTriageClient{addToProjectItemID: "item-123"}
+		cfg := &config.Config{Fields: map[string]config.Field{"type": {Field: "Work Type"}}}
+		issue := &api.Issue{ID: "issue-1", Number: 1}
+
+		err := applyAdHocTriageRules(mock, cfg, &api.Project{ID: "proj-1"}, issue, map[string]string{"type": "Chore"})
+		if err != nil {
+			t.Fatalf("applyAdHocTriageRules() error = %v", err)
+		}
+		if len(mock.setTypeCalls) != 0 || len(mock.setFieldCalls) != 1 || mock.setFieldCalls[0].field != "Work Type" {
+			t.Errorf("expected the Work Type field to be set, got types %v and fields %v", mock.setTypeCalls, mock.setFieldCalls)
+		}
+	})
+}
+
 func TestEnsureIssueInProject(t *testing.T) {
 	t.Run("returns item ID on success", func(t *testing.T) {
 		mock := &mockTriageClient{
@@ -936,7 +1055,7 @@ func TestOutputTriageJSON(t *testing.T) {
 
 		cmd := newTriageCommand()
 		// Output goes to os.Stdout, verify no error
-		err := outputTriageJSON(cmd, issues, "dry-run", "tracked")
+		err := outputTriageJSON(cmd, issues, "dry-run", "tracked", nil)
 		if err != nil {
 			t.Fatalf("outputTriageJSON() error = %v", err)
 		}
@@ -946,7 +1065,7 @@ func TestOutputTriageJSON(t *testing.T) {
 		issues := []api.Issue{}
 
 		cmd := newTriageCommand()
-		err := outputTriageJSON(cmd, issues, "no-matches", "estimate")
+		err := outputTriageJSON(cmd, issues, "no-matches", "estimate", nil)
 		if err != nil {
 			t.Fatalf("outputTriageJSON() error = %v", err)
 		}
@@ -961,7 +1080,7 @@ func TestOutputTriageJSON(t *testing.T) {
 
 		statuses := []string{"dry-run", "no-matches", "completed"}
 		for _, status := range statuses {
-			err := outputTriageJSON(cmd, issues, status, "config")
+			err := outputTriageJSON(cmd, issues, status, "config", nil)
 			if err != nil {
 				t.Errorf("outputTriageJSON() with status %q error = %v", status, err)
 			}
@@ -1087,6 +1206,29 @@ func TestRunTriageWithDeps(t *testing.T) {
 		}
 	})
 
+	t.Run("dry run with csv output", func(t *testing.T) {
+		cfg := makeConfig()
+		mock := &mockTriageClient{
+			project: &api.Project{ID: "proj-1"},
+			issues: []api.Issue{
+				{Number: 1, Title: "Test Issue", State: "OPEN", Labels: []api.Label{{Name: "bug"}}},
+			},
+		}
+		opts := &triageOptions{dryRun: true, format: &formatOptions{format: formatCSV, defaults: []string{"number", "title", "labels"}}}
+
+		buf := new(bytes.Buffer)
+		cmd := newTriageCommand()
+		cmd.SetOut(buf)
+
+		err := runTriageWithDeps(cmd, []string{"tracked"}, opts, cfg, mock, nil)
+		if err != nil {
+			t.Fatalf("runTriageWithDeps() error = %v", err)
+		}
+		if buf.String() != "number,title,labels\n1,Test Issue,bug\n" {
+			t.Errorf("expected only CSV rows, got:\n%s", buf.String())
+		}
+	})
+
 	t.Run("dry run with json output", func(t *testing.T) {
 		cfg := makeConfig()
 		mock := &mockTriageClient{
@@ -1095,7 +1237,7 @@ func TestRunTriageWithDeps(t *testing.T) {
 				{Number: 1, Title: "Test Issue", State: "OPEN", Labels: []api.Label{}},
 			},
 		}
-		opts := &triageOptions{dryRun: true, json: true}
+		opts := &triageOptions{dryRun: true, export: &exportOptions{json: true}}
 
 		cmd := newTriageCommand()
 
@@ -1134,7 +1276,7 @@ func TestRunTriageWithDeps(t *testing.T) {
 			project: &api.Project{ID: "proj-1"},
 			issues:  []api.Issue{},
 		}
-		opts := &triageOptions{json: true}
+		opts := &triageOptions{export: &exportOptions{json: true}}
 
 		cmd := newTriageCommand()
 
@@ -1219,7 +1361,7 @@ func TestRunTriageWithDeps(t *testing.T) {
 				{ID: "issue-1", Number: 1, Title: "Test Issue", State: "OPEN", Labels: []api.Label{}},
 			},
 		}
-		opts := &triageOptions{json: true}
+		opts := &triageOptions{export: &exportOptions{json: true}}
 
 		cmd := newTriageCommand()
 
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newTypesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "types",
+		Short: "Show an organization's issue types",
+		Long: `Show the issue types (such as Bug, Feature, and Task) defined by an
+organization. Issue types are set with --type on create and sub create,
+filtered with list --type, and matched by type: in triage queries.`,
+	}
+
+	cmd.AddCommand(newTypesListCommand())
+
+	return cmd
+}
+
+type typesListOptions struct {
+	export *exportOptions
+}
+
+func newTypesListCommand() *cobra.Command {
+	opts := &typesListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list [org]",
+		Short: "List an organization's issue types",
+		Long: `List the issue types defined by an organization.
+
+The organization defaults to the owner of the first configured repository.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTypesList(cmd, args, opts)
+		},
+	}
+	needsConfig(cmd)
+	takesWordArgs(cmd)
+
+	opts.export = addExportFlags(cmd, typeJSON{})
+
+	return cmd
+}
+
+// typesClient defines the interface for API methods used by the types command
+type typesClient interface {
+	ListIssueTypes(owner string) ([]api.IssueType, error)
+}
+
+// issueTypeClient is the part of the API client used to set issue types
+type issueTypeClient interface {
+	ListIssueTypes(owner string) ([]api.IssueType, error)
+	SetIssueType(issueID, typeID string) error
+}
+
+func runTypesList(cmd *cobra.Command, args []string, opts *typesListOptions) error {
+	cc, err := commandContext(cmd)
+	if err != nil {
+		return err
+	}
+	return runTypesListWithDeps(cmd, args, opts, cc.cfg, cc.client)
+}
+
+func runTypesListWithDeps(cmd *cobra.Command, args []string, opts *typesListOptions, cfg *config.Config, client typesClient) error {
+	var org string
+	if len(args) > 0 {
+		org = args[0]
+	} else {
+		owner, _, err := defaultRepository(cfg)
+		if err != nil {
+			return err
+		}
+		org = owner
+	}
+
+	types, err := client.ListIssueTypes(org)
+	if err != nil {
+		return err
+	}
+
+	if opts.export.enabled() {
+		return outputTypesJSON(cmd, types, opts.export)
+	}
+
+	if len(types) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No issue types defined for %s\n", org)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tDESCRIPTION")
+	for _, t := range types {
+		name := t.Name
+		if !t.IsEnabled {
+			name += " (disabled)"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", name, t.Description)
+	}
+	return w.Flush()
+}
+
+// typeJSON is the JSON output of an issue type
+type typeJSON struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func outputTypesJSON(cmd *cobra.Command, types []api.IssueType, export *exportOptions) error {
+	out := make([]typeJSON, 0, len(types))
+	for _, t := range types {
+		out = append(out, typeJSON{Name: t.Name, Description: t.Description, Color: t.Color, Enabled: t.IsEnabled})
+	}
+	return export.write(cmd, out)
+}
+
+// resolveIssueType finds the organization's enabled issue type named name,
+// ignoring case
+func resolveIssueType(client issueTypeClient, owner, name string) (*api.IssueType, error) {
+	types, err := client.ListIssueTypes(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i, t := range types {
+		if !t.IsEnabled {
+			continue
+		}
+		if strings.EqualFold(t.Name, name) {
+			return &types[i], nil
+		}
+		names = append(names, t.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("unknown issue type %q: %s has no issue types", name, owner)
+	}
+	return nil, fmt.Errorf("unknown issue type %q for %s: expected one of %s", name, owner, strings.Join(names, ", "))
+}
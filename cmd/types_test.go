@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+)
+
+// fakeTypesClient serves a fixed set of issue types
+type fakeTypesClient struct {
+	types    []api.IssueType
+	owner    string
+	setCalls []string
+}
+
+func (f *fakeTypesClient) ListIssueTypes(owner string) ([]api.IssueType, error) {
+	f.owner = owner
+	return f.types, nil
+}
+
+func (f *fakeTypesClient) SetIssueType(issueID, typeID string) error {
+	f.setCalls = append(f.setCalls, issueID+"="+typeID)
+	return nil
+}
+
+func newFakeTypesClient() *fakeTypesClient {
+	return &fakeTypesClient{types: []api.IssueType{
+		{ID: "IT_bug", Name: "Bug", Description: "An unexpected problem", IsEnabled: true},
+		{ID: "IT_feature", Name: "Feature", Description: "A request or idea", IsEnabled: true},
+		{ID: "IT_epic", Name: "Epic", IsEnabled: false},
+	}}
+}
+
+func TestTypesCommand_HasListSubcommand(t *testing.T) {
+	cmd := NewRootCommand()
+	sub, _, err := cmd.Find([]string{"types", "list"})
+	if err != nil || sub.Name() != "list" {
+		t.Fatalf("Expected types list command, got %v (%v)", sub, err)
+	}
+	if sub.Flags().Lookup("json") == nil {
+		t.Error("Expected --json flag")
+	}
+}
+
+func TestRunTypesListWithDeps_DefaultsToRepositoryOwner(t *testing.T) {
+	client := newFakeTypesClient()
+	cfg := &config.Config{Repositories: []string{"acme/api"}}
+	cmd := newTypesListCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	if err := runTypesListWithDeps(cmd, nil, &typesListOptions{}, cfg, client); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.owner != "acme" {
+		t.Errorf("Expected types for acme, got %q", client.owner)
+	}
+	out := buf.String()
+	for _, want := range []string{"NAME", "Bug", "An unexpected problem", "Epic (disabled)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunTypesListWithDeps_JSON(t *testing.T) {
+	client := newFakeTypesClient()
+	cmd := newTypesListCommand()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	if err := runTypesListWithDeps(cmd, []string{"other-org"}, &typesListOptions{export: &exportOptions{json: true}}, &config.Config{}, client); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.owner != "other-org" {
+		t.Errorf("Expected types for other-org, got %q", client.owner)
+	}
+
+	var out []typeJSON
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(out) != 3 || out[0].Name != "Bug" || !out[0].Enabled || out[2].Enabled {
+		t.Errorf("Unexpected JSON output: %+v", out)
+	}
+}
+
+func TestResolveIssueType(t *testing.T) {
+	client := newFakeTypesClient()
+
+	issueType, err := resolveIssueType(client, "acme", "feature")
+	if err != nil || issueType.ID != "IT_feature" {
+		t.Errorf("Expected Feature, got %+v (%v)", issueType, err)
+	}
+
+	_, err = resolveIssueType(client, "acme", "Epic")
+	if err == nil || !strings.Contains(err.Error(), "expected one of Bug, Feature") {
+		t.Errorf("Expected disabled types to be rejected, got %v", err)
+	}
+}
+
+func TestLookupIssueType_NoType(t *testing.T) {
+	client := newFakeTypesClient()
+
+	issueType, err := lookupIssueType(client, "acme", "")
+	if err != nil || issueType != nil {
+		t.Errorf("Expected no type, got %+v (%v)", issueType, err)
+	}
+	if client.owner != "" {
+		t.Error("Expected no issue types to be fetched")
+	}
+}
+
+func TestSetCreatedIssueType(t *testing.T) {
+	client := newFakeTypesClient()
+	issue := &api.Issue{ID: "I_1"}
+
+	setCreatedIssueType(client, issue, &api.IssueType{ID: "IT_bug", Name: "Bug"})
+	if len(client.setCalls) != 1 || client.setCalls[0] != "I_1=IT_bug" || issue.Type != "Bug" {
+		t.Errorf("Expected the type to be set, got %v and %q", client.setCalls, issue.Type)
+	}
+}
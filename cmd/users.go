@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+)
+
+// userClient is the part of the API client that resolves and checks users
+type userClient interface {
+	GetViewerLogin() (string, error)
+	GetTeamMembers(org, team string) ([]string, error)
+	GetUserID(login string) (string, error)
+}
+
+// resolveLogins turns user arguments into logins: "@me" becomes the
+// authenticated user, "@org/team" the members of the team, and a leading
+// "@" is dropped from other logins. Comma-separated lists are accepted, and
+// duplicates are removed, keeping the first.
+func resolveLogins(client userClient, users []string) ([]string, error) {
+	var logins []string
+	seen := make(map[string]bool)
+	add := func(login string) {
+		if key := strings.ToLower(login); !seen[key] {
+			seen[key] = true
+			logins = append(logins, login)
+		}
+	}
+
+	for _, arg := range users {
+		for _, user := range strings.Split(arg, ",") {
+			user = strings.TrimSpace(user)
+			switch {
+			case user == "":
+			case strings.EqualFold(user, "@me"):
+				login, err := client.GetViewerLogin()
+				if err != nil {
+					return nil, err
+				}
+				add(login)
+			case strings.HasPrefix(user, "@") && strings.Contains(user, "/"):
+				org, team, _ := strings.Cut(strings.TrimPrefix(user, "@"), "/")
+				if org == "" || team == "" {
+					return nil, fmt.Errorf("invalid team %q: expected @org/team", user)
+				}
+				members, err := client.GetTeamMembers(org, team)
+				if err != nil {
+					return nil, err
+				}
+				if len(members) == 0 {
+					return nil, fmt.Errorf("team %s has no members", user)
+				}
+				for _, member := range members {
+					add(member)
+				}
+			default:
+				add(strings.TrimPrefix(user, "@"))
+			}
+		}
+	}
+
+	return logins, nil
+}
+
+// validateLogins checks that every login exists before a command changes
+// anything, reporting all unknown users at once. Errors other than a missing
+// user, such as rate limits, are returned as they are.
+func validateLogins(client userClient, logins []string) error {
+	var unknown []string
+	for _, login := range logins {
+		if _, err := client.GetUserID(login); errors.Is(err, api.ErrNotFound) {
+			unknown = append(unknown, login)
+		} else if err != nil {
+			return err
+		}
+	}
+	switch len(unknown) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("unknown user %q", unknown[0])
+	}
+	return fmt.Errorf("unknown users: %s", strings.Join(unknown, ", "))
+}
+
+// resolveAssignees resolves user arguments for assignment and checks that
+// each login exists, so nothing is created with assignees that would fail
+func resolveAssignees(client userClient, users []string) ([]string, error) {
+	logins, err := resolveLogins(client, users)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLogins(client, logins); err != nil {
+		return nil, err
+	}
+	return logins, nil
+}
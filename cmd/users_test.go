@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+)
+
+// userAPI is the fake API with a signed-in user, teams, and known users. It
+// records the issues it creates.
+type userAPI struct {
+	*fakeAPI
+	viewer  string
+	teams   map[string][]string // "org/team" -> members
+	users   map[string]bool
+	created [][]string // assignees of each created issue
+}
+
+func newUserAPI() *userAPI {
+	return &userAPI{
+		fakeAPI: newFakeAPI(),
+		viewer:  "octocat",
+		teams:   map[string][]string{"acme/backend": {"alice", "octocat"}},
+		users:   map[string]bool{"octocat": true, "alice": true, "bob": true},
+	}
+}
+
+func (u *userAPI) GetViewerLogin() (string, error) {
+	return u.viewer, nil
+}
+
+func (u *userAPI) GetTeamMembers(org, team string) ([]string, error) {
+	members, ok := u.teams[org+"/"+team]
+	if !ok {
+		return nil, fmt.Errorf("team %s/%s not found", org, team)
+	}
+	return members, nil
+}
+
+func (u *userAPI) GetUserID(login string) (string, error) {
+	if !u.users[login] {
+		return "", fmt.Errorf("user %q: %w", login, api.ErrNotFound)
+	}
+	return "U_" + login, nil
+}
+
+func (u *userAPI) CreateIssueWithOptions(owner, repo, title, body string, labels, assignees []string, milestone string) (*api.Issue, error) {
+	u.created = append(u.created, assignees)
+	return &api.Issue{ID: "I_new", Number: 99, Title: title}, nil
+}
+
+func (u *userAPI) AddIssueToProject(projectID, issueID string) (string, error) {
+	return "item-new", nil
+}
+
+func (u *userAPI) SetProjectItemField(projectID, itemID, fieldName, value string) error {
+	return nil
+}
+
+func TestResolveLogins(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []string
+		want  []string
+	}{
+		{"plain logins", []string{"alice", "@bob"}, []string{"alice", "bob"}},
+		{"me", []string{"@me"}, []string{"octocat"}},
+		{"team", []string{"@acme/backend"}, []string{"alice", "octocat"}},
+		{"comma-separated without duplicates", []string{"@me,alice", "@acme/backend", "Alice"}, []string{"octocat", "alice"}},
+		{"empty", []string{"", " , "}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveLogins(newUserAPI(), tt.users)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveLogins(%v) = %v, want %v", tt.users, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLogins_PlainLoginsNeedNoClient(t *testing.T) {
+	got, err := resolveLogins(nil, []string{"alice"})
+	if err != nil || !reflect.DeepEqual(got, []string{"alice"}) {
+		t.Errorf("Expected alice, got %v, %v", got, err)
+	}
+}
+
+func TestResolveLogins_Errors(t *testing.T) {
+	client := newUserAPI()
+	client.teams["acme/empty"] = nil
+
+	for user, want := range map[string]string{
+		"@acme/":       "invalid team",
+		"@acme/ghosts": "team acme/ghosts not found",
+		"@acme/empty":  "team @acme/empty has no members",
+	} {
+		if _, err := resolveLogins(client, []string{user}); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("resolveLogins(%q): expected %q, got %v", user, want, err)
+		}
+	}
+}
+
+func TestValidateLogins(t *testing.T) {
+	client := newUserAPI()
+
+	if err := validateLogins(client, []string{"alice", "bob"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := validateLogins(client, []string{"alice", "ghost"}); err == nil || err.Error() != `unknown user "ghost"` {
+		t.Errorf("Expected unknown user error, got %v", err)
+	}
+	if err := validateLogins(client, []string{"ghost", "alice", "spook"}); err == nil || err.Error() != "unknown users: ghost, spook" {
+		t.Errorf("Expected every unknown user, got %v", err)
+	}
+
+	limited := rateLimitedUserAPI{newUserAPI()}
+	if err := validateLogins(limited, []string{"alice"}); err == nil || strings.Contains(err.Error(), "unknown user") || !api.IsRateLimited(err) {
+		t.Errorf("Expected the rate limit error, got %v", err)
+	}
+}
+
+// rateLimitedUserAPI fails every user lookup with a rate limit error
+type rateLimitedUserAPI struct {
+	*userAPI
+}
+
+func (rateLimitedUserAPI) GetUserID(login string) (string, error) {
+	return "", fmt.Errorf("failed to get user ID for %s: %w", login, api.ErrRateLimited)
+}
+
+func TestList_AssigneeMe(t *testing.T) {
+	client := newUserAPI()
+	client.items[0].Issue.Assignees = []api.Actor{{Login: "octocat"}}
+
+	out, err := runRootWith(t, client, "list", "--assignee", "@me")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, client.items[0].Issue.Title) || strings.Contains(out, client.items[1].Issue.Title) {
+		t.Errorf("Expected only the viewer's issues, got:\n%s", out)
+	}
+}
+
+func TestList_AssigneeTeamSentToHost(t *testing.T) {
+	client := &hostFilteringAPI{fakeAPI: newFakeAPI(), itemQuery: true}
+
+	if _, err := runRootWith(t, teamListAPI{client, newUserAPI()}, "list", "--assignee", "@acme/backend"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := client.filter.Query(); !strings.Contains(got, "assignee:alice,octocat") {
+		t.Errorf("Expected the team's members in the query, got %q", got)
+	}
+}
+
+// teamListAPI lists through a host that filters items, resolving users
+// with a userAPI
+type teamListAPI struct {
+	*hostFilteringAPI
+	users *userAPI
+}
+
+func (t teamListAPI) GetViewerLogin() (string, error) {
+	return t.users.GetViewerLogin()
+}
+
+func (t teamListAPI) GetTeamMembers(org, team string) ([]string, error) {
+	return t.users.GetTeamMembers(org, team)
+}
+
+func (t teamListAPI) GetUserID(login string) (string, error) {
+	return t.users.GetUserID(login)
+}
+
+func TestCreate_ResolvesAssignees(t *testing.T) {
+	client := newUserAPI()
+
+	if _, err := runRootWith(t, client, "create", "--title", "New", "--assignee", "@me", "--assignee", "bob"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(client.created) != 1 || !reflect.DeepEqual(client.created[0], []string{"octocat", "bob"}) {
+		t.Errorf("Expected octocat and bob as assignees, got %v", client.created)
+	}
+}
+
+func TestCreate_UnknownAssigneeCreatesNothing(t *testing.T) {
+	client := newUserAPI()
+
+	_, err := runRootWith(t, client, "create", "--title", "New", "--assignee", "ghost")
+	if err == nil || !strings.Contains(err.Error(), `unknown user "ghost"`) {
+		t.Errorf("Expected unknown user error, got %v", err)
+	}
+	if len(client.created) != 0 {
+		t.Errorf("Expected no issue to be created, got %v", client.created)
+	}
+}
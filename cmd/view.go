@@ -1,9 +1,7 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
 	"runtime"
 	"strconv"
@@ -15,7 +13,7 @@ import (
 )
 
 type viewOptions struct {
-	json     bool
+	export   *exportOptions
 	web      bool
 	comments bool
 }
@@ -37,30 +35,34 @@ Also shows sub-issues if any exist, and parent issue if this is a sub-issue.`,
 			return runView(cmd, args, opts)
 		},
 	}
+	needsConfig(cmd)
 
-	cmd.Flags().BoolVar(&opts.json, "json", false, "Output in JSON format")
+	opts.export = addExportFlags(cmd, ViewJSONOutput{})
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open issue in browser")
 	cmd.Flags().BoolVarP(&opts.comments, "comments", "c", false, "Show issue comments")
 
 	return cmd
 }
 
-func runView(cmd *cobra.Command, args []string, opts *viewOptions) error {
-	// Load configuration
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
+// viewClient is the part of the API client used by view
+type viewClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	GetProject(owner string, number int) (*api.Project, error)
+	GetProjectItems(projectID string, filter *api.ProjectItemsFilter) ([]api.ProjectItem, error)
+	GetSubIssues(owner, repo string, number int) ([]api.SubIssue, error)
+	GetParentIssue(owner, repo string, number int) (*api.Issue, error)
+	GetIssueComments(owner, repo string, number int) ([]api.Comment, error)
+}
 
-	cfg, err := config.LoadFromDirectory(cwd)
+func runView(cmd *cobra.Command, args []string, opts *viewOptions) error {
+	cc, err := commandContext(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'gh pmu init' to create a configuration file", err)
-	}
-
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return err
 	}
+	return runViewWithDeps(cmd, args, opts, cc.cfg, cc.client)
+}
 
+func runViewWithDeps(cmd *cobra.Command, args []string, opts *viewOptions, cfg *config.Config, client viewClient) error {
 	// Parse issue reference
 	owner, repo, number, err := parseIssueReference(args[0])
 	if err != nil {
@@ -69,20 +71,11 @@ func runView(cmd *cobra.Command, args []string, opts *viewOptions) error {
 
 	// If owner/repo not specified, use first repo from config
 	if owner == "" || repo == "" {
-		if len(cfg.Repositories) == 0 {
-			return fmt.Errorf("no repository specified and none configured")
-		}
-		parts := strings.Split(cfg.Repositories[0], "/")
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid repository format in config: %s", cfg.Repositories[0])
+		if owner, repo, err = defaultRepository(cfg); err != nil {
+			return err
 		}
-		owner = parts[0]
-		repo = parts[1]
 	}
 
-	// Create API client
-	client := api.NewClient()
-
 	// Fetch issue
 	issue, err := client.GetIssue(owner, repo, number)
 	if err != nil {
@@ -139,8 +132,8 @@ func runView(cmd *cobra.Command, args []string, opts *viewOptions) error {
 	}
 
 	// Output
-	if opts.json {
-		return outputViewJSON(cmd, issue, fieldValues, subIssues, parentIssue, comments)
+	if opts.export.enabled() {
+		return outputViewJSON(cmd, issue, fieldValues, subIssues, parentIssue, comments, opts.export)
 	}
 
 	return outputViewTable(cmd, issue, fieldValues, subIssues, parentIssue, comments)
@@ -168,6 +161,7 @@ type ViewJSONOutput struct {
 	Body        string            `json:"body"`
 	URL         string            `json:"url"`
 	Author      string            `json:"author"`
+	Type        string            `json:"type,omitempty"`
 	Assignees   []string          `json:"assignees"`
 	Labels      []string          `json:"labels"`
 	Milestone   string            `json:"milestone,omitempty"`
@@ -207,7 +201,7 @@ type ParentIssueJSON struct {
 	URL    string `json:"url"`
 }
 
-func outputViewJSON(cmd *cobra.Command, issue *api.Issue, fieldValues []api.FieldValue, subIssues []api.SubIssue, parentIssue *api.Issue, comments []api.Comment) error {
+func outputViewJSON(cmd *cobra.Command, issue *api.Issue, fieldValues []api.FieldValue, subIssues []api.SubIssue, parentIssue *api.Issue, comments []api.Comment, export *exportOptions) error {
 	output := ViewJSONOutput{
 		Number:      issue.Number,
 		Title:       issue.Title,
@@ -215,6 +209,7 @@ func outputViewJSON(cmd *cobra.Command, issue *api.Issue, fieldValues []api.Fiel
 		Body:        issue.Body,
 		URL:         issue.URL,
 		Author:      issue.Author.Login,
+		Type:        issue.Type,
 		Assignees:   make([]string, 0),
 		Labels:      make([]string, 0),
 		FieldValues: make(map[string]string),
@@ -283,9 +278,7 @@ func outputViewJSON(cmd *cobra.Command, issue *api.Issue, fieldValues []api.Fiel
 		}
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	return export.write(cmd, output)
 }
 
 func outputViewTable(cmd *cobra.Command, issue *api.Issue, fieldValues []api.FieldValue, subIssues []api.SubIssue, parentIssue *api.Issue, comments []api.Comment) error {
@@ -298,6 +291,11 @@ func outputViewTable(cmd *cobra.Command, issue *api.Issue, fieldValues []api.Fie
 	// Author
 	fmt.Printf("Author: @%s\n", issue.Author.Login)
 
+	// Issue type
+	if issue.Type != "" {
+		fmt.Printf("Type: %s\n", issue.Type)
+	}
+
 	// Assignees
 	if len(issue.Assignees) > 0 {
 		var assignees []string
@@ -428,10 +426,10 @@ func parseIssueNumber(s string) (int, error) {
 // Accepts formats: "123", "#123", "owner/repo#123", or full GitHub issue URL
 // Returns owner, repo, number (owner/repo may be empty if not specified)
 func parseIssueReference(s string) (owner, repo string, number int, err error) {
-	// Check for GitHub URL format
+	// Check for GitHub URL format, on github.com or a GitHub Enterprise Server host
 	// Formats: https://github.com/owner/repo/issues/123
-	//          https://github.com/owner/repo/issues/123#issuecomment-...
-	if strings.HasPrefix(s, "https://github.com/") || strings.HasPrefix(s, "http://github.com/") {
+	//          https://github.example.com/owner/repo/issues/123#issuecomment-...
+	if strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") {
 		owner, repo, number, err = parseIssueURL(s)
 		if err != nil {
 			return "", "", 0, err
@@ -470,16 +468,17 @@ func parseIssueReference(s string) (owner, repo string, number int, err error) {
 // Supports formats:
 //   - https://github.com/owner/repo/issues/123
 //   - https://github.com/owner/repo/issues/123#issuecomment-...
+//   - https://github.example.com/owner/repo/issues/123 (GitHub Enterprise Server)
 func parseIssueURL(url string) (owner, repo string, number int, err error) {
 	// Remove protocol prefix
 	url = strings.TrimPrefix(url, "https://")
 	url = strings.TrimPrefix(url, "http://")
 
-	// Remove github.com prefix
-	if !strings.HasPrefix(url, "github.com/") {
-		return "", "", 0, fmt.Errorf("invalid GitHub URL: not a github.com URL")
+	// Remove host prefix
+	host, url, ok := strings.Cut(url, "/")
+	if !ok || host == "" {
+		return "", "", 0, fmt.Errorf("invalid GitHub URL: missing host")
 	}
-	url = strings.TrimPrefix(url, "github.com/")
 
 	// Split path parts: owner/repo/issues/number[#anchor]
 	parts := strings.Split(url, "/")
@@ -507,3 +506,11 @@ func parseIssueURL(url string) (owner, repo string, number int, err error) {
 
 	return owner, repo, number, nil
 }
+
+// issueURL returns the web URL of an issue on host (github.com when empty)
+func issueURL(host, owner, repo string, number int) string {
+	if host == "" {
+		host = api.DefaultHost
+	}
+	return fmt.Sprintf("https://%s/%s/%s/issues/%d", host, owner, repo, number)
+}
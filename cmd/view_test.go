@@ -146,6 +146,7 @@ func TestViewCommand_ParsesIssueReference(t *testing.T) {
 		{"URL with anchor", "https://github.com/owner/repo/issues/123#issuecomment-456", "owner", "repo", 123, false},
 		{"invalid URL - not issues", "https://github.com/owner/repo/pulls/123", "", "", 0, true},
 		{"invalid URL - too short", "https://github.com/owner", "", "", 0, true},
+		{"Enterprise Server URL", "https://ghe.example.com/owner/repo/issues/7", "owner", "repo", 7, false},
 	}
 
 	for _, tt := range tests {
@@ -501,7 +502,7 @@ func TestOutputViewJSON_BasicIssue(t *testing.T) {
 		Author: api.Actor{Login: "testuser"},
 	}
 
-	err := outputViewJSON(cmd, issue, nil, nil, nil, nil)
+	err := outputViewJSON(cmd, issue, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("outputViewJSON() error = %v", err)
 	}
@@ -528,7 +529,7 @@ func TestOutputViewJSON_WithAllFields(t *testing.T) {
 		{Field: "Priority", Value: "High"},
 	}
 
-	err := outputViewJSON(cmd, issue, fieldValues, nil, nil, nil)
+	err := outputViewJSON(cmd, issue, fieldValues, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("outputViewJSON() error = %v", err)
 	}
@@ -552,7 +553,7 @@ func TestOutputViewJSON_WithSubIssues(t *testing.T) {
 		{Number: 45, Title: "Sub 3", State: "CLOSED", URL: "https://github.com/owner/repo/issues/45"},
 	}
 
-	err := outputViewJSON(cmd, issue, nil, subIssues, nil, nil)
+	err := outputViewJSON(cmd, issue, nil, subIssues, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("outputViewJSON() error = %v", err)
 	}
@@ -576,7 +577,7 @@ func TestOutputViewJSON_WithParentIssue(t *testing.T) {
 		URL:    "https://github.com/owner/repo/issues/10",
 	}
 
-	err := outputViewJSON(cmd, issue, nil, nil, parentIssue, nil)
+	err := outputViewJSON(cmd, issue, nil, nil, parentIssue, nil, nil)
 	if err != nil {
 		t.Fatalf("outputViewJSON() error = %v", err)
 	}
@@ -603,7 +604,7 @@ func TestOutputViewJSON_SubIssueProgress(t *testing.T) {
 		{Number: 5, Title: "Task 5", State: "OPEN"},
 	}
 
-	err := outputViewJSON(cmd, issue, nil, subIssues, nil, nil)
+	err := outputViewJSON(cmd, issue, nil, subIssues, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("outputViewJSON() error = %v", err)
 	}
@@ -655,7 +656,7 @@ func TestOutputViewJSON_WithComments(t *testing.T) {
 		{Author: "user2", Body: "Second comment", CreatedAt: "2024-01-02T11:00:00Z"},
 	}
 
-	err := outputViewJSON(cmd, issue, nil, nil, nil, comments)
+	err := outputViewJSON(cmd, issue, nil, nil, nil, comments, nil)
 	if err != nil {
 		t.Fatalf("outputViewJSON() error = %v", err)
 	}
@@ -774,6 +775,15 @@ func TestViewJSONOutput_WithParentIssue(t *testing.T) {
 	}
 }
 
+func TestIssueURL(t *testing.T) {
+	if got := issueURL("", "owner", "repo", 1); got != "https://github.com/owner/repo/issues/1" {
+		t.Errorf("Unexpected github.com URL: %s", got)
+	}
+	if got := issueURL("ghe.example.com", "owner", "repo", 1); got != "https://ghe.example.com/owner/repo/issues/1" {
+		t.Errorf("Unexpected Enterprise Server URL: %s", got)
+	}
+}
+
 func TestSubIssueJSON_Structure(t *testing.T) {
 	sub := SubIssueJSON{
 		Number: 43,
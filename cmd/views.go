@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	tmpl "github.com/scooter-indie/gh-pmu/internal/template"
+	"github.com/scooter-indie/gh-pmu/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+type viewSaveOptions struct {
+	list       listOptions
+	importView bool
+	force      bool
+}
+
+func newViewSaveCommand() *cobra.Command {
+	opts := &viewSaveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "view-save <name>",
+		Short: "Save list filters, columns, and sorting as a named view",
+		Long: `Save the given list flags as a named view in the views: section of the
+nearest .gh-pmu.yml, to run later with 'gh pmu list --view <name>'.
+
+Use --import to copy the project's own views (the tabs on the project board)
+instead: each view's filter string, visible fields, sorting, grouping, and
+layout are saved under the view's name in lowercase, with dashes for spaces.
+Give project view names to import only those.
+
+An existing view is only replaced with --force.`,
+		Example: `  # Save your in-progress backend work
+  gh pmu view-save my-wip --status in_progress --assignee octocat --label backend
+
+  # Save a board-like listing
+  gh pmu view-save board --group-by status --columns number,title,priority,assignees
+
+  # Import every view of the project board
+  gh pmu view-save --import
+
+  # Import one project view, replacing the saved copy
+  gh pmu view-save --import "Current sprint" --force`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.importView {
+				return nil
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runViewSave(cmd, opts, args)
+		},
+	}
+	needsConfig(cmd)
+
+	addListQueryFlags(cmd, &opts.list)
+	cmd.Flags().BoolVar(&opts.importView, "import", false, "Import the project's views from the project board")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Replace views that already exist")
+
+	return cmd
+}
+
+// viewSaveClient is the part of the API client used by view-save
+type viewSaveClient interface {
+	GetProject(owner string, number int) (*api.Project, error)
+	GetProjectViews(projectID string) ([]api.ProjectView, error)
+}
+
+func runViewSave(cmd *cobra.Command, opts *viewSaveOptions, args []string) error {
+	cc, err := commandContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	configPath := cc.cfg.Path()
+	if configPath == "" {
+		return fmt.Errorf("no %s to update: view-save needs a configuration file", config.ConfigFileName)
+	}
+	file, err := config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	return runViewSaveWithDeps(cmd, opts, args, cc.cfg, file, configPath, cc.client)
+}
+
+// runViewSaveWithDeps saves views to file, which is written to configPath
+func runViewSaveWithDeps(cmd *cobra.Command, opts *viewSaveOptions, args []string, cfg, file *config.Config, configPath string, client viewSaveClient) error {
+	if opts.importView {
+		return importProjectViews(cmd, opts, args, cfg, file, configPath, client)
+	}
+
+	name := args[0]
+	if _, exists := file.Views[name]; exists && !opts.force {
+		return fmt.Errorf("view %q already exists; use --force to replace it", name)
+	}
+
+	view := viewFromOptions(&opts.list)
+	if view.AllRepos && len(view.Repos) > 0 {
+		return fmt.Errorf("cannot use --repo with --all-repos")
+	}
+	if file.Views == nil {
+		file.Views = make(map[string]config.View)
+	}
+	file.Views[name] = view
+
+	if err := file.Save(configPath); err != nil {
+		return err
+	}
+
+	u := ui.New(cmd.OutOrStdout())
+	u.Success(fmt.Sprintf("Saved view %q to %s", name, configPath))
+	u.Info(fmt.Sprintf("Run it with: gh pmu list --view %s", name))
+	return nil
+}
+
+// importProjectViews saves the project's views, or those named in names
+func importProjectViews(cmd *cobra.Command, opts *viewSaveOptions, names []string, cfg, file *config.Config, configPath string, client viewSaveClient) error {
+	u := ui.New(cmd.OutOrStdout())
+
+	project, err := client.GetProject(cfg.Project.Owner, cfg.Project.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	views, err := client.GetProjectViews(project.ID)
+	if err != nil {
+		return err
+	}
+	if len(views) == 0 {
+		return fmt.Errorf("project %q has no views", project.Title)
+	}
+
+	selected := views
+	if len(names) > 0 {
+		selected = nil
+		for _, name := range names {
+			view, ok := findProjectView(views, name)
+			if !ok {
+				available := make([]string, len(views))
+				for i, v := range views {
+					available[i] = v.Name
+				}
+				return fmt.Errorf("project view %q not found\nAvailable views:\n  %s", name, strings.Join(available, "\n  "))
+			}
+			selected = append(selected, view)
+		}
+	}
+
+	if file.Views == nil {
+		file.Views = make(map[string]config.View)
+	}
+	var imported []string
+	for _, pv := range selected {
+		name := viewName(pv.Name)
+		if _, exists := file.Views[name]; exists && !opts.force {
+			u.Warning(fmt.Sprintf("Skipped %q: view %q already exists (use --force to replace it)", pv.Name, name))
+			continue
+		}
+		file.Views[name] = viewFromProject(pv)
+		imported = append(imported, fmt.Sprintf("Imported %q as view %q", pv.Name, name))
+	}
+	if len(imported) == 0 {
+		return nil
+	}
+
+	if err := file.Save(configPath); err != nil {
+		return err
+	}
+	for _, line := range imported {
+		u.Success(line)
+	}
+	return nil
+}
+
+// findProjectView returns the project view with the given name, compared
+// case-insensitively, or whose view name it is
+func findProjectView(views []api.ProjectView, name string) (api.ProjectView, bool) {
+	for _, view := range views {
+		if strings.EqualFold(view.Name, name) || viewName(view.Name) == name {
+			return view, true
+		}
+	}
+	return api.ProjectView{}, false
+}
+
+var nonViewNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// viewName turns a project view name such as "Current Sprint" into a view
+// name such as "current-sprint"
+func viewName(name string) string {
+	return strings.Trim(nonViewNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// projectViewColumns maps the project board's built-in fields to list columns
+var projectViewColumns = map[string]string{
+	"Title":      "title",
+	"Status":     "status",
+	"Assignees":  "assignees",
+	"Labels":     "labels",
+	"Milestone":  "milestone",
+	"Repository": "repository",
+	"Type":       "type",
+}
+
+// projectViewColumn returns the list column for a project field, and false
+// for built-in fields list cannot show, such as Linked pull requests
+func projectViewColumn(field string) (string, bool) {
+	if column, ok := projectViewColumns[field]; ok {
+		return column, true
+	}
+	if containsString(tmpl.BuiltinFields, field) {
+		return "", false
+	}
+	return field, true
+}
+
+// viewFromProject converts a project view to a saved view
+func viewFromProject(pv api.ProjectView) config.View {
+	view := config.View{
+		Filter: strings.TrimSpace(pv.Filter),
+		Layout: strings.ToLower(strings.TrimSuffix(pv.Layout, "_LAYOUT")),
+	}
+
+	if len(pv.Fields) > 0 {
+		view.Columns = []string{"number"}
+		for _, field := range pv.Fields {
+			if column, ok := projectViewColumn(field); ok {
+				view.Columns = append(view.Columns, column)
+			}
+		}
+	}
+
+	var keys []string
+	for _, key := range pv.SortBy {
+		column, ok := projectViewColumn(key.Field)
+		if !ok {
+			continue
+		}
+		if key.Descending {
+			column = "-" + column
+		}
+		keys = append(keys, column)
+	}
+	view.Sort = strings.Join(keys, ",")
+
+	for _, field := range pv.GroupBy {
+		if column, ok := projectViewColumn(field); ok {
+			view.GroupBy = column
+			break
+		}
+	}
+
+	return view
+}
+
+// viewFromOptions converts list flags to a saved view
+func viewFromOptions(opts *listOptions) config.View {
+	view := config.View{
+		Filter:       opts.filter,
+		Status:       opts.status,
+		Priority:     opts.priority,
+		Assignee:     opts.assignee,
+		Label:        opts.label,
+		Type:         opts.issueType,
+		Search:       opts.search,
+		Repos:        opts.repos,
+		AllRepos:     opts.allRepos,
+		HasSubIssues: opts.hasSubIssues,
+		Sort:         opts.sort,
+		GroupBy:      opts.groupBy,
+		Limit:        opts.limit,
+	}
+	if opts.format != nil {
+		view.Columns = opts.format.columns
+		view.Format = opts.format.format
+	}
+	return view
+}
+
+// applyView fills in list options from the view named by opts.view. Flags
+// given on the command line take precedence over the view, and the view's
+// format and columns are left out with --json, --jq, or --template.
+func applyView(cmd *cobra.Command, opts *listOptions, cfg *config.Config) error {
+	view, ok := cfg.Views[opts.view]
+	if !ok {
+		if len(cfg.Views) == 0 {
+			return fmt.Errorf("unknown view %q: no views configured; save one with 'gh pmu view-save'", opts.view)
+		}
+		return fmt.Errorf("unknown view %q: expected one of %s", opts.view, strings.Join(cfg.ViewNames(), ", "))
+	}
+
+	flags := cmd.Flags()
+	setString := func(flag string, target *string, value string) {
+		if !flags.Changed(flag) && value != "" {
+			*target = value
+		}
+	}
+	setString("filter", &opts.filter, view.Filter)
+	setString("status", &opts.status, view.Status)
+	setString("priority", &opts.priority, view.Priority)
+	setString("assignee", &opts.assignee, view.Assignee)
+	setString("label", &opts.label, view.Label)
+	setString("type", &opts.issueType, view.Type)
+	setString("search", &opts.search, view.Search)
+	setString("sort", &opts.sort, view.Sort)
+	setString("group-by", &opts.groupBy, view.GroupBy)
+
+	if !flags.Changed("repo") && !flags.Changed("all-repos") {
+		opts.repos = view.Repos
+		opts.allRepos = view.AllRepos
+	}
+	if !flags.Changed("has-sub-issues") && view.HasSubIssues {
+		opts.hasSubIssues = true
+	}
+	if !flags.Changed("limit") && view.Limit > 0 {
+		opts.limit = view.Limit
+	}
+
+	if opts.format != nil && !opts.export.enabled() {
+		setString("format", &opts.format.format, view.Format)
+		if !flags.Changed("columns") && len(view.Columns) > 0 {
+			opts.format.columns = view.Columns
+		}
+	}
+	return nil
+}
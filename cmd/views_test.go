@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/internal/api"
+	"github.com/scooter-indie/gh-pmu/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// runRootWithViews runs the root command against client with views configured
+func runRootWithViews(t *testing.T, client apiClient, views map[string]config.View, args ...string) (string, error) {
+	t.Helper()
+	return runRootSetup(t, client, func(root *cobra.Command, cc *cmdContext) {
+		cc.cfg.Views = views
+	}, args...)
+}
+
+func TestList_RunsSavedView(t *testing.T) {
+	views := map[string]config.View{
+		"done": {Status: "done", Columns: []string{"number", "title"}, Format: "csv"},
+	}
+
+	out, err := runRootWithViews(t, newFakeAPI(), views, "list", "--view", "done")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out, "number,title\n") || !strings.Contains(out, "Signup flow") || strings.Contains(out, "Login page") {
+		t.Errorf("Expected the view's done issues as CSV, got:\n%s", out)
+	}
+}
+
+func TestList_FlagsOverrideView(t *testing.T) {
+	views := map[string]config.View{
+		"done": {Status: "done", Columns: []string{"number", "title"}, Format: "csv"},
+	}
+
+	out, err := runRootWithViews(t, newFakeAPI(), views, "list", "--view", "done", "--status", "todo", "--format", "tsv")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out, "number\ttitle\n") || !strings.Contains(out, "Login page") || strings.Contains(out, "Signup flow") {
+		t.Errorf("Expected the flags to override the view, got:\n%s", out)
+	}
+
+	// --json leaves out the view's format instead of rejecting it
+	out, err = runRootWithViews(t, newFakeAPI(), views, "list", "--view", "done", "--json", "number")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"number"`) || strings.Contains(out, "number,title") {
+		t.Errorf("Expected JSON output, got:\n%s", out)
+	}
+}
+
+func TestList_UnknownView(t *testing.T) {
+	_, err := runRootWithViews(t, newFakeAPI(), nil, "list", "--view", "mine")
+	if err == nil || !strings.Contains(err.Error(), "no views configured") {
+		t.Errorf("Expected no views error, got %v", err)
+	}
+
+	views := map[string]config.View{"b": {}, "a": {}}
+	_, err = runRootWithViews(t, newFakeAPI(), views, "list", "--view", "mine")
+	if err == nil || !strings.Contains(err.Error(), `unknown view "mine": expected one of a, b`) {
+		t.Errorf("Expected unknown view error, got %v", err)
+	}
+}
+
+func TestList_FilterString(t *testing.T) {
+	client := &hostFilteringAPI{fakeAPI: newFakeAPI(), itemQuery: true}
+	if _, err := runRootWith(t, client, "list", "--filter", "status:Todo -label:blocked"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.filter.Filter != "status:Todo -label:blocked" {
+		t.Errorf("Expected the filter string to be sent to the host, got %+v", client.filter)
+	}
+
+	// Only the host can apply a filter string
+	client = &hostFilteringAPI{fakeAPI: newFakeAPI()}
+	_, err := runRootWith(t, client, "list", "--filter", "status:Todo")
+	if err == nil || !strings.Contains(err.Error(), "does not support project item queries") {
+		t.Errorf("Expected unsupported filter error, got %v", err)
+	}
+}
+
+// viewSaveAPI is the fake API for view-save, with the project's views
+type viewSaveAPI struct {
+	*fakeAPI
+	views []api.ProjectView
+}
+
+func (v *viewSaveAPI) GetProjectViews(projectID string) ([]api.ProjectView, error) {
+	return v.views, nil
+}
+
+// runViewSaveTest runs view-save with args against a config file holding
+// content, and returns the output and the saved configuration
+func runViewSaveTest(t *testing.T, content string, client viewSaveClient, args ...string) (string, *config.Config, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), config.ConfigFileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &viewSaveOptions{}
+	cmd := &cobra.Command{}
+	addListQueryFlags(cmd, &opts.list)
+	cmd.Flags().BoolVar(&opts.importView, "import", false, "")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "")
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+
+	runErr := runViewSaveWithDeps(cmd, opts, cmd.Flags().Args(), file, file, path, client)
+
+	saved, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Saved config does not load: %v", err)
+	}
+	return buf.String(), saved, runErr
+}
+
+const viewSaveConfig = `project:
+  owner: acme
+  number: 1
+repositories:
+  - acme/api
+views:
+  existing:
+    status: done
+`
+
+func TestViewSave_SavesFlags(t *testing.T) {
+	out, saved, err := runViewSaveTest(t, viewSaveConfig, nil,
+		"my-wip", "--status", "in_progress", "--assignee", "octocat", "--label", "backend", "--columns", "number,title", "--sort", "-priority", "--repo", "acme/*")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := config.View{
+		Status:   "in_progress",
+		Assignee: "octocat",
+		Label:    "backend",
+		Columns:  []string{"number", "title"},
+		Sort:     "-priority",
+		Repos:    []string{"acme/*"},
+	}
+	if !reflect.DeepEqual(saved.Views["my-wip"], want) {
+		t.Errorf("Expected %+v, got %+v", want, saved.Views["my-wip"])
+	}
+	if saved.Views["existing"].Status != "done" {
+		t.Errorf("Expected other views to be kept, got %+v", saved.Views)
+	}
+	if !strings.Contains(out, `Saved view "my-wip"`) || !strings.Contains(out, "gh pmu list --view my-wip") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+}
+
+func TestViewSave_KeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.ConfigFileName)
+	content := "# Team board\n" + viewSaveConfig + "  # Issues in review\n  review:\n    status: in_review\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &viewSaveOptions{}
+	cmd := &cobra.Command{}
+	addListQueryFlags(cmd, &opts.list)
+	if err := cmd.ParseFlags([]string{"--label", "bug"}); err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetOut(new(bytes.Buffer))
+	if err := runViewSaveWithDeps(cmd, opts, []string{"bugs"}, file, file, path, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := content + "  bugs:\n    label: bug\n"; string(data) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, data)
+	}
+}
+
+func TestViewSave_ExistingViewNeedsForce(t *testing.T) {
+	_, saved, err := runViewSaveTest(t, viewSaveConfig, nil, "existing", "--status", "todo")
+	if err == nil || !strings.Contains(err.Error(), "use --force") {
+		t.Errorf("Expected an error without --force, got %v", err)
+	}
+	if saved.Views["existing"].Status != "done" {
+		t.Errorf("Expected the view to be kept, got %+v", saved.Views["existing"])
+	}
+
+	_, saved, err = runViewSaveTest(t, viewSaveConfig, nil, "existing", "--status", "todo", "--force")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saved.Views["existing"].Status != "todo" {
+		t.Errorf("Expected the view to be replaced, got %+v", saved.Views["existing"])
+	}
+}
+
+func TestViewSave_ImportsProjectViews(t *testing.T) {
+	client := &viewSaveAPI{fakeAPI: newFakeAPI(), views: []api.ProjectView{
+		{
+			Name:    "Current Sprint",
+			Layout:  "BOARD_LAYOUT",
+			Filter:  "iteration:@current -label:blocked",
+			Fields:  []string{"Title", "Assignees", "Status", "Linked pull requests", "Story Points"},
+			GroupBy: []string{"Status"},
+			SortBy:  []api.ProjectViewSort{{Field: "Priority", Descending: true}, {Field: "Title"}},
+		},
+		{Name: "Existing", Layout: "TABLE_LAYOUT"},
+	}}
+
+	out, saved, err := runViewSaveTest(t, viewSaveConfig, client, "--import")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := config.View{
+		Filter:  "iteration:@current -label:blocked",
+		Layout:  "board",
+		Columns: []string{"number", "title", "assignees", "status", "Story Points"},
+		Sort:    "-Priority,title",
+		GroupBy: "status",
+	}
+	if !reflect.DeepEqual(saved.Views["current-sprint"], want) {
+		t.Errorf("Expected %+v, got %+v", want, saved.Views["current-sprint"])
+	}
+	if saved.Views["existing"].Status != "done" {
+		t.Errorf("Expected the existing view to be kept, got %+v", saved.Views["existing"])
+	}
+	if !strings.Contains(out, `Imported "Current Sprint" as view "current-sprint"`) || !strings.Contains(out, `Skipped "Existing"`) {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+}
+
+func TestViewSave_ImportsNamedProjectView(t *testing.T) {
+	client := &viewSaveAPI{fakeAPI: newFakeAPI(), views: []api.ProjectView{
+		{Name: "Backlog", Layout: "TABLE_LAYOUT", Filter: "status:Backlog"},
+		{Name: "Roadmap", Layout: "ROADMAP_LAYOUT"},
+	}}
+
+	_, saved, err := runViewSaveTest(t, viewSaveConfig, client, "--import", "roadmap")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := saved.Views["backlog"]; ok {
+		t.Error("Expected only the named view to be imported")
+	}
+	if saved.Views["roadmap"].Layout != "roadmap" {
+		t.Errorf("Expected the roadmap view, got %+v", saved.Views)
+	}
+
+	_, _, err = runViewSaveTest(t, viewSaveConfig, client, "--import", "Sprint")
+	if err == nil || !strings.Contains(err.Error(), `project view "Sprint" not found`) || !strings.Contains(err.Error(), "Backlog") {
+		t.Errorf("Expected not found error listing the views, got %v", err)
+	}
+}
+
+func TestViewName(t *testing.T) {
+	tests := map[string]string{
+		"Current Sprint":   "current-sprint",
+		"  My WIP! ":       "my-wip",
+		"Bugs / Triage":    "bugs-triage",
+		"Release 1.2 plan": "release-1-2-plan",
+	}
+	for name, want := range tests {
+		if got := viewName(name); got != want {
+			t.Errorf("viewName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
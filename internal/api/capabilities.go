@@ -0,0 +1,240 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FeatureIterationFields is the ability to create iteration fields with
+// their schedule, used by Supports
+const FeatureIterationFields = "iteration_fields"
+
+// FeatureItemQuery is the ability to filter project items on the server
+// with the query argument of ProjectV2.items, used by Supports
+const FeatureItemQuery = "item_query"
+
+// FeatureIssueDependencies is the ability to mark issues as blocked by
+// other issues, used by Supports
+const FeatureIssueDependencies = "issue_dependencies"
+
+// capabilityCacheTTL is how long probed capabilities are reused before the
+// schema is introspected again
+const capabilityCacheTTL = 24 * time.Hour
+
+// capabilities records which features a host's GraphQL schema provides
+type capabilities struct {
+	SubIssues       bool      `json:"sub_issues"`
+	IssueTypes      bool      `json:"issue_types"`
+	IterationFields bool      `json:"iteration_fields"`
+	ItemQuery       bool      `json:"item_query"`
+	Dependencies    bool      `json:"issue_dependencies"`
+	CheckedAt       time.Time `json:"checked_at"`
+}
+
+// has reports whether feature is available. Unknown features are assumed to be.
+func (caps *capabilities) has(feature string) bool {
+	switch feature {
+	case FeatureSubIssues:
+		return caps.SubIssues
+	case FeatureIssueTypes:
+		return caps.IssueTypes
+	case FeatureIterationFields:
+		return caps.IterationFields
+	case FeatureItemQuery:
+		return caps.ItemQuery
+	case FeatureIssueDependencies:
+		return caps.Dependencies
+	}
+	return true
+}
+
+// set records whether feature is available
+func (caps *capabilities) set(feature string, available bool) {
+	switch feature {
+	case FeatureSubIssues:
+		caps.SubIssues = available
+	case FeatureIssueTypes:
+		caps.IssueTypes = available
+	case FeatureIterationFields:
+		caps.IterationFields = available
+	case FeatureItemQuery:
+		caps.ItemQuery = available
+	case FeatureIssueDependencies:
+		caps.Dependencies = available
+	}
+}
+
+// Supports reports whether the host supports feature (FeatureSubIssues,
+// FeatureIssueTypes, FeatureIterationFields, FeatureItemQuery, or
+// FeatureIssueDependencies). The schema is introspected
+// once and the result cached per host for a day. When the schema cannot be
+// introspected, features are assumed to be supported and calls report
+// errors as they happen.
+func (c *Client) Supports(feature string) bool {
+	caps := c.capabilities()
+	return caps == nil || caps.has(feature)
+}
+
+// capabilities returns the host's capabilities, probing them on first use.
+// It returns nil when they could not be determined.
+func (c *Client) capabilities() *capabilities {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	if c.capsProbed {
+		return c.caps
+	}
+	c.capsProbed = true
+
+	host := c.Host()
+	if c.cacheCapabilities {
+		if caps := readCapabilityCache(host); caps != nil {
+			c.caps = caps
+			return caps
+		}
+	}
+
+	caps, err := c.probeCapabilities()
+	if err != nil || caps == nil {
+		return nil
+	}
+	c.caps = caps
+	if c.cacheCapabilities {
+		writeCapabilityCache(host, caps)
+	}
+	return caps
+}
+
+// markUnsupported records that a call found feature missing, so later calls
+// use their fallbacks
+func (c *Client) markUnsupported(feature string) {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	if c.caps == nil {
+		c.caps = &capabilities{SubIssues: true, IssueTypes: true, IterationFields: true, ItemQuery: true, Dependencies: true, CheckedAt: time.Now()}
+	}
+	c.caps.set(feature, false)
+	c.capsProbed = true
+}
+
+// probeCapabilities introspects the schema for the fields each feature needs.
+// It returns nil when introspection gives no answer.
+func (c *Client) probeCapabilities() (*capabilities, error) {
+	if c.gql == nil {
+		return nil, nil
+	}
+
+	var query struct {
+		Issue struct {
+			Fields []struct {
+				Name string
+			}
+		} `graphql:"issue: __type(name: \"Issue\")"`
+		FieldInput struct {
+			InputFields []struct {
+				Name string
+			}
+		} `graphql:"fieldInput: __type(name: \"CreateProjectV2FieldInput\")"`
+		ProjectV2 struct {
+			Fields []struct {
+				Name string
+				Args []struct {
+					Name string
+				}
+			}
+		} `graphql:"projectV2: __type(name: \"ProjectV2\")"`
+	}
+
+	if err := c.gql.Query("Capabilities", &query, nil); err != nil {
+		return nil, err
+	}
+	if len(query.Issue.Fields) == 0 {
+		// Introspection disabled or not answered
+		return nil, nil
+	}
+
+	caps := &capabilities{CheckedAt: time.Now()}
+	for _, f := range query.Issue.Fields {
+		switch f.Name {
+		case "subIssues":
+			caps.SubIssues = true
+		case "issueType":
+			caps.IssueTypes = true
+		case "blockedBy":
+			caps.Dependencies = true
+		}
+	}
+	for _, f := range query.FieldInput.InputFields {
+		if f.Name == "iterationConfiguration" {
+			caps.IterationFields = true
+		}
+	}
+	for _, f := range query.ProjectV2.Fields {
+		if f.Name != "items" {
+			continue
+		}
+		for _, arg := range f.Args {
+			if arg.Name == "query" {
+				caps.ItemQuery = true
+			}
+		}
+	}
+	return caps, nil
+}
+
+// capabilityCachePath returns the file probed capabilities are cached in
+func capabilityCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-pmu", "capabilities.json")
+}
+
+// readCapabilityCache returns the cached capabilities for host, or nil when
+// there are none or they are older than capabilityCacheTTL
+func readCapabilityCache(host string) *capabilities {
+	path := capabilityCachePath()
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cache map[string]*capabilities
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil
+	}
+	caps := cache[host]
+	if caps == nil || time.Since(caps.CheckedAt) > capabilityCacheTTL {
+		return nil
+	}
+	return caps
+}
+
+// writeCapabilityCache stores caps for host. Failures are ignored: the cache
+// only saves a query.
+func writeCapabilityCache(host string, caps *capabilities) {
+	path := capabilityCachePath()
+	if path == "" {
+		return
+	}
+	cache := make(map[string]*capabilities)
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	cache[host] = caps
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// schemaMock answers the capability probe with the given Issue fields and
+// CreateProjectV2FieldInput input fields
+func schemaMock(calls *int, issueFields, inputFields []string) *mockGraphQLClient {
+	return &mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if name != "Capabilities" {
+				return errors.New("unexpected query " + name)
+			}
+			*calls++
+			v := reflect.ValueOf(query).Elem()
+			setNames(v.FieldByName("Issue").FieldByName("Fields"), issueFields)
+			setNames(v.FieldByName("FieldInput").FieldByName("InputFields"), inputFields)
+			return nil
+		},
+	}
+}
+
+func setNames(slice reflect.Value, names []string) {
+	values := reflect.MakeSlice(slice.Type(), len(names), len(names))
+	for i, name := range names {
+		values.Index(i).FieldByName("Name").SetString(name)
+	}
+	slice.Set(values)
+}
+
+func TestSupports_ProbesSchemaOnce(t *testing.T) {
+	calls := 0
+	client := NewClientWithGraphQL(schemaMock(&calls, []string{"title", "subIssues"}, []string{"name", "iterationConfiguration"}))
+
+	if !client.Supports(FeatureSubIssues) {
+		t.Error("Expected sub-issues to be supported")
+	}
+	if client.Supports(FeatureIssueTypes) {
+		t.Error("Expected issue types to be unsupported")
+	}
+	if !client.Supports(FeatureIterationFields) {
+		t.Error("Expected iteration fields to be supported")
+	}
+	if calls != 1 {
+		t.Errorf("Expected one introspection query, got %d", calls)
+	}
+}
+
+func TestSupports_AssumesSupportWhenProbeFails(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			return errors.New("introspection is disabled")
+		},
+	})
+
+	if !client.Supports(FeatureSubIssues) || !client.Supports(FeatureIssueTypes) {
+		t.Error("Expected features to be assumed supported")
+	}
+}
+
+func TestSupports_UsesCachedCapabilities(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	writeCapabilityCache("ghe.example.com", &capabilities{IssueTypes: true, CheckedAt: time.Now()})
+
+	calls := 0
+	client := &Client{
+		gql:               schemaMock(&calls, []string{"subIssues"}, nil),
+		opts:              ClientOptions{Host: "ghe.example.com"},
+		cacheCapabilities: true,
+	}
+
+	if client.Supports(FeatureSubIssues) {
+		t.Error("Expected the cached result (no sub-issues) to be used")
+	}
+	if calls != 0 {
+		t.Errorf("Expected no introspection query, got %d", calls)
+	}
+}
+
+func TestCapabilityCache_Expires(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	writeCapabilityCache("ghe.example.com", &capabilities{CheckedAt: time.Now().Add(-2 * capabilityCacheTTL)})
+	writeCapabilityCache("github.com", &capabilities{SubIssues: true, CheckedAt: time.Now()})
+
+	if readCapabilityCache("ghe.example.com") != nil {
+		t.Error("Expected a stale entry to be ignored")
+	}
+	if caps := readCapabilityCache("github.com"); caps == nil || !caps.SubIssues {
+		t.Errorf("Expected the github.com entry to be kept, got %+v", caps)
+	}
+}
+
+func TestUnsupportedCallMarksFeatureUnsupported(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			return errors.New("Field 'issueType' doesn't exist on type 'Issue'")
+		},
+	})
+
+	err := client.unsupported(errors.New("Field 'issueType' doesn't exist on type 'Issue'"), FeatureIssueTypes)
+	if !IsUnsupported(err) {
+		t.Fatalf("Expected unsupported error, got %v", err)
+	}
+	if client.Supports(FeatureIssueTypes) {
+		t.Error("Expected issue types to be marked unsupported")
+	}
+	if !client.Supports(FeatureSubIssues) {
+		t.Error("Expected other features to stay supported")
+	}
+}
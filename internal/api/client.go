@@ -1,9 +1,15 @@
 package api
 
 import (
+	"sync"
+
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
 )
 
+// DefaultHost is the hostname of GitHub.com
+const DefaultHost = "github.com"
+
 // FeatureSubIssues is the GitHub API preview header for sub-issues
 const FeatureSubIssues = "sub_issues"
 
@@ -20,6 +26,17 @@ type GraphQLClient interface {
 type Client struct {
 	gql  GraphQLClient
 	opts ClientOptions
+
+	// Capabilities probed on first use of Supports
+	capsMu            sync.Mutex
+	caps              *capabilities
+	capsProbed        bool
+	cacheCapabilities bool // persist probed capabilities across runs
+
+	// Users looked up during this run
+	usersMu sync.Mutex
+	viewer  string
+	userIDs map[string]string // login -> node ID
 }
 
 // ClientOptions configures the API client
@@ -36,7 +53,14 @@ type ClientOptions struct {
 
 // NewClient creates a new API client with default options
 func NewClient() *Client {
+	return NewClientForHost("")
+}
+
+// NewClientForHost creates a new API client with default options for a
+// GitHub Enterprise Server hostname. An empty host uses gh's default host.
+func NewClientForHost(host string) *Client {
 	return NewClientWithOptions(ClientOptions{
+		Host:             host,
 		EnableSubIssues:  true,
 		EnableIssueTypes: true,
 	})
@@ -80,8 +104,9 @@ func NewClientWithOptions(opts ClientOptions) *Client {
 	}
 
 	return &Client{
-		gql:  gql,
-		opts: opts,
+		gql:               gql,
+		opts:              opts,
+		cacheCapabilities: true,
 	}
 }
 
@@ -90,6 +115,28 @@ func NewClientWithGraphQL(gql GraphQLClient) *Client {
 	return &Client{gql: gql}
 }
 
+// Host returns the hostname the client sends requests to
+func (c *Client) Host() string {
+	if c.opts.Host != "" {
+		return c.opts.Host
+	}
+	host, _ := auth.DefaultHost()
+	return host
+}
+
+// unsupported returns an *UnsupportedError for feature when err says the
+// GraphQL schema lacks a field or input type the query uses, and err otherwise
+func (c *Client) unsupported(err error, feature string) error {
+	if err == nil {
+		return nil
+	}
+	if isMissingFromSchema(err) {
+		c.markUnsupported(feature)
+		return &UnsupportedError{Feature: feature, Host: c.Host()}
+	}
+	return err
+}
+
 // joinFeatures joins feature names with commas
 func joinFeatures(features []string) string {
 	if len(features) == 0 {
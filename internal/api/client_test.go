@@ -44,6 +44,23 @@ func TestNewClientWithOptions_CustomHost(t *testing.T) {
 	}
 }
 
+func TestNewClientForHost_Host(t *testing.T) {
+	client := NewClientForHost("github.example.com")
+
+	if got := client.Host(); got != "github.example.com" {
+		t.Errorf("Expected host github.example.com, got %q", got)
+	}
+}
+
+func TestClient_Host_DefaultsToGitHubHost(t *testing.T) {
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	if got := NewClientWithGraphQL(nil).Host(); got != DefaultHost {
+		t.Errorf("Expected %s, got %q", DefaultHost, got)
+	}
+}
+
 func TestClient_FeatureHeaders_Included(t *testing.T) {
 	// This test verifies that sub_issues feature header is configured
 	// We can't easily test the actual header without making a request,
@@ -69,6 +69,46 @@ func IsAuthError(err error) bool {
 		strings.Contains(msg, "not authenticated")
 }
 
+// UnsupportedError reports a feature the host's API does not provide, such
+// as sub-issues on GitHub Enterprise Server releases that predate them
+type UnsupportedError struct {
+	Feature string // FeatureSubIssues, FeatureIssueTypes, FeatureIterationFields, FeatureItemQuery, or FeatureIssueDependencies
+	Host    string
+}
+
+func (e *UnsupportedError) Error() string {
+	name := e.Feature
+	switch e.Feature {
+	case FeatureSubIssues:
+		name = "sub-issues"
+	case FeatureIssueTypes:
+		name = "issue types"
+	case FeatureIterationFields:
+		name = "iteration fields"
+	case FeatureItemQuery:
+		name = "project item queries"
+	case FeatureIssueDependencies:
+		name = "issue dependencies"
+	}
+	return fmt.Sprintf("%s are not supported by %s", name, e.Host)
+}
+
+// IsUnsupported reports whether err is caused by a feature the host does
+// not support
+func IsUnsupported(err error) bool {
+	var unsupported *UnsupportedError
+	return errors.As(err, &unsupported)
+}
+
+// isMissingFromSchema checks if a GraphQL error says the query uses a field,
+// argument, or input type the server's schema does not define
+func isMissingFromSchema(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "doesn't exist on type") ||
+		strings.Contains(msg, "doesn't accept argument") ||
+		strings.Contains(msg, "isn't a defined input type")
+}
+
 // WrapError wraps an API error with operation context
 func WrapError(operation, resource string, err error) error {
 	if err == nil {
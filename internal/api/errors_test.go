@@ -2,6 +2,7 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -124,3 +125,20 @@ func TestAPIError_Unwrap(t *testing.T) {
 		t.Error("Expected errors.Is to find ErrNotFound")
 	}
 }
+
+func TestIsUnsupported(t *testing.T) {
+	err := fmt.Errorf("failed to get sub-issues: %w", &UnsupportedError{Feature: FeatureSubIssues, Host: "ghe.example.com"})
+	if !IsUnsupported(err) {
+		t.Error("Expected IsUnsupported to return true for a wrapped UnsupportedError")
+	}
+	if IsUnsupported(errors.New("some other error")) || IsUnsupported(nil) {
+		t.Error("Expected IsUnsupported to return false for other errors")
+	}
+}
+
+func TestUnsupportedError_Message(t *testing.T) {
+	err := &UnsupportedError{Feature: FeatureIssueTypes, Host: "ghe.example.com"}
+	if err.Error() != "issue types are not supported by ghe.example.com" {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+}
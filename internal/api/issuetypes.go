@@ -0,0 +1,158 @@
+package api
+
+import (
+	"fmt"
+
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+// IssueType represents an organization's issue type, such as Bug or Feature
+type IssueType struct {
+	ID          string
+	Name        string
+	Description string
+	Color       string
+	IsEnabled   bool
+}
+
+// ListIssueTypes fetches the issue types defined by an organization
+func (c *Client) ListIssueTypes(owner string) ([]IssueType, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+	if !c.Supports(FeatureIssueTypes) {
+		return nil, &UnsupportedError{Feature: FeatureIssueTypes, Host: c.Host()}
+	}
+
+	var query struct {
+		Organization struct {
+			IssueTypes struct {
+				Nodes []struct {
+					ID          string
+					Name        string
+					Description string
+					Color       string
+					IsEnabled   bool
+				}
+			} `graphql:"issueTypes(first: 100)"`
+		} `graphql:"organization(login: $owner)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": graphql.String(owner),
+	}
+
+	if err := c.gql.Query("ListIssueTypes", &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to list issue types for %s: %w", owner, c.unsupported(err, FeatureIssueTypes))
+	}
+
+	var types []IssueType
+	for _, node := range query.Organization.IssueTypes.Nodes {
+		types = append(types, IssueType{
+			ID:          node.ID,
+			Name:        node.Name,
+			Description: node.Description,
+			Color:       node.Color,
+			IsEnabled:   node.IsEnabled,
+		})
+	}
+	return types, nil
+}
+
+// SetIssueType sets the type of an issue. An empty typeID clears it.
+func (c *Client) SetIssueType(issueID, typeID string) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+	if !c.Supports(FeatureIssueTypes) {
+		return &UnsupportedError{Feature: FeatureIssueTypes, Host: c.Host()}
+	}
+
+	var mutation struct {
+		UpdateIssueIssueType struct {
+			Issue struct {
+				ID string
+			}
+		} `graphql:"updateIssueIssueType(input: $input)"`
+	}
+
+	input := UpdateIssueIssueTypeInput{
+		IssueID: graphql.ID(issueID),
+	}
+	if typeID != "" {
+		id := graphql.ID(typeID)
+		input.IssueTypeID = &id
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	if err := c.gql.Mutate("UpdateIssueIssueType", &mutation, variables); err != nil {
+		return fmt.Errorf("failed to set issue type: %w", c.unsupported(err, FeatureIssueTypes))
+	}
+	return nil
+}
+
+// UpdateIssueIssueTypeInput represents the input for setting an issue's type
+type UpdateIssueIssueTypeInput struct {
+	IssueID     graphql.ID  `json:"issueId"`
+	IssueTypeID *graphql.ID `json:"issueTypeId"`
+}
+
+// hydrateIssueTypes fills in the Type of each issue. Types are fetched in a
+// separate query so hosts without issue types can still list issues; on such
+// hosts it does nothing.
+func (c *Client) hydrateIssueTypes(issues []*Issue) error {
+	if len(issues) == 0 || !c.Supports(FeatureIssueTypes) {
+		return nil
+	}
+
+	byID := make(map[string][]*Issue, len(issues))
+	var ids []graphql.ID
+	for _, issue := range issues {
+		if issue == nil || issue.ID == "" {
+			continue
+		}
+		if _, seen := byID[issue.ID]; !seen {
+			ids = append(ids, graphql.ID(issue.ID))
+		}
+		byID[issue.ID] = append(byID[issue.ID], issue)
+	}
+
+	for start := 0; start < len(ids); start += 100 {
+		end := start + 100
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var query struct {
+			Nodes []struct {
+				Issue struct {
+					ID        string
+					IssueType struct {
+						Name string
+					}
+				} `graphql:"... on Issue"`
+			} `graphql:"nodes(ids: $ids)"`
+		}
+
+		variables := map[string]interface{}{
+			"ids": ids[start:end],
+		}
+
+		if err := c.gql.Query("GetIssueTypes", &query, variables); err != nil {
+			if err := c.unsupported(err, FeatureIssueTypes); IsUnsupported(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get issue types: %w", err)
+		}
+
+		for _, node := range query.Nodes {
+			for _, issue := range byID[node.Issue.ID] {
+				issue.Type = node.Issue.IssueType.Name
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+func TestListIssueTypes_Success(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if name != "ListIssueTypes" {
+				return nil
+			}
+			if variables["owner"] != graphql.String("acme") {
+				t.Errorf("Expected owner acme, got %v", variables["owner"])
+			}
+			nodes := reflect.ValueOf(query).Elem().FieldByName("Organization").FieldByName("IssueTypes").FieldByName("Nodes")
+			values := reflect.MakeSlice(nodes.Type(), 2, 2)
+			values.Index(0).FieldByName("ID").SetString("IT_bug")
+			values.Index(0).FieldByName("Name").SetString("Bug")
+			values.Index(0).FieldByName("IsEnabled").SetBool(true)
+			values.Index(1).FieldByName("ID").SetString("IT_task")
+			values.Index(1).FieldByName("Name").SetString("Task")
+			nodes.Set(values)
+			return nil
+		},
+	})
+
+	types, err := client.ListIssueTypes("acme")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(types) != 2 || types[0].Name != "Bug" || !types[0].IsEnabled || types[1].ID != "IT_task" {
+		t.Errorf("Unexpected issue types: %+v", types)
+	}
+}
+
+func TestListIssueTypes_UnsupportedHost(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{})
+	client.caps = &capabilities{SubIssues: true}
+	client.capsProbed = true
+
+	if _, err := client.ListIssueTypes("acme"); !IsUnsupported(err) {
+		t.Errorf("Expected unsupported error, got %v", err)
+	}
+}
+
+func TestSetIssueType_Input(t *testing.T) {
+	var input UpdateIssueIssueTypeInput
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			input = variables["input"].(UpdateIssueIssueTypeInput)
+			return nil
+		},
+	})
+
+	if err := client.SetIssueType("I_1", "IT_bug"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if input.IssueID != graphql.ID("I_1") || input.IssueTypeID == nil || *input.IssueTypeID != graphql.ID("IT_bug") {
+		t.Errorf("Unexpected input: %+v", input)
+	}
+
+	if err := client.SetIssueType("I_1", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if input.IssueTypeID != nil {
+		t.Errorf("Expected an empty type to clear the issue type, got %v", *input.IssueTypeID)
+	}
+}
+
+func TestGetIssue_HydratesType(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			v := reflect.ValueOf(query).Elem()
+			switch name {
+			case "GetIssue":
+				issue := v.FieldByName("Repository").FieldByName("Issue")
+				issue.FieldByName("ID").SetString("I_1")
+				issue.FieldByName("Number").SetInt(1)
+			case "GetIssueTypes":
+				nodes := v.FieldByName("Nodes")
+				values := reflect.MakeSlice(nodes.Type(), 1, 1)
+				node := values.Index(0).FieldByName("Issue")
+				node.FieldByName("ID").SetString("I_1")
+				node.FieldByName("IssueType").FieldByName("Name").SetString("Bug")
+				nodes.Set(values)
+			}
+			return nil
+		},
+	})
+
+	issue, err := client.GetIssue("acme", "api", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if issue.Type != "Bug" {
+		t.Errorf("Expected type Bug, got %q", issue.Type)
+	}
+}
+
+func TestGetIssue_TypesMissingFromSchema(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			switch name {
+			case "GetIssue":
+				reflect.ValueOf(query).Elem().FieldByName("Repository").FieldByName("Issue").FieldByName("ID").SetString("I_1")
+			case "GetIssueTypes":
+				return errors.New("Field 'issueType' doesn't exist on type 'Issue'")
+			}
+			return nil
+		},
+	})
+
+	issue, err := client.GetIssue("acme", "api", 1)
+	if err != nil {
+		t.Fatalf("Expected the issue without a type, got error: %v", err)
+	}
+	if issue.Type != "" {
+		t.Errorf("Expected no type, got %q", issue.Type)
+	}
+	if client.Supports(FeatureIssueTypes) {
+		t.Error("Expected issue types to be marked unsupported")
+	}
+}
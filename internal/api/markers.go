@@ -0,0 +1,246 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+// Hosts without sub-issues record the parent in the child's body instead, on
+// a line of its own: "Parent: #12", or "Parent: owner/repo#12" when the
+// parent lives in another repository.
+
+// parentMarkerPattern matches a parent marker line
+var parentMarkerPattern = regexp.MustCompile(`(?m)^Parent:[ \t]*(?:([\w.-]+)/([\w.-]+))?#(\d+)[ \t]*\r?$`)
+
+// parentMarker returns the marker line linking an issue in child to its parent
+func parentMarker(parent Repository, number int, child Repository) string {
+	if strings.EqualFold(parent.Owner, child.Owner) && strings.EqualFold(parent.Name, child.Name) {
+		return fmt.Sprintf("Parent: #%d", number)
+	}
+	return fmt.Sprintf("Parent: %s/%s#%d", parent.Owner, parent.Name, number)
+}
+
+// parseParentMarker returns the parent named by the marker in body, if any.
+// A marker without a repository refers to the child's repository.
+func parseParentMarker(body string, child Repository) (Repository, int, bool) {
+	m := parentMarkerPattern.FindStringSubmatch(body)
+	if m == nil {
+		return Repository{}, 0, false
+	}
+	number, err := strconv.Atoi(m[3])
+	if err != nil {
+		return Repository{}, 0, false
+	}
+	if m[1] == "" {
+		return child, number, true
+	}
+	return Repository{Owner: m[1], Name: m[2]}, number, true
+}
+
+// withParentMarker returns body with its parent marker set to marker
+func withParentMarker(body, marker string) string {
+	if parentMarkerPattern.MatchString(body) {
+		return parentMarkerPattern.ReplaceAllLiteralString(body, marker)
+	}
+	body = strings.TrimRight(body, " \t\r\n")
+	if body == "" {
+		return marker
+	}
+	return body + "\n\n" + marker
+}
+
+// withoutParentMarker returns body with its parent marker line removed
+func withoutParentMarker(body string) string {
+	body = parentMarkerPattern.ReplaceAllLiteralString(body, "")
+	return strings.TrimRight(body, " \t\r\n")
+}
+
+// markerIssue is an issue fetched for reading or updating its parent marker
+type markerIssue struct {
+	ID         string
+	Number     int
+	Title      string
+	Body       string
+	State      string
+	URL        string `graphql:"url"`
+	Repository struct {
+		NameWithOwner string
+	}
+}
+
+func (m markerIssue) repository() Repository {
+	parts := splitRepoName(m.Repository.NameWithOwner)
+	if len(parts) != 2 {
+		return Repository{}
+	}
+	return Repository{Owner: parts[0], Name: parts[1]}
+}
+
+// getMarkerIssue fetches an issue by node ID
+func (c *Client) getMarkerIssue(id string) (*markerIssue, error) {
+	var query struct {
+		Node struct {
+			Issue markerIssue `graphql:"... on Issue"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	variables := map[string]interface{}{
+		"id": graphql.ID(id),
+	}
+
+	if err := c.gql.Query("GetIssueByID", &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", id, err)
+	}
+	return &query.Node.Issue, nil
+}
+
+// updateIssueBody replaces the body of an issue
+func (c *Client) updateIssueBody(id, body string) error {
+	var mutation struct {
+		UpdateIssue struct {
+			Issue struct {
+				ID string
+			}
+		} `graphql:"updateIssue(input: $input)"`
+	}
+
+	newBody := graphql.String(body)
+	input := UpdateIssueInput{
+		ID:   graphql.ID(id),
+		Body: &newBody,
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	if err := c.gql.Mutate("UpdateIssue", &mutation, variables); err != nil {
+		return fmt.Errorf("failed to update issue body: %w", err)
+	}
+	return nil
+}
+
+// addParentMarker links a child to its parent with a body marker
+func (c *Client) addParentMarker(parentIssueID, childIssueID string) error {
+	parent, err := c.getMarkerIssue(parentIssueID)
+	if err != nil {
+		return fmt.Errorf("failed to add sub-issue: %w", err)
+	}
+	child, err := c.getMarkerIssue(childIssueID)
+	if err != nil {
+		return fmt.Errorf("failed to add sub-issue: %w", err)
+	}
+
+	childRepo := child.repository()
+	if existing, number, ok := parseParentMarker(child.Body, childRepo); ok {
+		if number == parent.Number && strings.EqualFold(existing.Owner+"/"+existing.Name, parent.Repository.NameWithOwner) {
+			return fmt.Errorf("failed to add sub-issue: duplicate sub-issue")
+		}
+		return fmt.Errorf("failed to add sub-issue: issue may only have one parent (body names %s/%s#%d)", existing.Owner, existing.Name, number)
+	}
+
+	marker := parentMarker(parent.repository(), parent.Number, childRepo)
+	if err := c.updateIssueBody(child.ID, withParentMarker(child.Body, marker)); err != nil {
+		return fmt.Errorf("failed to add sub-issue: %w", err)
+	}
+	return nil
+}
+
+// removeParentMarker removes the body marker linking a child to its parent
+func (c *Client) removeParentMarker(parentIssueID, childIssueID string) error {
+	parent, err := c.getMarkerIssue(parentIssueID)
+	if err != nil {
+		return fmt.Errorf("failed to remove sub-issue: %w", err)
+	}
+	child, err := c.getMarkerIssue(childIssueID)
+	if err != nil {
+		return fmt.Errorf("failed to remove sub-issue: %w", err)
+	}
+
+	linked, number, ok := parseParentMarker(child.Body, child.repository())
+	if !ok || number != parent.Number || !strings.EqualFold(linked.Owner+"/"+linked.Name, parent.Repository.NameWithOwner) {
+		return fmt.Errorf("failed to remove sub-issue: #%d is not a sub-issue of #%d", child.Number, parent.Number)
+	}
+	if err := c.updateIssueBody(child.ID, withoutParentMarker(child.Body)); err != nil {
+		return fmt.Errorf("failed to remove sub-issue: %w", err)
+	}
+	return nil
+}
+
+// parentFromMarker returns the parent named in an issue's body marker, or
+// nil when there is none
+func (c *Client) parentFromMarker(owner, repo string, number int) (*Issue, error) {
+	issue, err := c.GetIssue(owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent issue for %s/%s#%d: %w", owner, repo, number, err)
+	}
+
+	parentRepo, parentNumber, ok := parseParentMarker(issue.Body, Repository{Owner: owner, Name: repo})
+	if !ok {
+		return nil, nil
+	}
+	parent, err := c.GetIssue(parentRepo.Owner, parentRepo.Name, parentNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent issue for %s/%s#%d: %w", owner, repo, number, err)
+	}
+	return parent, nil
+}
+
+// subIssuesFromMarkers returns the issues in the parent's repository whose
+// body marker names the parent. Children in other repositories are not found.
+func (c *Client) subIssuesFromMarkers(owner, repo string, number int) ([]SubIssue, error) {
+	parentRepo := Repository{Owner: owner, Name: repo}
+	var subIssues []SubIssue
+	var cursor *string
+
+	for {
+		var query struct {
+			Repository struct {
+				Issues struct {
+					Nodes    []markerIssue
+					PageInfo pageInfo
+				} `graphql:"issues(first: 100, after: $cursor)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+
+		variables := map[string]interface{}{
+			"owner":  graphql.String(owner),
+			"repo":   graphql.String(repo),
+			"cursor": (*graphql.String)(nil),
+		}
+		if cursor != nil {
+			variables["cursor"] = graphql.String(*cursor)
+		}
+
+		if err := c.gql.Query("GetSubIssuesFromMarkers", &query, variables); err != nil {
+			return nil, fmt.Errorf("failed to get sub-issues for %s/%s#%d: %w", owner, repo, number, err)
+		}
+
+		for _, node := range query.Repository.Issues.Nodes {
+			linked, n, ok := parseParentMarker(node.Body, parentRepo)
+			if !ok || n != number || !strings.EqualFold(linked.Owner, owner) || !strings.EqualFold(linked.Name, repo) {
+				continue
+			}
+			subIssues = append(subIssues, SubIssue{
+				ID:         node.ID,
+				Number:     node.Number,
+				Title:      node.Title,
+				State:      node.State,
+				URL:        node.URL,
+				Repository: parentRepo,
+			})
+		}
+
+		if !query.Repository.Issues.PageInfo.HasNextPage {
+			break
+		}
+		next := query.Repository.Issues.PageInfo.EndCursor
+		cursor = &next
+	}
+
+	return subIssues, nil
+}
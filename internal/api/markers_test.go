@@ -0,0 +1,201 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	graphql "github.com/cli/shurcooL-graphql"
+)
+
+// clientWithoutSubIssues returns a client for a host without sub-issues
+func clientWithoutSubIssues(mock *mockGraphQLClient) *Client {
+	client := NewClientWithGraphQL(mock)
+	client.caps = &capabilities{IssueTypes: true, IterationFields: true}
+	client.capsProbed = true
+	return client
+}
+
+func markerNode(id string, number int, body, repo string) markerIssue {
+	issue := markerIssue{ID: id, Number: number, Title: id, Body: body, State: "OPEN"}
+	issue.Repository.NameWithOwner = repo
+	return issue
+}
+
+func TestParseParentMarker(t *testing.T) {
+	child := Repository{Owner: "acme", Name: "api"}
+	tests := []struct {
+		name   string
+		body   string
+		repo   string
+		number int
+		ok     bool
+	}{
+		{"same repository", "Some text\n\nParent: #12", "acme/api", 12, true},
+		{"other repository", "Parent: acme/web#3\nmore", "acme/web", 3, true},
+		{"no marker", "Mentions Parent: #12 inline", "", 0, false},
+		{"empty body", "", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, number, ok := parseParentMarker(tt.body, child)
+			if ok != tt.ok || number != tt.number {
+				t.Fatalf("parseParentMarker(%q) = %v, %d, %v", tt.body, repo, number, ok)
+			}
+			if ok && repo.Owner+"/"+repo.Name != tt.repo {
+				t.Errorf("Expected repository %s, got %s/%s", tt.repo, repo.Owner, repo.Name)
+			}
+		})
+	}
+}
+
+func TestParentMarkerEditing(t *testing.T) {
+	if got := withParentMarker("Body text\n", "Parent: #4"); got != "Body text\n\nParent: #4" {
+		t.Errorf("Unexpected body after adding marker: %q", got)
+	}
+	if got := withParentMarker("Body\n\nParent: #4", "Parent: #5"); got != "Body\n\nParent: #5" {
+		t.Errorf("Unexpected body after replacing marker: %q", got)
+	}
+	if got := withoutParentMarker("Body\n\nParent: acme/web#4\n"); got != "Body" {
+		t.Errorf("Unexpected body after removing marker: %q", got)
+	}
+	if got := parentMarker(Repository{Owner: "acme", Name: "web"}, 4, Repository{Owner: "acme", Name: "api"}); got != "Parent: acme/web#4" {
+		t.Errorf("Unexpected cross-repository marker: %q", got)
+	}
+}
+
+func TestGetSubIssues_FallsBackToMarkers(t *testing.T) {
+	client := clientWithoutSubIssues(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if name != "GetSubIssuesFromMarkers" {
+				t.Fatalf("Unexpected query %s", name)
+			}
+			nodes := reflect.ValueOf(query).Elem().FieldByName("Repository").FieldByName("Issues").FieldByName("Nodes")
+			nodes.Set(reflect.ValueOf([]markerIssue{
+				markerNode("I_2", 2, "Task\n\nParent: #1", "acme/api"),
+				markerNode("I_3", 3, "Parent: #9", "acme/api"),
+				markerNode("I_4", 4, "Parent: acme/api#1", "acme/api"),
+				markerNode("I_5", 5, "No parent", "acme/api"),
+			}))
+			return nil
+		},
+	})
+
+	subIssues, err := client.GetSubIssues("acme", "api", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(subIssues) != 2 || subIssues[0].Number != 2 || subIssues[1].Number != 4 {
+		t.Errorf("Expected #2 and #4, got %+v", subIssues)
+	}
+}
+
+func TestGetParentIssue_FallsBackToMarker(t *testing.T) {
+	client := clientWithoutSubIssues(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if name != "GetIssue" {
+				t.Fatalf("Unexpected query %s", name)
+			}
+			issue := reflect.ValueOf(query).Elem().FieldByName("Repository").FieldByName("Issue")
+			number := int(variables["number"].(graphql.Int))
+			issue.FieldByName("Number").SetInt(int64(number))
+			if number == 2 {
+				issue.FieldByName("Body").SetString("Task\n\nParent: #1")
+			} else {
+				issue.FieldByName("Title").SetString("Epic")
+			}
+			return nil
+		},
+	})
+
+	parent, err := client.GetParentIssue("acme", "api", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parent == nil || parent.Number != 1 || parent.Title != "Epic" {
+		t.Errorf("Expected parent #1, got %+v", parent)
+	}
+}
+
+func TestAddSubIssue_FallsBackToMarker(t *testing.T) {
+	var updated UpdateIssueInput
+	client := clientWithoutSubIssues(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			node := reflect.ValueOf(query).Elem().FieldByName("Node").FieldByName("Issue")
+			if variables["id"] == graphql.ID("I_parent") {
+				node.Set(reflect.ValueOf(markerNode("I_parent", 1, "", "acme/web")))
+			} else {
+				node.Set(reflect.ValueOf(markerNode("I_child", 2, "Task", "acme/api")))
+			}
+			return nil
+		},
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			updated = variables["input"].(UpdateIssueInput)
+			return nil
+		},
+	})
+
+	if err := client.AddSubIssue("I_parent", "I_child"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.ID != graphql.ID("I_child") || updated.Body == nil || string(*updated.Body) != "Task\n\nParent: acme/web#1" {
+		t.Errorf("Unexpected update: %+v", updated)
+	}
+}
+
+func TestAddSubIssue_MarkerFallbackRejectsSecondParent(t *testing.T) {
+	client := clientWithoutSubIssues(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			node := reflect.ValueOf(query).Elem().FieldByName("Node").FieldByName("Issue")
+			if variables["id"] == graphql.ID("I_parent") {
+				node.Set(reflect.ValueOf(markerNode("I_parent", 1, "", "acme/api")))
+			} else {
+				node.Set(reflect.ValueOf(markerNode("I_child", 2, "Parent: #7", "acme/api")))
+			}
+			return nil
+		},
+	})
+
+	err := client.AddSubIssue("I_parent", "I_child")
+	if err == nil {
+		t.Fatal("Expected an error for an issue that already has a parent")
+	}
+}
+
+func TestRemoveSubIssue_FallsBackToMarker(t *testing.T) {
+	var updated UpdateIssueInput
+	client := clientWithoutSubIssues(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			node := reflect.ValueOf(query).Elem().FieldByName("Node").FieldByName("Issue")
+			if variables["id"] == graphql.ID("I_parent") {
+				node.Set(reflect.ValueOf(markerNode("I_parent", 1, "", "acme/api")))
+			} else {
+				node.Set(reflect.ValueOf(markerNode("I_child", 2, "Task\n\nParent: #1", "acme/api")))
+			}
+			return nil
+		},
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			updated = variables["input"].(UpdateIssueInput)
+			return nil
+		},
+	})
+
+	if err := client.RemoveSubIssue("I_parent", "I_child"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.Body == nil || string(*updated.Body) != "Task" {
+		t.Errorf("Expected the marker to be removed, got %+v", updated)
+	}
+}
+
+func TestCreateIterationField_UnsupportedHost(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{})
+	client.opts.Host = "ghe.example.com"
+	client.caps = &capabilities{SubIssues: true}
+	client.capsProbed = true
+
+	_, err := client.CreateIterationField("PVT_1", "Sprint", "2026-01-05", 14, 3)
+	if !IsUnsupported(err) {
+		t.Errorf("Expected unsupported error, got %v", err)
+	}
+}
@@ -1,7 +1,11 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	graphql "github.com/cli/shurcooL-graphql"
 )
@@ -157,6 +161,10 @@ func (c *Client) SetProjectItemField(projectID, itemID, fieldName, value string)
 		return c.setTextField(projectID, itemID, field.ID, value)
 	case "NUMBER":
 		return c.setNumberField(projectID, itemID, field.ID, value)
+	case "DATE":
+		return c.setDateField(projectID, itemID, field.ID, value)
+	case "ITERATION":
+		return c.setIterationField(projectID, itemID, field, value)
 	default:
 		return fmt.Errorf("unsupported field type: %s", field.DataType)
 	}
@@ -232,6 +240,43 @@ func (c *Client) setTextField(projectID, itemID, fieldID, value string) error {
 }
 
 func (c *Client) setNumberField(projectID, itemID, fieldID, value string) error {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", value)
+	}
+	n := graphql.Float(number)
+	return c.updateFieldValue(projectID, itemID, fieldID, ProjectV2FieldValue{Number: &n}, "number")
+}
+
+func (c *Client) setDateField(projectID, itemID, fieldID, value string) error {
+	value = strings.TrimSpace(value)
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", value)
+	}
+	return c.updateFieldValue(projectID, itemID, fieldID, ProjectV2FieldValue{Date: graphql.String(value)}, "date")
+}
+
+// setIterationField sets an iteration field to the iteration with the given
+// title or ID
+func (c *Client) setIterationField(projectID, itemID string, field *ProjectField, value string) error {
+	var iterationID string
+	if field.Iteration != nil {
+		for _, it := range field.Iteration.Iterations {
+			if strings.EqualFold(it.Title, value) || it.ID == value {
+				iterationID = it.ID
+				break
+			}
+		}
+	}
+	if iterationID == "" {
+		return fmt.Errorf("iteration %q not found for field %q", value, field.Name)
+	}
+	return c.updateFieldValue(projectID, itemID, field.ID, ProjectV2FieldValue{IterationId: graphql.String(iterationID)}, "iteration")
+}
+
+// updateFieldValue runs updateProjectV2ItemFieldValue; kind names the field
+// type in errors
+func (c *Client) updateFieldValue(projectID, itemID, fieldID string, value ProjectV2FieldValue, kind string) error {
 	var mutation struct {
 		UpdateProjectV2ItemFieldValue struct {
 			ClientMutationID string `graphql:"clientMutationId"`
@@ -242,9 +287,7 @@ func (c *Client) setNumberField(projectID, itemID, fieldID, value string) error
 		ProjectID: graphql.ID(projectID),
 		ItemID:    graphql.ID(itemID),
 		FieldID:   graphql.ID(fieldID),
-		Value: ProjectV2FieldValue{
-			Number: graphql.Float(0), // TODO: parse value to float
-		},
+		Value:     value,
 	}
 
 	variables := map[string]interface{}{
@@ -253,7 +296,52 @@ func (c *Client) setNumberField(projectID, itemID, fieldID, value string) error
 
 	err := c.gql.Mutate("UpdateProjectV2ItemFieldValue", &mutation, variables)
 	if err != nil {
-		return fmt.Errorf("failed to set number field value: %w", err)
+		return fmt.Errorf("failed to set %s field value: %w", kind, err)
+	}
+
+	return nil
+}
+
+// ClearProjectItemField removes a field's value from a project item
+func (c *Client) ClearProjectItemField(projectID, itemID, fieldName string) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	fields, err := c.GetProjectFields(projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project fields: %w", err)
+	}
+
+	var fieldID string
+	for _, field := range fields {
+		if field.Name == fieldName {
+			fieldID = field.ID
+			break
+		}
+	}
+	if fieldID == "" {
+		return fmt.Errorf("field %q not found in project", fieldName)
+	}
+
+	var mutation struct {
+		ClearProjectV2ItemFieldValue struct {
+			ClientMutationID string `graphql:"clientMutationId"`
+		} `graphql:"clearProjectV2ItemFieldValue(input: $input)"`
+	}
+
+	input := ClearProjectV2ItemFieldValueInput{
+		ProjectID: graphql.ID(projectID),
+		ItemID:    graphql.ID(itemID),
+		FieldID:   graphql.ID(fieldID),
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	if err := c.gql.Mutate("ClearProjectV2ItemFieldValue", &mutation, variables); err != nil {
+		return fmt.Errorf("failed to clear field value: %w", err)
 	}
 
 	return nil
@@ -267,10 +355,17 @@ type UpdateProjectV2ItemFieldValueInput struct {
 	Value     ProjectV2FieldValue `json:"value"`
 }
 
+// ClearProjectV2ItemFieldValueInput represents the input for clearing a field value
+type ClearProjectV2ItemFieldValueInput struct {
+	ProjectID graphql.ID `json:"projectId"`
+	ItemID    graphql.ID `json:"itemId"`
+	FieldID   graphql.ID `json:"fieldId"`
+}
+
 // ProjectV2FieldValue represents a field value for a project item
 type ProjectV2FieldValue struct {
 	Text                 graphql.String `json:"text,omitempty"`
-	Number               graphql.Float  `json:"number,omitempty"`
+	Number               *graphql.Float `json:"number,omitempty"`
 	Date                 graphql.String `json:"date,omitempty"`
 	SingleSelectOptionId graphql.String `json:"singleSelectOptionId,omitempty"`
 	IterationId          graphql.String `json:"iterationId,omitempty"`
@@ -298,12 +393,17 @@ func (c *Client) getRepositoryID(owner, repo string) (string, error) {
 	return query.Repository.ID, nil
 }
 
-// AddSubIssue links a child issue as a sub-issue of a parent issue
+// AddSubIssue links a child issue as a sub-issue of a parent issue. On hosts
+// without sub-issues it adds a "Parent: #N" marker to the child's body.
 func (c *Client) AddSubIssue(parentIssueID, childIssueID string) error {
 	if c.gql == nil {
 		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
 	}
 
+	if !c.Supports(FeatureSubIssues) {
+		return c.addParentMarker(parentIssueID, childIssueID)
+	}
+
 	var mutation struct {
 		AddSubIssue struct {
 			Issue struct {
@@ -326,7 +426,7 @@ func (c *Client) AddSubIssue(parentIssueID, childIssueID string) error {
 
 	err := c.gql.Mutate("AddSubIssue", &mutation, variables)
 	if err != nil {
-		return fmt.Errorf("failed to add sub-issue: %w", err)
+		return fmt.Errorf("failed to add sub-issue: %w", c.unsupported(err, FeatureSubIssues))
 	}
 
 	return nil
@@ -338,12 +438,17 @@ type AddSubIssueInput struct {
 	SubIssueID graphql.ID `json:"subIssueId"`
 }
 
-// RemoveSubIssue removes a child issue from its parent issue
+// RemoveSubIssue removes a child issue from its parent issue. On hosts
+// without sub-issues it removes the child's "Parent: #N" body marker.
 func (c *Client) RemoveSubIssue(parentIssueID, childIssueID string) error {
 	if c.gql == nil {
 		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
 	}
 
+	if !c.Supports(FeatureSubIssues) {
+		return c.removeParentMarker(parentIssueID, childIssueID)
+	}
+
 	var mutation struct {
 		RemoveSubIssue struct {
 			Issue struct {
@@ -366,7 +471,7 @@ func (c *Client) RemoveSubIssue(parentIssueID, childIssueID string) error {
 
 	err := c.gql.Mutate("RemoveSubIssue", &mutation, variables)
 	if err != nil {
-		return fmt.Errorf("failed to remove sub-issue: %w", err)
+		return fmt.Errorf("failed to remove sub-issue: %w", c.unsupported(err, FeatureSubIssues))
 	}
 
 	return nil
@@ -378,6 +483,45 @@ type RemoveSubIssueInput struct {
 	SubIssueID graphql.ID `json:"subIssueId"`
 }
 
+// AddBlockedBy marks an issue as blocked by another issue
+func (c *Client) AddBlockedBy(issueID, blockingIssueID string) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+	if !c.Supports(FeatureIssueDependencies) {
+		return &UnsupportedError{Feature: FeatureIssueDependencies, Host: c.Host()}
+	}
+
+	var mutation struct {
+		AddBlockedBy struct {
+			Issue struct {
+				ID string
+			}
+		} `graphql:"addBlockedBy(input: $input)"`
+	}
+
+	input := AddBlockedByInput{
+		IssueID:         graphql.ID(issueID),
+		BlockingIssueID: graphql.ID(blockingIssueID),
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	if err := c.gql.Mutate("AddBlockedBy", &mutation, variables); err != nil {
+		return fmt.Errorf("failed to add dependency: %w", c.unsupported(err, FeatureIssueDependencies))
+	}
+
+	return nil
+}
+
+// AddBlockedByInput represents the input for marking an issue as blocked
+type AddBlockedByInput struct {
+	IssueID         graphql.ID `json:"issueId"`
+	BlockingIssueID graphql.ID `json:"blockingIssueId"`
+}
+
 // AddLabelToIssue adds a label to an issue
 func (c *Client) AddLabelToIssue(issueID, labelName string) error {
 	if c.gql == nil {
@@ -417,8 +561,21 @@ func (c *Client) getLabelID(owner, repo, labelName string) (string, error) {
 	return query.Repository.Label.ID, nil
 }
 
-// getUserID gets a user's ID from their login
-func (c *Client) getUserID(login string) (string, error) {
+// GetUserID gets a user's ID from their login, failing with an error that
+// wraps ErrNotFound when no such user exists. IDs are remembered for the
+// rest of the run.
+func (c *Client) GetUserID(login string) (string, error) {
+	if c.gql == nil {
+		return "", fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	c.usersMu.Lock()
+	id, ok := c.userIDs[strings.ToLower(login)]
+	c.usersMu.Unlock()
+	if ok {
+		return id, nil
+	}
+
 	var query struct {
 		User struct {
 			ID string
@@ -430,14 +587,21 @@ func (c *Client) getUserID(login string) (string, error) {
 	}
 
 	err := c.gql.Query("GetUserID", &query, variables)
-	if err != nil {
+	if err != nil && !IsNotFound(err) {
 		return "", fmt.Errorf("failed to get user ID for %s: %w", login, err)
 	}
 
-	if query.User.ID == "" {
-		return "", fmt.Errorf("user %q not found", login)
+	if err != nil || query.User.ID == "" {
+		return "", fmt.Errorf("user %q: %w", login, ErrNotFound)
 	}
 
+	c.usersMu.Lock()
+	if c.userIDs == nil {
+		c.userIDs = make(map[string]string)
+	}
+	c.userIDs[strings.ToLower(login)] = query.User.ID
+	c.usersMu.Unlock()
+
 	return query.User.ID, nil
 }
 
@@ -504,7 +668,7 @@ func (c *Client) CreateIssueWithOptions(owner, repo, title, body string, labels,
 	var assigneeIDs []graphql.ID
 	if len(assignees) > 0 {
 		for _, login := range assignees {
-			userID, err := c.getUserID(login)
+			userID, err := c.GetUserID(login)
 			if err != nil {
 				// Skip users that don't exist
 				continue
@@ -578,3 +742,700 @@ func (c *Client) CreateIssueWithOptions(owner, repo, title, body string, labels,
 		},
 	}, nil
 }
+
+// IssueEdit describes changes to an issue's title, body, milestone, labels,
+// and assignees. Nil fields are left unchanged.
+type IssueEdit struct {
+	Title *string
+	Body  *string
+	// Milestone is the milestone's title or number; empty removes it
+	Milestone       *string
+	AddLabels       []string
+	RemoveLabels    []string
+	AddAssignees    []string
+	RemoveAssignees []string
+}
+
+// IsEmpty reports whether the edit changes nothing
+func (e IssueEdit) IsEmpty() bool {
+	return e.Title == nil && e.Body == nil && e.Milestone == nil &&
+		len(e.AddLabels) == 0 && len(e.RemoveLabels) == 0 &&
+		len(e.AddAssignees) == 0 && len(e.RemoveAssignees) == 0
+}
+
+// EditIssue applies edit to issue: the title, body, and milestone in one
+// updateIssue mutation, then the labels and assignees added and removed.
+// Labels, assignees, and milestones are looked up in the issue's repository
+// first, so an unknown name changes nothing.
+func (c *Client) EditIssue(issue *Issue, edit IssueEdit) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	owner, repo := issue.Repository.Owner, issue.Repository.Name
+	labelIDs := func(names []string) ([]graphql.ID, error) {
+		var ids []graphql.ID
+		for _, name := range names {
+			id, err := c.getLabelID(owner, repo, name)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, graphql.ID(id))
+		}
+		return ids, nil
+	}
+	userIDs := func(logins []string) ([]graphql.ID, error) {
+		var ids []graphql.ID
+		for _, login := range logins {
+			id, err := c.GetUserID(login)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, graphql.ID(id))
+		}
+		return ids, nil
+	}
+
+	addLabels, err := labelIDs(edit.AddLabels)
+	if err != nil {
+		return err
+	}
+	removeLabels, err := labelIDs(edit.RemoveLabels)
+	if err != nil {
+		return err
+	}
+	addAssignees, err := userIDs(edit.AddAssignees)
+	if err != nil {
+		return err
+	}
+	removeAssignees, err := userIDs(edit.RemoveAssignees)
+	if err != nil {
+		return err
+	}
+
+	input := UpdateIssueInput{ID: graphql.ID(issue.ID)}
+	if edit.Title != nil {
+		title := graphql.String(*edit.Title)
+		input.Title = &title
+	}
+	if edit.Body != nil {
+		body := graphql.String(*edit.Body)
+		input.Body = &body
+	}
+	if edit.Milestone != nil {
+		if *edit.Milestone == "" {
+			input.ClearMilestone = true
+		} else {
+			id, err := c.getMilestoneID(owner, repo, *edit.Milestone)
+			if err != nil {
+				return err
+			}
+			milestoneID := graphql.ID(id)
+			input.MilestoneID = &milestoneID
+		}
+	}
+
+	if edit.Title != nil || edit.Body != nil || edit.Milestone != nil {
+		var mutation struct {
+			UpdateIssue struct {
+				Issue struct {
+					ID string
+				}
+			} `graphql:"updateIssue(input: $input)"`
+		}
+		variables := map[string]interface{}{
+			"input": input,
+		}
+		if err := c.gql.Mutate("UpdateIssue", &mutation, variables); err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
+	}
+
+	if len(addLabels) > 0 {
+		var mutation struct {
+			AddLabelsToLabelable struct {
+				ClientMutationID string `graphql:"clientMutationId"`
+			} `graphql:"addLabelsToLabelable(input: $input)"`
+		}
+		variables := map[string]interface{}{
+			"input": AddLabelsToLabelableInput{LabelableID: graphql.ID(issue.ID), LabelIDs: addLabels},
+		}
+		if err := c.gql.Mutate("AddLabelsToLabelable", &mutation, variables); err != nil {
+			return fmt.Errorf("failed to add labels: %w", err)
+		}
+	}
+
+	if len(removeLabels) > 0 {
+		var mutation struct {
+			RemoveLabelsFromLabelable struct {
+				ClientMutationID string `graphql:"clientMutationId"`
+			} `graphql:"removeLabelsFromLabelable(input: $input)"`
+		}
+		variables := map[string]interface{}{
+			"input": RemoveLabelsFromLabelableInput{LabelableID: graphql.ID(issue.ID), LabelIDs: removeLabels},
+		}
+		if err := c.gql.Mutate("RemoveLabelsFromLabelable", &mutation, variables); err != nil {
+			return fmt.Errorf("failed to remove labels: %w", err)
+		}
+	}
+
+	if len(addAssignees) > 0 {
+		var mutation struct {
+			AddAssigneesToAssignable struct {
+				ClientMutationID string `graphql:"clientMutationId"`
+			} `graphql:"addAssigneesToAssignable(input: $input)"`
+		}
+		variables := map[string]interface{}{
+			"input": AddAssigneesToAssignableInput{AssignableID: graphql.ID(issue.ID), AssigneeIDs: addAssignees},
+		}
+		if err := c.gql.Mutate("AddAssigneesToAssignable", &mutation, variables); err != nil {
+			return fmt.Errorf("failed to add assignees: %w", err)
+		}
+	}
+
+	if len(removeAssignees) > 0 {
+		var mutation struct {
+			RemoveAssigneesFromAssignable struct {
+				ClientMutationID string `graphql:"clientMutationId"`
+			} `graphql:"removeAssigneesFromAssignable(input: $input)"`
+		}
+		variables := map[string]interface{}{
+			"input": RemoveAssigneesFromAssignableInput{AssignableID: graphql.ID(issue.ID), AssigneeIDs: removeAssignees},
+		}
+		if err := c.gql.Mutate("RemoveAssigneesFromAssignable", &mutation, variables); err != nil {
+			return fmt.Errorf("failed to remove assignees: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateIssueInput represents the input for updating an issue
+type UpdateIssueInput struct {
+	ID          graphql.ID      `json:"id"`
+	Title       *graphql.String `json:"title,omitempty"`
+	Body        *graphql.String `json:"body,omitempty"`
+	MilestoneID *graphql.ID     `json:"milestoneId,omitempty"`
+
+	// ClearMilestone removes the milestone by sending a null milestoneId
+	ClearMilestone bool `json:"-"`
+}
+
+// MarshalJSON sends milestoneId as null when the milestone is cleared
+func (in UpdateIssueInput) MarshalJSON() ([]byte, error) {
+	type plain UpdateIssueInput
+	data, err := json.Marshal(plain(in))
+	if err != nil || !in.ClearMilestone {
+		return data, err
+	}
+	return append(data[:len(data)-1], `,"milestoneId":null}`...), nil
+}
+
+// AddLabelsToLabelableInput represents the input for adding labels to an issue
+type AddLabelsToLabelableInput struct {
+	LabelableID graphql.ID   `json:"labelableId"`
+	LabelIDs    []graphql.ID `json:"labelIds"`
+}
+
+// RemoveLabelsFromLabelableInput represents the input for removing labels
+// from an issue
+type RemoveLabelsFromLabelableInput struct {
+	LabelableID graphql.ID   `json:"labelableId"`
+	LabelIDs    []graphql.ID `json:"labelIds"`
+}
+
+// AddAssigneesToAssignableInput represents the input for assigning users to
+// an issue
+type AddAssigneesToAssignableInput struct {
+	AssignableID graphql.ID   `json:"assignableId"`
+	AssigneeIDs  []graphql.ID `json:"assigneeIds"`
+}
+
+// RemoveAssigneesFromAssignableInput represents the input for unassigning
+// users from an issue
+type RemoveAssigneesFromAssignableInput struct {
+	AssignableID graphql.ID   `json:"assignableId"`
+	AssigneeIDs  []graphql.ID `json:"assigneeIds"`
+}
+
+// Reasons an issue is closed, sent as the closeIssue stateReason
+const (
+	CloseReasonCompleted  = "COMPLETED"
+	CloseReasonNotPlanned = "NOT_PLANNED"
+	CloseReasonDuplicate  = "DUPLICATE"
+)
+
+// CloseIssue closes an issue with reason, one of the CloseReason constants
+// or empty for GitHub's default. duplicateIssueID names the issue a
+// duplicate duplicates; it is only sent with CloseReasonDuplicate.
+func (c *Client) CloseIssue(issueID, reason, duplicateIssueID string) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var mutation struct {
+		CloseIssue struct {
+			Issue struct {
+				ID string
+			}
+		} `graphql:"closeIssue(input: $input)"`
+	}
+
+	input := CloseIssueInput{
+		IssueID:     graphql.ID(issueID),
+		StateReason: reason,
+	}
+	if reason == CloseReasonDuplicate && duplicateIssueID != "" {
+		id := graphql.ID(duplicateIssueID)
+		input.DuplicateIssueID = &id
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	if err := c.gql.Mutate("CloseIssue", &mutation, variables); err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+	return nil
+}
+
+// CloseIssueInput represents the input for closing an issue
+type CloseIssueInput struct {
+	IssueID          graphql.ID  `json:"issueId"`
+	StateReason      string      `json:"stateReason,omitempty"`
+	DuplicateIssueID *graphql.ID `json:"duplicateIssueId,omitempty"`
+}
+
+// ReopenIssue reopens a closed issue
+func (c *Client) ReopenIssue(issueID string) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var mutation struct {
+		ReopenIssue struct {
+			Issue struct {
+				ID string
+			}
+		} `graphql:"reopenIssue(input: $input)"`
+	}
+
+	variables := map[string]interface{}{
+		"input": ReopenIssueInput{IssueID: graphql.ID(issueID)},
+	}
+
+	if err := c.gql.Mutate("ReopenIssue", &mutation, variables); err != nil {
+		return fmt.Errorf("failed to reopen issue: %w", err)
+	}
+	return nil
+}
+
+// ReopenIssueInput represents the input for reopening an issue
+type ReopenIssueInput struct {
+	IssueID graphql.ID `json:"issueId"`
+}
+
+// CreateProject creates a new GitHub Project V2 owned by the given user or organization ID
+func (c *Client) CreateProject(ownerID, title string) (*Project, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var mutation struct {
+		CreateProjectV2 struct {
+			ProjectV2 struct {
+				ID     string
+				Number int
+				Title  string
+				URL    string `graphql:"url"`
+			}
+		} `graphql:"createProjectV2(input: $input)"`
+	}
+
+	input := CreateProjectV2Input{
+		OwnerID: graphql.ID(ownerID),
+		Title:   graphql.String(title),
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err := c.gql.Mutate("CreateProjectV2", &mutation, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return &Project{
+		ID:     mutation.CreateProjectV2.ProjectV2.ID,
+		Number: mutation.CreateProjectV2.ProjectV2.Number,
+		Title:  mutation.CreateProjectV2.ProjectV2.Title,
+		URL:    mutation.CreateProjectV2.ProjectV2.URL,
+	}, nil
+}
+
+// CreateProjectV2Input represents the input for creating a project
+type CreateProjectV2Input struct {
+	OwnerID graphql.ID     `json:"ownerId"`
+	Title   graphql.String `json:"title"`
+}
+
+// UpdateProjectDetails sets the short description, readme, and visibility of a project
+func (c *Client) UpdateProjectDetails(projectID, shortDescription, readme string, public bool) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var mutation struct {
+		UpdateProjectV2 struct {
+			ProjectV2 struct {
+				ID string
+			}
+		} `graphql:"updateProjectV2(input: $input)"`
+	}
+
+	isPublic := graphql.Boolean(public)
+	input := UpdateProjectV2Input{
+		ProjectID: graphql.ID(projectID),
+		Public:    &isPublic,
+	}
+	if shortDescription != "" {
+		desc := graphql.String(shortDescription)
+		input.ShortDescription = &desc
+	}
+	if readme != "" {
+		r := graphql.String(readme)
+		input.Readme = &r
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err := c.gql.Mutate("UpdateProjectV2", &mutation, variables)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProjectV2Input represents the input for updating project settings
+type UpdateProjectV2Input struct {
+	ProjectID        graphql.ID       `json:"projectId"`
+	ShortDescription *graphql.String  `json:"shortDescription,omitempty"`
+	Readme           *graphql.String  `json:"readme,omitempty"`
+	Public           *graphql.Boolean `json:"public,omitempty"`
+}
+
+// projectFieldConfiguration selects the members of the ProjectV2FieldConfiguration union
+type projectFieldConfiguration struct {
+	TypeName       string `graphql:"__typename"`
+	ProjectV2Field struct {
+		ID       string
+		Name     string
+		DataType string
+	} `graphql:"... on ProjectV2Field"`
+	ProjectV2SingleSelectField struct {
+		ID       string
+		Name     string
+		DataType string
+		Options  []struct {
+			ID   string
+			Name string
+		}
+	} `graphql:"... on ProjectV2SingleSelectField"`
+	ProjectV2IterationField struct {
+		ID       string
+		Name     string
+		DataType string
+	} `graphql:"... on ProjectV2IterationField"`
+}
+
+// toProjectField converts a field configuration union into a ProjectField
+func (f *projectFieldConfiguration) toProjectField() *ProjectField {
+	switch f.TypeName {
+	case "ProjectV2SingleSelectField":
+		field := &ProjectField{
+			ID:       f.ProjectV2SingleSelectField.ID,
+			Name:     f.ProjectV2SingleSelectField.Name,
+			DataType: f.ProjectV2SingleSelectField.DataType,
+		}
+		for _, opt := range f.ProjectV2SingleSelectField.Options {
+			field.Options = append(field.Options, FieldOption{ID: opt.ID, Name: opt.Name})
+		}
+		return field
+	case "ProjectV2IterationField":
+		return &ProjectField{
+			ID:       f.ProjectV2IterationField.ID,
+			Name:     f.ProjectV2IterationField.Name,
+			DataType: f.ProjectV2IterationField.DataType,
+		}
+	default:
+		return &ProjectField{
+			ID:       f.ProjectV2Field.ID,
+			Name:     f.ProjectV2Field.Name,
+			DataType: f.ProjectV2Field.DataType,
+		}
+	}
+}
+
+// CreateProjectField creates a custom field on a project.
+// dataType is one of TEXT, NUMBER, DATE, or SINGLE_SELECT; options are used for SINGLE_SELECT only.
+func (c *Client) CreateProjectField(projectID, name, dataType string, options []FieldOption) (*ProjectField, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var mutation struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field projectFieldConfiguration `graphql:"projectV2Field"`
+		} `graphql:"createProjectV2Field(input: $input)"`
+	}
+
+	input := CreateProjectV2FieldInput{
+		ProjectID: graphql.ID(projectID),
+		DataType:  graphql.String(dataType),
+		Name:      graphql.String(name),
+	}
+	if dataType == "SINGLE_SELECT" {
+		opts := singleSelectOptionInputs(options)
+		input.SingleSelectOptions = &opts
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err := c.gql.Mutate("CreateProjectV2Field", &mutation, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create field %q: %w", name, err)
+	}
+
+	return mutation.CreateProjectV2Field.ProjectV2Field.toProjectField(), nil
+}
+
+// CreateIterationField creates an iteration field with count iterations of
+// duration days each, the first starting on startDate (YYYY-MM-DD).
+func (c *Client) CreateIterationField(projectID, name, startDate string, duration, count int) (*ProjectField, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	if !c.Supports(FeatureIterationFields) {
+		return nil, fmt.Errorf("failed to create iteration field %q: %w", name, &UnsupportedError{Feature: FeatureIterationFields, Host: c.Host()})
+	}
+
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid iteration start date %q: %w", startDate, err)
+	}
+
+	iterations := make([]ProjectV2Iteration, 0, count)
+	for i := 0; i < count; i++ {
+		iterations = append(iterations, ProjectV2Iteration{
+			Title:     graphql.String(fmt.Sprintf("%s %d", name, i+1)),
+			StartDate: graphql.String(start.AddDate(0, 0, i*duration).Format("2006-01-02")),
+			Duration:  graphql.Int(duration),
+		})
+	}
+
+	var mutation struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field projectFieldConfiguration `graphql:"projectV2Field"`
+		} `graphql:"createProjectV2Field(input: $input)"`
+	}
+
+	input := CreateProjectV2FieldInput{
+		ProjectID: graphql.ID(projectID),
+		DataType:  graphql.String("ITERATION"),
+		Name:      graphql.String(name),
+		IterationConfiguration: &ProjectV2IterationFieldConfigurationInput{
+			StartDate:  graphql.String(startDate),
+			Duration:   graphql.Int(duration),
+			Iterations: iterations,
+		},
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err = c.gql.Mutate("CreateProjectV2Field", &mutation, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iteration field %q: %w", name, c.unsupported(err, FeatureIterationFields))
+	}
+
+	return mutation.CreateProjectV2Field.ProjectV2Field.toProjectField(), nil
+}
+
+// UpdateSingleSelectOptions replaces the options of a single-select field.
+// Options with an ID update the existing option in place, so items keep
+// their value when it is renamed or moved; options without one are created,
+// and existing options left out are deleted.
+func (c *Client) UpdateSingleSelectOptions(fieldID string, options []FieldOption) (*ProjectField, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var mutation struct {
+		UpdateProjectV2Field struct {
+			ProjectV2Field projectFieldConfiguration `graphql:"projectV2Field"`
+		} `graphql:"updateProjectV2Field(input: $input)"`
+	}
+
+	opts := singleSelectOptionInputs(options)
+	input := UpdateProjectV2FieldInput{
+		FieldID:             graphql.ID(fieldID),
+		SingleSelectOptions: &opts,
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err := c.gql.Mutate("UpdateProjectV2Field", &mutation, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update field options: %w", err)
+	}
+
+	return mutation.UpdateProjectV2Field.ProjectV2Field.toProjectField(), nil
+}
+
+// singleSelectOptionInputs converts options to mutation inputs, defaulting the color to GRAY
+// and keeping the IDs of existing options
+func singleSelectOptionInputs(options []FieldOption) []ProjectV2SingleSelectFieldOptionInput {
+	inputs := make([]ProjectV2SingleSelectFieldOptionInput, 0, len(options))
+	for _, opt := range options {
+		color := strings.ToUpper(opt.Color)
+		if color == "" {
+			color = "GRAY"
+		}
+		input := ProjectV2SingleSelectFieldOptionInput{
+			Name:        graphql.String(opt.Name),
+			Color:       graphql.String(color),
+			Description: graphql.String(opt.Description),
+		}
+		if opt.ID != "" {
+			id := graphql.ID(opt.ID)
+			input.ID = &id
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+// CreateProjectV2FieldInput represents the input for creating a project field
+type CreateProjectV2FieldInput struct {
+	ProjectID              graphql.ID                                 `json:"projectId"`
+	DataType               graphql.String                             `json:"dataType"`
+	Name                   graphql.String                             `json:"name"`
+	SingleSelectOptions    *[]ProjectV2SingleSelectFieldOptionInput   `json:"singleSelectOptions,omitempty"`
+	IterationConfiguration *ProjectV2IterationFieldConfigurationInput `json:"iterationConfiguration,omitempty"`
+}
+
+// UpdateProjectV2FieldInput represents the input for updating a project field
+type UpdateProjectV2FieldInput struct {
+	FieldID             graphql.ID                               `json:"fieldId"`
+	Name                *graphql.String                          `json:"name,omitempty"`
+	SingleSelectOptions *[]ProjectV2SingleSelectFieldOptionInput `json:"singleSelectOptions,omitempty"`
+}
+
+// ProjectV2SingleSelectFieldOptionInput represents a single-select option in a field mutation
+type ProjectV2SingleSelectFieldOptionInput struct {
+	ID          *graphql.ID    `json:"id,omitempty"`
+	Name        graphql.String `json:"name"`
+	Color       graphql.String `json:"color"`
+	Description graphql.String `json:"description"`
+}
+
+// ProjectV2IterationFieldConfigurationInput represents the iteration settings of a new field
+type ProjectV2IterationFieldConfigurationInput struct {
+	StartDate  graphql.String       `json:"startDate"`
+	Duration   graphql.Int          `json:"duration"`
+	Iterations []ProjectV2Iteration `json:"iterations"`
+}
+
+// ProjectV2Iteration represents a single iteration in a field mutation
+type ProjectV2Iteration struct {
+	Title     graphql.String `json:"title"`
+	StartDate graphql.String `json:"startDate"`
+	Duration  graphql.Int    `json:"duration"`
+}
+
+// LinkProjectToRepository links a project to a repository
+func (c *Client) LinkProjectToRepository(projectID, owner, repo string) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	repoID, err := c.getRepositoryID(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	var mutation struct {
+		LinkProjectV2ToRepository struct {
+			Repository struct {
+				ID string
+			}
+		} `graphql:"linkProjectV2ToRepository(input: $input)"`
+	}
+
+	input := LinkProjectV2ToRepositoryInput{
+		ProjectID:    graphql.ID(projectID),
+		RepositoryID: graphql.ID(repoID),
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err = c.gql.Mutate("LinkProjectV2ToRepository", &mutation, variables)
+	if err != nil {
+		return fmt.Errorf("failed to link project to %s/%s: %w", owner, repo, err)
+	}
+
+	return nil
+}
+
+// LinkProjectV2ToRepositoryInput represents the input for linking a project to a repository
+type LinkProjectV2ToRepositoryInput struct {
+	ProjectID    graphql.ID `json:"projectId"`
+	RepositoryID graphql.ID `json:"repositoryId"`
+}
+
+// DeleteProjectField deletes a field from a project.
+// Values of the field are removed from all project items.
+func (c *Client) DeleteProjectField(fieldID string) error {
+	if c.gql == nil {
+		return fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var mutation struct {
+		DeleteProjectV2Field struct {
+			ClientMutationID string `graphql:"clientMutationId"`
+		} `graphql:"deleteProjectV2Field(input: $input)"`
+	}
+
+	input := DeleteProjectV2FieldInput{
+		FieldID: graphql.ID(fieldID),
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err := c.gql.Mutate("DeleteProjectV2Field", &mutation, variables)
+	if err != nil {
+		return fmt.Errorf("failed to delete field: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteProjectV2FieldInput represents the input for deleting a project field
+type DeleteProjectV2FieldInput struct {
+	FieldID graphql.ID `json:"fieldId"`
+}
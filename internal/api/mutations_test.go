@@ -1,10 +1,13 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"reflect"
 	"strings"
 	"testing"
+
+	graphql "github.com/cli/shurcooL-graphql"
 )
 
 // ============================================================================
@@ -153,6 +156,15 @@ func TestRemoveSubIssue_NilClient(t *testing.T) {
 	}
 }
 
+func TestAddBlockedBy_NilClient(t *testing.T) {
+	client := &Client{gql: nil}
+
+	err := client.AddBlockedBy("issue-id", "blocking-id")
+	if err == nil || !strings.Contains(err.Error(), "GraphQL client not initialized") {
+		t.Errorf("Expected 'GraphQL client not initialized' error, got: %v", err)
+	}
+}
+
 func TestAddLabelToIssue_NilClient(t *testing.T) {
 	client := &Client{gql: nil}
 
@@ -263,10 +275,10 @@ func TestSetProjectItemField_NumberField_Success(t *testing.T) {
 }
 
 func TestSetProjectItemField_UnsupportedFieldType(t *testing.T) {
-	mock := createMockWithField("Date", "DATE", nil)
+	mock := createMockWithField("Assignees", "ASSIGNEES", nil)
 
 	client := NewClientWithGraphQL(mock)
-	err := client.SetProjectItemField("proj-id", "item-id", "Date", "2024-01-15")
+	err := client.SetProjectItemField("proj-id", "item-id", "Assignees", "octocat")
 
 	if err == nil {
 		t.Fatal("Expected error for unsupported field type")
@@ -276,6 +288,74 @@ func TestSetProjectItemField_UnsupportedFieldType(t *testing.T) {
 	}
 }
 
+func TestSetProjectItemField_Values(t *testing.T) {
+	fieldsJSON := `{"node": {"projectV2": {"fields": {"nodes": [
+		{"typeName": "ProjectV2Field", "projectV2Field": {"id": "F_POINTS", "name": "Points", "dataType": "NUMBER"}},
+		{"typeName": "ProjectV2Field", "projectV2Field": {"id": "F_DUE", "name": "Due", "dataType": "DATE"}},
+		{"typeName": "ProjectV2IterationField", "projectV2IterationField": {"id": "F_SPRINT", "name": "Sprint", "dataType": "ITERATION",
+			"configuration": {"iterations": [{"id": "IT_1", "title": "Sprint 1"}, {"id": "IT_2", "title": "Sprint 2"}]}}}
+	]}}}}`
+	var got []ProjectV2FieldValue
+	mock := &mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			return json.Unmarshal([]byte(fieldsJSON), query)
+		},
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			got = append(got, variables["input"].(UpdateProjectV2ItemFieldValueInput).Value)
+			return nil
+		},
+	}
+	client := NewClientWithGraphQL(mock)
+
+	for _, set := range [][2]string{{"Points", "0"}, {"Points", "2.5"}, {"Due", "2024-01-15"}, {"Sprint", "sprint 2"}} {
+		if err := client.SetProjectItemField("proj-id", "item-id", set[0], set[1]); err != nil {
+			t.Fatalf("%s=%s: unexpected error: %v", set[0], set[1], err)
+		}
+	}
+	if len(got) != 4 || got[0].Number == nil || *got[0].Number != 0 || got[1].Number == nil || *got[1].Number != 2.5 ||
+		got[2].Date != "2024-01-15" || got[3].IterationId != "IT_2" {
+		t.Errorf("Unexpected values %+v", got)
+	}
+
+	for _, set := range [][3]string{
+		{"Points", "lots", `invalid number "lots"`},
+		{"Due", "15/01/2024", `invalid date "15/01/2024"`},
+		{"Sprint", "Sprint 9", `iteration "Sprint 9" not found`},
+	} {
+		err := client.SetProjectItemField("proj-id", "item-id", set[0], set[1])
+		if err == nil || !strings.Contains(err.Error(), set[2]) {
+			t.Errorf("%s=%s: expected %q, got %v", set[0], set[1], set[2], err)
+		}
+	}
+}
+
+func TestClearProjectItemField(t *testing.T) {
+	var input ClearProjectV2ItemFieldValueInput
+	mock := createMockWithField("Due", "DATE", nil)
+	mock.mutateFunc = func(name string, mutation interface{}, variables map[string]interface{}) error {
+		if name != "ClearProjectV2ItemFieldValue" {
+			t.Errorf("Expected mutation name 'ClearProjectV2ItemFieldValue', got '%s'", name)
+		}
+		input = variables["input"].(ClearProjectV2ItemFieldValueInput)
+		return nil
+	}
+
+	client := NewClientWithGraphQL(mock)
+	if err := client.ClearProjectItemField("proj-id", "item-id", "Due"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if input.ItemID != "item-id" || input.FieldID != "field-123" {
+		t.Errorf("Unexpected input %+v", input)
+	}
+
+	if err := client.ClearProjectItemField("proj-id", "item-id", "Missing"); err == nil || !strings.Contains(err.Error(), `field "Missing" not found`) {
+		t.Errorf("Expected 'field not found' error, got: %v", err)
+	}
+	if err := (&Client{}).ClearProjectItemField("proj-id", "item-id", "Due"); err == nil {
+		t.Error("Expected error when gql is nil")
+	}
+}
+
 func TestSetProjectItemField_MutationError(t *testing.T) {
 	mock := createMockWithField("Notes", "TEXT", nil)
 	mock.mutateFunc = func(name string, mutation interface{}, variables map[string]interface{}) error {
@@ -338,6 +418,41 @@ func TestAddIssueToProject_MutationError(t *testing.T) {
 // AddSubIssue Tests with Mocking
 // ============================================================================
 
+func TestAddBlockedBy_Success(t *testing.T) {
+	var input AddBlockedByInput
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			if name != "AddBlockedBy" {
+				t.Errorf("Expected mutation name 'AddBlockedBy', got '%s'", name)
+			}
+			input = variables["input"].(AddBlockedByInput)
+			return nil
+		},
+	})
+
+	if err := client.AddBlockedBy("I_2", "I_1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if input.IssueID != "I_2" || input.BlockingIssueID != "I_1" {
+		t.Errorf("Unexpected input %+v", input)
+	}
+}
+
+func TestAddBlockedBy_Unsupported(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			t.Error("Expected no mutation on a host without dependencies")
+			return nil
+		},
+	})
+	client.caps = &capabilities{SubIssues: true}
+	client.capsProbed = true
+
+	if err := client.AddBlockedBy("I_2", "I_1"); !IsUnsupported(err) {
+		t.Errorf("Expected an unsupported error, got %v", err)
+	}
+}
+
 func TestAddSubIssue_Success(t *testing.T) {
 	mock := &mockGraphQLClient{
 		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
@@ -374,6 +489,24 @@ func TestAddSubIssue_MutationError(t *testing.T) {
 	}
 }
 
+func TestAddSubIssue_UnsupportedHost(t *testing.T) {
+	mock := &mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			return errors.New("Field 'addSubIssue' doesn't exist on type 'Mutation'")
+		},
+	}
+
+	client := &Client{gql: mock, opts: ClientOptions{Host: "github.example.com"}}
+	err := client.AddSubIssue("parent-id", "child-id")
+
+	if !IsUnsupported(err) {
+		t.Fatalf("Expected unsupported error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "sub-issues are not supported by github.example.com") {
+		t.Errorf("Expected host in error, got: %v", err)
+	}
+}
+
 // ============================================================================
 // RemoveSubIssue Tests with Mocking
 // ============================================================================
@@ -651,9 +784,10 @@ func TestProjectV2FieldValue_AllFieldTypes(t *testing.T) {
 		t.Errorf("Expected Text 'text', got '%s'", textValue.Text)
 	}
 
-	numberValue := ProjectV2FieldValue{Number: 42.5}
-	if numberValue.Number != 42.5 {
-		t.Errorf("Expected Number 42.5, got %f", numberValue.Number)
+	number := graphql.Float(42.5)
+	numberValue := ProjectV2FieldValue{Number: &number}
+	if *numberValue.Number != 42.5 {
+		t.Errorf("Expected Number 42.5, got %f", *numberValue.Number)
 	}
 
 	dateValue := ProjectV2FieldValue{Date: "2024-01-15"}
@@ -731,3 +865,322 @@ func TestCreateIssueInput_OptionalFields(t *testing.T) {
 	}
 	_ = milestoneID // Verify it can be assigned
 }
+
+// ============================================================================
+// Project Creation Tests
+// ============================================================================
+
+func TestCreateProject_NilClient(t *testing.T) {
+	client := &Client{gql: nil}
+
+	_, err := client.CreateProject("owner-id", "Board")
+	if err == nil {
+		t.Fatal("Expected error when gql is nil")
+	}
+	if !strings.Contains(err.Error(), "GraphQL client not initialized") {
+		t.Errorf("Expected 'GraphQL client not initialized' error, got: %v", err)
+	}
+}
+
+func TestCreateProjectField_NilClient(t *testing.T) {
+	client := &Client{gql: nil}
+
+	_, err := client.CreateProjectField("proj-id", "Priority", "SINGLE_SELECT", nil)
+	if err == nil {
+		t.Fatal("Expected error when gql is nil")
+	}
+	if !strings.Contains(err.Error(), "GraphQL client not initialized") {
+		t.Errorf("Expected 'GraphQL client not initialized' error, got: %v", err)
+	}
+}
+
+func TestLinkProjectToRepository_NilClient(t *testing.T) {
+	client := &Client{gql: nil}
+
+	err := client.LinkProjectToRepository("proj-id", "owner", "repo")
+	if err == nil {
+		t.Fatal("Expected error when gql is nil")
+	}
+	if !strings.Contains(err.Error(), "GraphQL client not initialized") {
+		t.Errorf("Expected 'GraphQL client not initialized' error, got: %v", err)
+	}
+}
+
+func TestCreateProjectField_SendsOptions(t *testing.T) {
+	var captured CreateProjectV2FieldInput
+	mock := &mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			if name != "CreateProjectV2Field" {
+				t.Errorf("Expected mutation CreateProjectV2Field, got %s", name)
+			}
+			captured = variables["input"].(CreateProjectV2FieldInput)
+			return nil
+		},
+	}
+	client := NewClientWithGraphQL(mock)
+
+	_, err := client.CreateProjectField("proj-id", "Priority", "SINGLE_SELECT", []FieldOption{
+		{Name: "High", Color: "red"},
+		{Name: "Low"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if captured.SingleSelectOptions == nil || len(*captured.SingleSelectOptions) != 2 {
+		t.Fatalf("Expected 2 options, got %+v", captured.SingleSelectOptions)
+	}
+	opts := *captured.SingleSelectOptions
+	if opts[0].Color != "RED" {
+		t.Errorf("Expected color RED, got %s", opts[0].Color)
+	}
+	if opts[1].Color != "GRAY" {
+		t.Errorf("Expected default color GRAY, got %s", opts[1].Color)
+	}
+}
+
+func TestUpdateSingleSelectOptions_KeepsOptionIDs(t *testing.T) {
+	var captured UpdateProjectV2FieldInput
+	mock := &mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			if name != "UpdateProjectV2Field" {
+				t.Errorf("Expected mutation UpdateProjectV2Field, got %s", name)
+			}
+			captured = variables["input"].(UpdateProjectV2FieldInput)
+			return nil
+		},
+	}
+	client := NewClientWithGraphQL(mock)
+
+	_, err := client.UpdateSingleSelectOptions("field-id", []FieldOption{
+		{ID: "opt-1", Name: "In Progress"},
+		{Name: "Blocked"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if captured.SingleSelectOptions == nil || len(*captured.SingleSelectOptions) != 2 {
+		t.Fatalf("Expected 2 options, got %+v", captured.SingleSelectOptions)
+	}
+	opts := *captured.SingleSelectOptions
+	if opts[0].ID == nil || *opts[0].ID != "opt-1" {
+		t.Errorf("Expected the existing option to keep its ID, got %v", opts[0].ID)
+	}
+	if opts[1].ID != nil {
+		t.Errorf("Expected no ID for a new option, got %v", *opts[1].ID)
+	}
+}
+
+func TestCreateIterationField_GeneratesIterations(t *testing.T) {
+	var captured CreateProjectV2FieldInput
+	mock := &mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			captured = variables["input"].(CreateProjectV2FieldInput)
+			return nil
+		},
+	}
+	client := NewClientWithGraphQL(mock)
+
+	_, err := client.CreateIterationField("proj-id", "Sprint", "2025-01-06", 14, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cfg := captured.IterationConfiguration
+	if cfg == nil {
+		t.Fatal("Expected iteration configuration")
+	}
+	if len(cfg.Iterations) != 3 {
+		t.Fatalf("Expected 3 iterations, got %d", len(cfg.Iterations))
+	}
+	if cfg.Iterations[1].StartDate != "2025-01-20" {
+		t.Errorf("Expected second iteration to start 2025-01-20, got %s", cfg.Iterations[1].StartDate)
+	}
+}
+
+func TestDeleteProjectField_NilClient(t *testing.T) {
+	client := &Client{gql: nil}
+
+	err := client.DeleteProjectField("field-id")
+	if err == nil {
+		t.Fatal("Expected error when gql is nil")
+	}
+	if !strings.Contains(err.Error(), "GraphQL client not initialized") {
+		t.Errorf("Expected 'GraphQL client not initialized' error, got: %v", err)
+	}
+}
+
+func TestDeleteProjectField_SendsFieldID(t *testing.T) {
+	var captured DeleteProjectV2FieldInput
+	mock := &mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			if name != "DeleteProjectV2Field" {
+				t.Errorf("Expected mutation DeleteProjectV2Field, got %s", name)
+			}
+			captured = variables["input"].(DeleteProjectV2FieldInput)
+			return nil
+		},
+	}
+	client := NewClientWithGraphQL(mock)
+
+	if err := client.DeleteProjectField("field-123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if captured.FieldID != "field-123" {
+		t.Errorf("Expected field ID field-123, got %v", captured.FieldID)
+	}
+}
+
+// ============================================================================
+// EditIssue Tests
+// ============================================================================
+
+func TestEditIssue_NilClient(t *testing.T) {
+	client := &Client{}
+	title := "New"
+	if err := client.EditIssue(&Issue{ID: "I_1"}, IssueEdit{Title: &title}); err == nil {
+		t.Error("Expected error when gql is nil")
+	}
+}
+
+// editIssueMock answers label and user lookups, and records mutations
+func editIssueMock(mutations *[]string, inputs map[string]interface{}) *mockGraphQLClient {
+	return &mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			switch name {
+			case "GetLabelID":
+				if variables["labelName"] == graphql.String("missing") {
+					return nil
+				}
+				return json.Unmarshal([]byte(`{"repository": {"label": {"id": "L_`+string(variables["labelName"].(graphql.String))+`"}}}`), query)
+			case "GetUserID":
+				return json.Unmarshal([]byte(`{"user": {"id": "U_`+string(variables["login"].(graphql.String))+`"}}`), query)
+			}
+			return nil
+		},
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			*mutations = append(*mutations, name)
+			inputs[name] = variables["input"]
+			return nil
+		},
+	}
+}
+
+func TestEditIssue_Mutations(t *testing.T) {
+	var mutations []string
+	inputs := make(map[string]interface{})
+	client := NewClientWithGraphQL(editIssueMock(&mutations, inputs))
+
+	title, milestone := "New title", ""
+	err := client.EditIssue(&Issue{ID: "I_1", Repository: Repository{Owner: "acme", Name: "api"}}, IssueEdit{
+		Title:           &title,
+		Milestone:       &milestone,
+		AddLabels:       []string{"bug"},
+		RemoveLabels:    []string{"docs"},
+		RemoveAssignees: []string{"bob"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"UpdateIssue", "AddLabelsToLabelable", "RemoveLabelsFromLabelable", "RemoveAssigneesFromAssignable"}
+	if !reflect.DeepEqual(mutations, want) {
+		t.Errorf("Expected mutations %v, got %v", want, mutations)
+	}
+	update := inputs["UpdateIssue"].(UpdateIssueInput)
+	if update.Title == nil || *update.Title != "New title" || update.Body != nil || !update.ClearMilestone {
+		t.Errorf("Unexpected update input %+v", update)
+	}
+	if added := inputs["AddLabelsToLabelable"].(AddLabelsToLabelableInput); !reflect.DeepEqual(added.LabelIDs, []graphql.ID{"L_bug"}) {
+		t.Errorf("Unexpected labels %+v", added)
+	}
+	if removed := inputs["RemoveAssigneesFromAssignable"].(RemoveAssigneesFromAssignableInput); removed.AssignableID != "I_1" || !reflect.DeepEqual(removed.AssigneeIDs, []graphql.ID{"U_bob"}) {
+		t.Errorf("Unexpected assignees %+v", removed)
+	}
+}
+
+func TestEditIssue_UnknownLabelChangesNothing(t *testing.T) {
+	var mutations []string
+	client := NewClientWithGraphQL(editIssueMock(&mutations, make(map[string]interface{})))
+
+	title := "New title"
+	err := client.EditIssue(&Issue{ID: "I_1"}, IssueEdit{Title: &title, AddLabels: []string{"missing"}})
+	if err == nil || !strings.Contains(err.Error(), `label "missing" not found`) {
+		t.Errorf("Expected a label error, got %v", err)
+	}
+	if len(mutations) != 0 {
+		t.Errorf("Expected no mutations, got %v", mutations)
+	}
+}
+
+func TestUpdateIssueInput_ClearMilestone(t *testing.T) {
+	data, err := json.Marshal(UpdateIssueInput{ID: "I_1", ClearMilestone: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"id":"I_1","milestoneId":null}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+
+	data, _ = json.Marshal(UpdateIssueInput{ID: "I_1"})
+	if string(data) != `{"id":"I_1"}` {
+		t.Errorf("Expected the milestone to be left out, got %s", data)
+	}
+}
+
+// ============================================================================
+// CloseIssue and ReopenIssue Tests
+// ============================================================================
+
+func TestCloseIssue_NilClient(t *testing.T) {
+	client := &Client{}
+	if err := client.CloseIssue("I_1", CloseReasonCompleted, ""); err == nil {
+		t.Error("Expected error when gql is nil")
+	}
+	if err := client.ReopenIssue("I_1"); err == nil {
+		t.Error("Expected error when gql is nil")
+	}
+}
+
+func TestCloseIssue_Input(t *testing.T) {
+	var inputs []CloseIssueInput
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			if name != "CloseIssue" {
+				t.Errorf("Expected mutation name 'CloseIssue', got '%s'", name)
+			}
+			inputs = append(inputs, variables["input"].(CloseIssueInput))
+			return nil
+		},
+	})
+
+	if err := client.CloseIssue("I_1", CloseReasonDuplicate, "I_2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.CloseIssue("I_1", CloseReasonNotPlanned, "I_2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if inputs[0].StateReason != "DUPLICATE" || inputs[0].DuplicateIssueID == nil || *inputs[0].DuplicateIssueID != "I_2" {
+		t.Errorf("Unexpected duplicate input %+v", inputs[0])
+	}
+	if inputs[1].StateReason != "NOT_PLANNED" || inputs[1].DuplicateIssueID != nil {
+		t.Errorf("Expected no duplicate for not planned, got %+v", inputs[1])
+	}
+}
+
+func TestReopenIssue_MutationError(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		mutateFunc: func(name string, mutation interface{}, variables map[string]interface{}) error {
+			if input := variables["input"].(ReopenIssueInput); input.IssueID != "I_1" {
+				t.Errorf("Unexpected input %+v", input)
+			}
+			return errors.New("mutation failed")
+		},
+	})
+
+	if err := client.ReopenIssue("I_1"); err == nil || !strings.Contains(err.Error(), "failed to reopen issue") {
+		t.Errorf("Expected a reopen error, got %v", err)
+	}
+}
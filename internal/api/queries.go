@@ -2,6 +2,10 @@ package api
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 
 	graphql "github.com/cli/shurcooL-graphql"
 )
@@ -123,10 +127,28 @@ func (c *Client) GetProjectFields(projectID string) ([]ProjectField, error) {
 							Name     string
 							DataType string
 							Options  []struct {
-								ID   string
-								Name string
+								ID          string
+								Name        string
+								Color       string
+								Description string
 							}
 						} `graphql:"... on ProjectV2SingleSelectField"`
+						// Iteration fields have a schedule
+						ProjectV2IterationField struct {
+							ID            string
+							Name          string
+							DataType      string
+							Configuration struct {
+								Duration   int
+								StartDay   int
+								Iterations []struct {
+									ID        string
+									Title     string
+									StartDate string
+									Duration  int
+								}
+							}
+						} `graphql:"... on ProjectV2IterationField"`
 					}
 				} `graphql:"fields(first: 50)"`
 			} `graphql:"... on ProjectV2"`
@@ -153,8 +175,27 @@ func (c *Client) GetProjectFields(projectID string) ([]ProjectField, error) {
 			field.DataType = node.ProjectV2SingleSelectField.DataType
 			for _, opt := range node.ProjectV2SingleSelectField.Options {
 				field.Options = append(field.Options, FieldOption{
-					ID:   opt.ID,
-					Name: opt.Name,
+					ID:          opt.ID,
+					Name:        opt.Name,
+					Color:       opt.Color,
+					Description: opt.Description,
+				})
+			}
+		case "ProjectV2IterationField":
+			iter := node.ProjectV2IterationField
+			field.ID = iter.ID
+			field.Name = iter.Name
+			field.DataType = iter.DataType
+			field.Iteration = &IterationConfig{
+				Duration: iter.Configuration.Duration,
+				StartDay: iter.Configuration.StartDay,
+			}
+			for _, it := range iter.Configuration.Iterations {
+				field.Iteration.Iterations = append(field.Iteration.Iterations, Iteration{
+					ID:        it.ID,
+					Title:     it.Title,
+					StartDate: it.StartDate,
+					Duration:  it.Duration,
 				})
 			}
 		case "ProjectV2Field":
@@ -162,7 +203,6 @@ func (c *Client) GetProjectFields(projectID string) ([]ProjectField, error) {
 			field.Name = node.ProjectV2Field.Name
 			field.DataType = node.ProjectV2Field.DataType
 		default:
-			// Skip iteration/other field types for now
 			continue
 		}
 
@@ -172,6 +212,114 @@ func (c *Client) GetProjectFields(projectID string) ([]ProjectField, error) {
 	return fields, nil
 }
 
+// GetProjectDetails fetches the description, readme, and visibility of a project
+func (c *Client) GetProjectDetails(projectID string) (*ProjectDetails, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				ShortDescription string
+				Readme           string
+				Public           bool
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $projectId)"`
+	}
+
+	variables := map[string]interface{}{
+		"projectId": graphql.ID(projectID),
+	}
+
+	err := c.gql.Query("GetProjectDetails", &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project details: %w", err)
+	}
+
+	return &ProjectDetails{
+		ShortDescription: query.Node.ProjectV2.ShortDescription,
+		Readme:           query.Node.ProjectV2.Readme,
+		Public:           query.Node.ProjectV2.Public,
+	}, nil
+}
+
+// GetProjectViews fetches the saved views of a project with their layout,
+// filter, visible fields, grouping, and sorting
+func (c *Client) GetProjectViews(projectID string) ([]ProjectView, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Views struct {
+					Nodes []struct {
+						Name   string
+						Number int
+						Layout string
+						Filter string
+						Fields struct {
+							Nodes []projectFieldConfiguration
+						} `graphql:"fields(first: 50)"`
+						GroupByFields struct {
+							Nodes []projectFieldConfiguration
+						} `graphql:"groupByFields(first: 5)"`
+						VerticalGroupByFields struct {
+							Nodes []projectFieldConfiguration
+						} `graphql:"verticalGroupByFields(first: 5)"`
+						SortByFields struct {
+							Nodes []struct {
+								Direction string
+								Field     projectFieldConfiguration
+							}
+						} `graphql:"sortByFields(first: 5)"`
+					}
+				} `graphql:"views(first: 50)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $projectId)"`
+	}
+
+	variables := map[string]interface{}{
+		"projectId": graphql.ID(projectID),
+	}
+
+	err := c.gql.Query("GetProjectViews", &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project views: %w", err)
+	}
+
+	var views []ProjectView
+	for _, node := range query.Node.ProjectV2.Views.Nodes {
+		view := ProjectView{
+			Name:   node.Name,
+			Number: node.Number,
+			Layout: node.Layout,
+			Filter: node.Filter,
+		}
+		for _, f := range node.Fields.Nodes {
+			view.Fields = append(view.Fields, f.toProjectField().Name)
+		}
+		// A board's columns are its vertical grouping
+		for _, f := range node.VerticalGroupByFields.Nodes {
+			view.GroupBy = append(view.GroupBy, f.toProjectField().Name)
+		}
+		for _, f := range node.GroupByFields.Nodes {
+			view.GroupBy = append(view.GroupBy, f.toProjectField().Name)
+		}
+		for _, sort := range node.SortByFields.Nodes {
+			view.SortBy = append(view.SortBy, ProjectViewSort{
+				Field:      sort.Field.toProjectField().Name,
+				Descending: sort.Direction == "DESC",
+			})
+		}
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
 // GetIssue fetches an issue by repository and number
 func (c *Client) GetIssue(owner, repo string, number int) (*Issue, error) {
 	if c.gql == nil {
@@ -245,12 +393,181 @@ func (c *Client) GetIssue(owner, repo string, number int) (*Issue, error) {
 		issue.Milestone = &Milestone{Title: query.Repository.Issue.Milestone.Title}
 	}
 
+	if err := c.hydrateIssueTypes([]*Issue{issue}); err != nil {
+		return nil, err
+	}
+
 	return issue, nil
 }
 
-// ProjectItemsFilter allows filtering project items
+// Issue details fetched with project items only when named in
+// ProjectItemsFilter.Include
+const (
+	DetailLabels    = "labels"
+	DetailMilestone = "milestone"
+	DetailAuthor    = "author"
+	DetailDates     = "dates" // CreatedAt, UpdatedAt, and ClosedAt
+	DetailComments  = "comments"
+	DetailBody      = "body"
+)
+
+// ProjectItemsFilter allows filtering project items.
+//
+// Repositories are always filtered. FieldValues, Assignee, and Label are sent
+// to the host as a ProjectV2 items query when it supports FeatureItemQuery;
+// on other hosts the items are returned unfiltered by them, with the labels
+// those filters need, and callers filter the items themselves. Search is
+// never sent, because the host's free-text match differs from a substring
+// of the title or body: the body is fetched and callers match it. Filter is
+// a filter string as typed on the board; only the host can apply it.
 type ProjectItemsFilter struct {
-	Repository string // Filter by repository (owner/repo format)
+	Repository   string            // Filter by repository (owner/repo format)
+	Repositories []string          // Filter by any of these repositories: owner/repo, an owner, or a wildcard such as owner/api-*
+	FieldValues  map[string]string // Filter by project field values, such as "Status": "In progress"
+	Assignee     string            // Filter by assignee login; comma-separated logins match any of them
+	Label        string            // Filter by label name
+	Search       string            // Filter by text in the title or body, matched by the caller
+	Filter       string            // Filter by a project filter string, such as "status:Todo -label:bug"
+	Include      []string          // Issue details to fetch, such as DetailLabels; others are left empty
+}
+
+// includes reports whether detail is one of the filter's Include details
+func (f *ProjectItemsFilter) includes(detail string) bool {
+	if f == nil {
+		return false
+	}
+	for _, d := range f.Include {
+		if d == detail {
+			return true
+		}
+	}
+	return false
+}
+
+// detailVariables returns the variables selecting which issue details a
+// project items query fetches. The body needed to match Search is always
+// fetched, and without a host-side query so are the labels to filter by
+// Label.
+func (f *ProjectItemsFilter) detailVariables(hostFiltered bool) map[string]interface{} {
+	labels := f.includes(DetailLabels)
+	body := f.includes(DetailBody)
+	if f != nil {
+		labels = labels || (!hostFiltered && f.Label != "")
+		body = body || f.Search != ""
+	}
+	return map[string]interface{}{
+		"withLabels":    graphql.Boolean(labels),
+		"withMilestone": graphql.Boolean(f.includes(DetailMilestone)),
+		"withAuthor":    graphql.Boolean(f.includes(DetailAuthor)),
+		"withDates":     graphql.Boolean(f.includes(DetailDates)),
+		"withComments":  graphql.Boolean(f.includes(DetailComments)),
+		"withBody":      graphql.Boolean(body),
+	}
+}
+
+// Query returns the filter in the ProjectV2 items query syntax used by the
+// project's web UI, such as `is:issue status:"In progress" label:bug`, or
+// "" when there is nothing for the host to filter. Repositories are only
+// included when none is an owner or a wildcard.
+func (f *ProjectItemsFilter) Query() string {
+	if f == nil {
+		return ""
+	}
+
+	var terms []string
+	fields := make([]string, 0, len(f.FieldValues))
+	for field := range f.FieldValues {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if value := f.FieldValues[field]; value != "" {
+			key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(field)), " ", "-")
+			terms = append(terms, key+":"+queryValue(value))
+		}
+	}
+	if f.Assignee != "" {
+		var logins []string
+		for _, login := range strings.Split(f.Assignee, ",") {
+			if login = strings.TrimSpace(login); login != "" {
+				logins = append(logins, queryValue(login))
+			}
+		}
+		terms = append(terms, "assignee:"+strings.Join(logins, ","))
+	}
+	if f.Label != "" {
+		terms = append(terms, "label:"+queryValue(f.Label))
+	}
+	if filter := strings.TrimSpace(f.Filter); filter != "" {
+		terms = append(terms, filter)
+	}
+	if repos := f.exactRepositories(); len(repos) > 0 && len(terms) > 0 {
+		terms = append(terms, "repo:"+strings.Join(repos, ","))
+	}
+	if len(terms) == 0 {
+		return ""
+	}
+	return "is:issue " + strings.Join(terms, " ")
+}
+
+// exactRepositories returns the filter's repositories when each is an exact
+// owner/repo, and nil otherwise
+func (f *ProjectItemsFilter) exactRepositories() []string {
+	var repos []string
+	if f.Repository != "" {
+		repos = append(repos, f.Repository)
+	}
+	repos = append(repos, f.Repositories...)
+	for _, repo := range repos {
+		owner, name, ok := strings.Cut(repo, "/")
+		if !ok || owner == "" || name == "" || strings.ContainsAny(repo, "*?[") {
+			return nil
+		}
+	}
+	return repos
+}
+
+// queryValue quotes a value for an items query when it has spaces or
+// characters with a meaning in the query syntax
+func queryValue(value string) string {
+	if strings.ContainsAny(value, " \t:,\"") {
+		return `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+	return value
+}
+
+// Matches reports whether item is in one of the filter's repositories.
+// Items whose repository is unknown always match.
+func (f *ProjectItemsFilter) Matches(item ProjectItem) bool {
+	if f == nil || (f.Repository == "" && len(f.Repositories) == 0) {
+		return true
+	}
+	if item.Issue == nil || item.Issue.Repository.Owner == "" {
+		return true
+	}
+
+	name := item.Issue.Repository.Owner + "/" + item.Issue.Repository.Name
+	if f.Repository != "" && MatchRepository(f.Repository, name) {
+		return true
+	}
+	for _, pattern := range f.Repositories {
+		if MatchRepository(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchRepository reports whether the owner/repo name matches pattern,
+// ignoring case. A pattern without a slash matches every repository of that
+// owner, and * and ? match within the owner or repository name.
+func MatchRepository(pattern, name string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if !strings.Contains(pattern, "/") {
+		pattern += "/*"
+	}
+	matched, err := path.Match(pattern, strings.ToLower(name))
+	return err == nil && matched
 }
 
 // GetProjectItems fetches all items from a project with their field values.
@@ -260,27 +577,30 @@ func (c *Client) GetProjectItems(projectID string, filter *ProjectItemsFilter) (
 		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
 	}
 
+	query := filter.Query()
+	if query != "" && !c.Supports(FeatureItemQuery) {
+		query = ""
+	}
+
 	var allItems []ProjectItem
 	var cursor *string
 
 	for {
-		items, pageInfo, err := c.getProjectItemsPage(projectID, cursor)
+		items, pageInfo, err := c.getProjectItemsPage(projectID, cursor, query, filter.detailVariables(query != ""))
 		if err != nil {
+			if query != "" && IsUnsupported(c.unsupported(err, FeatureItemQuery)) {
+				// The host has no items query; fetch every item instead
+				query, allItems, cursor = "", nil, nil
+				continue
+			}
 			return nil, err
 		}
 
 		// Filter and process items from this page
 		for _, item := range items {
-			// Apply repository filter if specified
-			if filter != nil && filter.Repository != "" {
-				if item.Issue != nil && item.Issue.Repository.Owner != "" {
-					repoName := item.Issue.Repository.Owner + "/" + item.Issue.Repository.Name
-					if repoName != filter.Repository {
-						continue
-					}
-				}
+			if filter.Matches(item) {
+				allItems = append(allItems, item)
 			}
-			allItems = append(allItems, item)
 		}
 
 		// Check if there are more pages
@@ -290,6 +610,14 @@ func (c *Client) GetProjectItems(projectID string, filter *ProjectItemsFilter) (
 		cursor = &pageInfo.EndCursor
 	}
 
+	issues := make([]*Issue, 0, len(allItems))
+	for _, item := range allItems {
+		issues = append(issues, item.Issue)
+	}
+	if err := c.hydrateIssueTypes(issues); err != nil {
+		return nil, err
+	}
+
 	return allItems, nil
 }
 
@@ -299,80 +627,150 @@ type pageInfo struct {
 	EndCursor   string
 }
 
-// getProjectItemsPage fetches a single page of project items
-func (c *Client) getProjectItemsPage(projectID string, cursor *string) ([]ProjectItem, pageInfo, error) {
-	var query struct {
-		Node struct {
-			ProjectV2 struct {
-				Items struct {
+// projectItemsConnection is a page of project items as fetched by the
+// GetProjectItems queries
+type projectItemsConnection struct {
+	Nodes []struct {
+		ID      string
+		Content struct {
+			TypeName string `graphql:"__typename"`
+			Issue    struct {
+				ID         string
+				Number     int
+				Title      string
+				State      string
+				URL        string `graphql:"url"`
+				Repository struct {
+					NameWithOwner string
+				}
+				Assignees struct {
 					Nodes []struct {
-						ID      string
-						Content struct {
-							TypeName string `graphql:"__typename"`
-							Issue    struct {
-								ID         string
-								Number     int
-								Title      string
-								State      string
-								URL        string `graphql:"url"`
-								Repository struct {
-									NameWithOwner string
-								}
-								Assignees struct {
-									Nodes []struct {
-										Login string
-									}
-								} `graphql:"assignees(first: 10)"`
-							} `graphql:"... on Issue"`
-						}
-						FieldValues struct {
-							Nodes []struct {
-								TypeName string `graphql:"__typename"`
-								// Single select field value
-								ProjectV2ItemFieldSingleSelectValue struct {
-									Name  string
-									Field struct {
-										ProjectV2SingleSelectField struct {
-											Name string
-										} `graphql:"... on ProjectV2SingleSelectField"`
-									}
-								} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
-								// Text field value
-								ProjectV2ItemFieldTextValue struct {
-									Text  string
-									Field struct {
-										ProjectV2Field struct {
-											Name string
-										} `graphql:"... on ProjectV2Field"`
-									}
-								} `graphql:"... on ProjectV2ItemFieldTextValue"`
-							}
-						} `graphql:"fieldValues(first: 20)"`
+						Login string
 					}
-					PageInfo struct {
-						HasNextPage bool
-						EndCursor   string
+				} `graphql:"assignees(first: 10)"`
+				// Details fetched only when requested
+				Labels struct {
+					Nodes []struct {
+						Name  string
+						Color string
 					}
-				} `graphql:"items(first: 100, after: $cursor)"`
-			} `graphql:"... on ProjectV2"`
-		} `graphql:"node(id: $projectId)"`
+				} `graphql:"labels(first: 20) @include(if: $withLabels)"`
+				Milestone struct {
+					Title string
+				} `graphql:"milestone @include(if: $withMilestone)"`
+				Author struct {
+					Login string
+				} `graphql:"author @include(if: $withAuthor)"`
+				CreatedAt string `graphql:"createdAt @include(if: $withDates)"`
+				UpdatedAt string `graphql:"updatedAt @include(if: $withDates)"`
+				ClosedAt  string `graphql:"closedAt @include(if: $withDates)"`
+				Comments  struct {
+					TotalCount int
+				} `graphql:"comments @include(if: $withComments)"`
+				Body string `graphql:"body @include(if: $withBody)"`
+			} `graphql:"... on Issue"`
+		}
+		FieldValues struct {
+			Nodes []struct {
+				TypeName string `graphql:"__typename"`
+				// Single select field value
+				ProjectV2ItemFieldSingleSelectValue struct {
+					Name  string
+					Field struct {
+						ProjectV2SingleSelectField struct {
+							Name string
+						} `graphql:"... on ProjectV2SingleSelectField"`
+					}
+				} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+				// Text field value
+				ProjectV2ItemFieldTextValue struct {
+					Text  string
+					Field struct {
+						ProjectV2Field struct {
+							Name string
+						} `graphql:"... on ProjectV2Field"`
+					}
+				} `graphql:"... on ProjectV2ItemFieldTextValue"`
+				// Number field value
+				ProjectV2ItemFieldNumberValue struct {
+					Number float64
+					Field  struct {
+						ProjectV2Field struct {
+							Name string
+						} `graphql:"... on ProjectV2Field"`
+					}
+				} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+				// Date field value
+				ProjectV2ItemFieldDateValue struct {
+					Date  string
+					Field struct {
+						ProjectV2Field struct {
+							Name string
+						} `graphql:"... on ProjectV2Field"`
+					}
+				} `graphql:"... on ProjectV2ItemFieldDateValue"`
+				// Iteration field value
+				ProjectV2ItemFieldIterationValue struct {
+					Title string
+					Field struct {
+						ProjectV2IterationField struct {
+							Name string
+						} `graphql:"... on ProjectV2IterationField"`
+					}
+				} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+			}
+		} `graphql:"fieldValues(first: 20)"`
+	}
+	PageInfo struct {
+		HasNextPage bool
+		EndCursor   string
 	}
+}
 
+// getProjectItemsPage fetches a single page of project items, filtered by
+// query when it is not empty, with the issue details selected by details
+func (c *Client) getProjectItemsPage(projectID string, cursor *string, query string, details map[string]interface{}) ([]ProjectItem, pageInfo, error) {
 	variables := map[string]interface{}{
 		"projectId": graphql.ID(projectID),
 		"cursor":    (*graphql.String)(nil),
 	}
+	for name, value := range details {
+		variables[name] = value
+	}
 	if cursor != nil {
 		variables["cursor"] = graphql.String(*cursor)
 	}
 
-	err := c.gql.Query("GetProjectItems", &query, variables)
-	if err != nil {
-		return nil, pageInfo{}, fmt.Errorf("failed to get project items: %w", err)
+	var page projectItemsConnection
+	if query == "" {
+		var q struct {
+			Node struct {
+				ProjectV2 struct {
+					Items projectItemsConnection `graphql:"items(first: 100, after: $cursor)"`
+				} `graphql:"... on ProjectV2"`
+			} `graphql:"node(id: $projectId)"`
+		}
+		if err := c.gql.Query("GetProjectItems", &q, variables); err != nil {
+			return nil, pageInfo{}, fmt.Errorf("failed to get project items: %w", err)
+		}
+		page = q.Node.ProjectV2.Items
+	} else {
+		var q struct {
+			Node struct {
+				ProjectV2 struct {
+					Items projectItemsConnection `graphql:"items(first: 100, after: $cursor, query: $query)"`
+				} `graphql:"... on ProjectV2"`
+			} `graphql:"node(id: $projectId)"`
+		}
+		variables["query"] = graphql.String(query)
+		if err := c.gql.Query("GetProjectItems", &q, variables); err != nil {
+			return nil, pageInfo{}, fmt.Errorf("failed to get project items: %w", err)
+		}
+		page = q.Node.ProjectV2.Items
 	}
 
 	var items []ProjectItem
-	for _, node := range query.Node.ProjectV2.Items.Nodes {
+	for _, node := range page.Nodes {
 		// Skip non-issue items (like draft issues or PRs)
 		if node.Content.TypeName != "Issue" {
 			continue
@@ -381,14 +779,27 @@ func (c *Client) getProjectItemsPage(projectID string, cursor *string) ([]Projec
 		item := ProjectItem{
 			ID: node.ID,
 			Issue: &Issue{
-				ID:     node.Content.Issue.ID,
-				Number: node.Content.Issue.Number,
-				Title:  node.Content.Issue.Title,
-				State:  node.Content.Issue.State,
-				URL:    node.Content.Issue.URL,
+				ID:        node.Content.Issue.ID,
+				Number:    node.Content.Issue.Number,
+				Title:     node.Content.Issue.Title,
+				Body:      node.Content.Issue.Body,
+				State:     node.Content.Issue.State,
+				URL:       node.Content.Issue.URL,
+				Author:    Actor{Login: node.Content.Issue.Author.Login},
+				CreatedAt: node.Content.Issue.CreatedAt,
+				UpdatedAt: node.Content.Issue.UpdatedAt,
+				ClosedAt:  node.Content.Issue.ClosedAt,
+				Comments:  node.Content.Issue.Comments.TotalCount,
 			},
 		}
 
+		for _, l := range node.Content.Issue.Labels.Nodes {
+			item.Issue.Labels = append(item.Issue.Labels, Label{Name: l.Name, Color: l.Color})
+		}
+		if node.Content.Issue.Milestone.Title != "" {
+			item.Issue.Milestone = &Milestone{Title: node.Content.Issue.Milestone.Title}
+		}
+
 		// Parse repository
 		if node.Content.Issue.Repository.NameWithOwner != "" {
 			parts := splitRepoName(node.Content.Issue.Repository.NameWithOwner)
@@ -422,6 +833,25 @@ func (c *Client) getProjectItemsPage(projectID string, cursor *string) ([]Projec
 						Value: fv.ProjectV2ItemFieldTextValue.Text,
 					})
 				}
+			case "ProjectV2ItemFieldNumberValue":
+				item.FieldValues = append(item.FieldValues, FieldValue{
+					Field: fv.ProjectV2ItemFieldNumberValue.Field.ProjectV2Field.Name,
+					Value: strconv.FormatFloat(fv.ProjectV2ItemFieldNumberValue.Number, 'f', -1, 64),
+				})
+			case "ProjectV2ItemFieldDateValue":
+				if fv.ProjectV2ItemFieldDateValue.Date != "" {
+					item.FieldValues = append(item.FieldValues, FieldValue{
+						Field: fv.ProjectV2ItemFieldDateValue.Field.ProjectV2Field.Name,
+						Value: fv.ProjectV2ItemFieldDateValue.Date,
+					})
+				}
+			case "ProjectV2ItemFieldIterationValue":
+				if fv.ProjectV2ItemFieldIterationValue.Title != "" {
+					item.FieldValues = append(item.FieldValues, FieldValue{
+						Field: fv.ProjectV2ItemFieldIterationValue.Field.ProjectV2IterationField.Name,
+						Value: fv.ProjectV2ItemFieldIterationValue.Title,
+					})
+				}
 			}
 		}
 
@@ -429,8 +859,8 @@ func (c *Client) getProjectItemsPage(projectID string, cursor *string) ([]Projec
 	}
 
 	return items, pageInfo{
-		HasNextPage: query.Node.ProjectV2.Items.PageInfo.HasNextPage,
-		EndCursor:   query.Node.ProjectV2.Items.PageInfo.EndCursor,
+		HasNextPage: page.PageInfo.HasNextPage,
+		EndCursor:   page.PageInfo.EndCursor,
 	}, nil
 }
 
@@ -444,12 +874,18 @@ func splitRepoName(nameWithOwner string) []string {
 	return nil
 }
 
-// GetSubIssues fetches all sub-issues for a given issue
+// GetSubIssues fetches all sub-issues for a given issue. On hosts without
+// sub-issues it finds issues in the same repository whose body has a
+// "Parent: #N" marker.
 func (c *Client) GetSubIssues(owner, repo string, number int) ([]SubIssue, error) {
 	if c.gql == nil {
 		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
 	}
 
+	if !c.Supports(FeatureSubIssues) {
+		return c.subIssuesFromMarkers(owner, repo, number)
+	}
+
 	var query struct {
 		Repository struct {
 			Issue struct {
@@ -480,7 +916,7 @@ func (c *Client) GetSubIssues(owner, repo string, number int) ([]SubIssue, error
 
 	err := c.gql.Query("GetSubIssues", &query, variables)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get sub-issues for %s/%s#%d: %w", owner, repo, number, err)
+		return nil, fmt.Errorf("failed to get sub-issues for %s/%s#%d: %w", owner, repo, number, c.unsupported(err, FeatureSubIssues))
 	}
 
 	var subIssues []SubIssue
@@ -524,11 +960,22 @@ func (c *Client) GetRepositoryIssues(owner, repo, state string) ([]Issue, error)
 		Repository struct {
 			Issues struct {
 				Nodes []struct {
-					ID     string
-					Number int
-					Title  string
-					State  string
-					URL    string `graphql:"url"`
+					ID        string
+					Number    int
+					Title     string
+					State     string
+					URL       string `graphql:"url"`
+					Assignees struct {
+						Nodes []struct {
+							Login string
+						}
+					} `graphql:"assignees(first: 10)"`
+					Labels struct {
+						Nodes []struct {
+							Name  string
+							Color string
+						}
+					} `graphql:"labels(first: 20)"`
 				}
 				PageInfo struct {
 					HasNextPage bool
@@ -551,7 +998,7 @@ func (c *Client) GetRepositoryIssues(owner, repo, state string) ([]Issue, error)
 
 	var issues []Issue
 	for _, node := range query.Repository.Issues.Nodes {
-		issues = append(issues, Issue{
+		issue := Issue{
 			ID:     node.ID,
 			Number: node.Number,
 			Title:  node.Title,
@@ -561,18 +1008,38 @@ func (c *Client) GetRepositoryIssues(owner, repo, state string) ([]Issue, error)
 				Owner: owner,
 				Name:  repo,
 			},
-		})
+		}
+		for _, a := range node.Assignees.Nodes {
+			issue.Assignees = append(issue.Assignees, Actor{Login: a.Login})
+		}
+		for _, l := range node.Labels.Nodes {
+			issue.Labels = append(issue.Labels, Label{Name: l.Name, Color: l.Color})
+		}
+		issues = append(issues, issue)
+	}
+
+	refs := make([]*Issue, len(issues))
+	for i := range issues {
+		refs[i] = &issues[i]
+	}
+	if err := c.hydrateIssueTypes(refs); err != nil {
+		return nil, err
 	}
 
 	return issues, nil
 }
 
-// GetParentIssue fetches the parent issue for a given sub-issue
+// GetParentIssue fetches the parent issue for a given sub-issue. On hosts
+// without sub-issues it reads the issue's "Parent: #N" body marker.
 func (c *Client) GetParentIssue(owner, repo string, number int) (*Issue, error) {
 	if c.gql == nil {
 		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
 	}
 
+	if !c.Supports(FeatureSubIssues) {
+		return c.parentFromMarker(owner, repo, number)
+	}
+
 	var query struct {
 		Repository struct {
 			Issue struct {
@@ -595,7 +1062,7 @@ func (c *Client) GetParentIssue(owner, repo string, number int) (*Issue, error)
 
 	err := c.gql.Query("GetParentIssue", &query, variables)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get parent issue for %s/%s#%d: %w", owner, repo, number, err)
+		return nil, fmt.Errorf("failed to get parent issue for %s/%s#%d: %w", owner, repo, number, c.unsupported(err, FeatureSubIssues))
 	}
 
 	// If no parent issue, return nil
@@ -781,3 +1248,229 @@ func (c *Client) listOrgProjects(owner string) ([]Project, error) {
 
 	return projects, nil
 }
+
+// GetOwnerID fetches the node ID of a user or organization by login
+func (c *Client) GetOwnerID(login string) (string, error) {
+	if c.gql == nil {
+		return "", fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var query struct {
+		RepositoryOwner struct {
+			ID string
+		} `graphql:"repositoryOwner(login: $login)"`
+	}
+
+	variables := map[string]interface{}{
+		"login": graphql.String(login),
+	}
+
+	err := c.gql.Query("GetOwnerID", &query, variables)
+	if err != nil {
+		return "", fmt.Errorf("failed to get owner ID for %s: %w", login, err)
+	}
+
+	if query.RepositoryOwner.ID == "" {
+		return "", fmt.Errorf("owner %q not found", login)
+	}
+
+	return query.RepositoryOwner.ID, nil
+}
+
+// GetViewerLogin returns the login of the authenticated user
+func (c *Client) GetViewerLogin() (string, error) {
+	if c.gql == nil {
+		return "", fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	c.usersMu.Lock()
+	viewer := c.viewer
+	c.usersMu.Unlock()
+	if viewer != "" {
+		return viewer, nil
+	}
+
+	var query struct {
+		Viewer struct {
+			Login string
+		}
+	}
+
+	if err := c.gql.Query("GetViewer", &query, nil); err != nil {
+		return "", fmt.Errorf("failed to get the authenticated user: %w", err)
+	}
+
+	c.usersMu.Lock()
+	c.viewer = query.Viewer.Login
+	c.usersMu.Unlock()
+
+	return query.Viewer.Login, nil
+}
+
+// GetRepositoryMetadata returns up to 100 each of a repository's labels,
+// assignable users, and open milestones, for choosing issue metadata
+func (c *Client) GetRepositoryMetadata(owner, repo string) (*RepositoryMetadata, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var query struct {
+		Repository struct {
+			ID     string
+			Labels struct {
+				Nodes []struct {
+					Name  string
+					Color string
+				}
+			} `graphql:"labels(first: 100, orderBy: {field: NAME, direction: ASC})"`
+			AssignableUsers struct {
+				Nodes []struct {
+					Login string
+				}
+			} `graphql:"assignableUsers(first: 100)"`
+			Milestones struct {
+				Nodes []struct {
+					Title string
+				}
+			} `graphql:"milestones(first: 100, states: [OPEN], orderBy: {field: DUE_DATE, direction: ASC})"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner": graphql.String(owner),
+		"repo":  graphql.String(repo),
+	}
+
+	if err := c.gql.Query("GetRepositoryMetadata", &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to get metadata for %s/%s: %w", owner, repo, err)
+	}
+	if query.Repository.ID == "" {
+		return nil, fmt.Errorf("repository %s/%s not found", owner, repo)
+	}
+
+	metadata := &RepositoryMetadata{}
+	for _, node := range query.Repository.Labels.Nodes {
+		metadata.Labels = append(metadata.Labels, Label{Name: node.Name, Color: node.Color})
+	}
+	for _, node := range query.Repository.AssignableUsers.Nodes {
+		metadata.AssignableUsers = append(metadata.AssignableUsers, Actor{Login: node.Login})
+	}
+	for _, node := range query.Repository.Milestones.Nodes {
+		metadata.Milestones = append(metadata.Milestones, Milestone{Title: node.Title})
+	}
+
+	return metadata, nil
+}
+
+// IssueTemplateDir is where a repository keeps its issue forms and templates
+const IssueTemplateDir = ".github/ISSUE_TEMPLATE"
+
+// GetIssueTemplates returns the text files in a repository's
+// .github/ISSUE_TEMPLATE directory on its default branch, sorted by name.
+// A repository without the directory has no templates.
+func (c *Client) GetIssueTemplates(owner, repo string) ([]RepositoryFile, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var query struct {
+		Repository struct {
+			ID     string
+			Object *struct {
+				Tree struct {
+					Entries []struct {
+						Name   string
+						Type   string
+						Object struct {
+							Blob struct {
+								Text     string
+								IsBinary bool
+							} `graphql:"... on Blob"`
+						}
+					}
+				} `graphql:"... on Tree"`
+			} `graphql:"object(expression: $expression)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+
+	variables := map[string]interface{}{
+		"owner":      graphql.String(owner),
+		"repo":       graphql.String(repo),
+		"expression": graphql.String("HEAD:" + IssueTemplateDir),
+	}
+
+	if err := c.gql.Query("GetIssueTemplates", &query, variables); err != nil {
+		return nil, fmt.Errorf("failed to get issue templates for %s/%s: %w", owner, repo, err)
+	}
+	if query.Repository.ID == "" {
+		return nil, fmt.Errorf("repository %s/%s not found", owner, repo)
+	}
+	if query.Repository.Object == nil {
+		return nil, nil
+	}
+
+	var files []RepositoryFile
+	for _, entry := range query.Repository.Object.Tree.Entries {
+		if entry.Type != "blob" || entry.Object.Blob.IsBinary {
+			continue
+		}
+		files = append(files, RepositoryFile{Name: entry.Name, Text: entry.Object.Blob.Text})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	return files, nil
+}
+
+// GetTeamMembers returns the logins of the members of an organization's
+// team, including members of its child teams
+func (c *Client) GetTeamMembers(org, team string) ([]string, error) {
+	if c.gql == nil {
+		return nil, fmt.Errorf("GraphQL client not initialized - are you authenticated with gh?")
+	}
+
+	var logins []string
+	var cursor *string
+
+	for {
+		var query struct {
+			Organization struct {
+				Team struct {
+					ID      string
+					Members struct {
+						Nodes []struct {
+							Login string
+						}
+						PageInfo pageInfo
+					} `graphql:"members(first: 100, after: $cursor)"`
+				} `graphql:"team(slug: $team)"`
+			} `graphql:"organization(login: $org)"`
+		}
+
+		variables := map[string]interface{}{
+			"org":    graphql.String(org),
+			"team":   graphql.String(team),
+			"cursor": (*graphql.String)(nil),
+		}
+		if cursor != nil {
+			variables["cursor"] = graphql.String(*cursor)
+		}
+
+		if err := c.gql.Query("GetTeamMembers", &query, variables); err != nil {
+			return nil, fmt.Errorf("failed to get members of %s/%s: %w", org, team, err)
+		}
+		if query.Organization.Team.ID == "" {
+			return nil, fmt.Errorf("team %s/%s not found", org, team)
+		}
+
+		for _, node := range query.Organization.Team.Members.Nodes {
+			logins = append(logins, node.Login)
+		}
+
+		if !query.Organization.Team.Members.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &query.Organization.Team.Members.PageInfo.EndCursor
+	}
+
+	return logins, nil
+}
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+
+	graphql "github.com/cli/shurcooL-graphql"
 )
 
 func TestSplitRepoName(t *testing.T) {
@@ -150,6 +154,36 @@ func TestGetSubIssues_NilClient(t *testing.T) {
 	}
 }
 
+func TestGetSubIssues_UnsupportedHost(t *testing.T) {
+	mock := &mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			return errors.New("Field 'subIssues' doesn't exist on type 'Issue'")
+		},
+	}
+	client := &Client{gql: mock, opts: ClientOptions{Host: "github.example.com"}}
+
+	_, err := client.GetSubIssues("owner", "repo", 1)
+
+	if !IsUnsupported(err) {
+		t.Errorf("Expected unsupported error, got: %v", err)
+	}
+}
+
+func TestGetSubIssues_OtherErrorsAreNotUnsupported(t *testing.T) {
+	mock := &mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			return errors.New("Could not resolve to an Issue with the number of 1")
+		},
+	}
+	client := NewClientWithGraphQL(mock)
+
+	_, err := client.GetSubIssues("owner", "repo", 1)
+
+	if err == nil || IsUnsupported(err) {
+		t.Errorf("Expected a plain error, got: %v", err)
+	}
+}
+
 func TestGetRepositoryIssues_NilClient(t *testing.T) {
 	// ARRANGE: Create client with nil gql
 	client := &Client{gql: nil}
@@ -1259,6 +1293,44 @@ func TestGetProjectItems_WithFieldValues(t *testing.T) {
 	}
 }
 
+func TestGetProjectItems_NumberDateAndIterationValues(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, vars map[string]interface{}) error {
+			if name != "GetProjectItems" {
+				return nil
+			}
+			return json.Unmarshal([]byte(`{"node": {"projectV2": {"items": {"nodes": [{
+				"id": "item-1",
+				"content": {"typeName": "Issue", "issue": {"id": "issue-1", "number": 1, "title": "Test", "state": "OPEN"}},
+				"fieldValues": {"nodes": [
+					{"typeName": "ProjectV2ItemFieldNumberValue", "projectV2ItemFieldNumberValue": {"number": 2.5, "field": {"projectV2Field": {"name": "Story Points"}}}},
+					{"typeName": "ProjectV2ItemFieldNumberValue", "projectV2ItemFieldNumberValue": {"number": 0, "field": {"projectV2Field": {"name": "Risk"}}}},
+					{"typeName": "ProjectV2ItemFieldDateValue", "projectV2ItemFieldDateValue": {"date": "2026-03-01", "field": {"projectV2Field": {"name": "Due"}}}},
+					{"typeName": "ProjectV2ItemFieldIterationValue", "projectV2ItemFieldIterationValue": {"title": "Sprint 4", "field": {"projectV2IterationField": {"name": "Sprint"}}}}
+				]}
+			}]}}}}`), query)
+		},
+	})
+
+	items, err := client.GetProjectItems("proj-id", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(items))
+	}
+
+	want := []FieldValue{
+		{Field: "Story Points", Value: "2.5"},
+		{Field: "Risk", Value: "0"},
+		{Field: "Due", Value: "2026-03-01"},
+		{Field: "Sprint", Value: "Sprint 4"},
+	}
+	if !reflect.DeepEqual(items[0].FieldValues, want) {
+		t.Errorf("Expected %v, got %v", want, items[0].FieldValues)
+	}
+}
+
 func TestGetProjectItems_WithAssignees(t *testing.T) {
 	mock := &queryMockClient{
 		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
@@ -1439,6 +1511,32 @@ func TestGetRepositoryIssues_Success(t *testing.T) {
 	}
 }
 
+func TestGetRepositoryIssues_AssigneesAndLabels(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if name != "GetRepositoryIssues" {
+				return nil
+			}
+			return json.Unmarshal([]byte(`{"repository": {"issues": {"nodes": [{
+				"id": "issue-1", "number": 1, "title": "First",
+				"assignees": {"nodes": [{"login": "octocat"}]},
+				"labels": {"nodes": [{"name": "bug", "color": "d73a4a"}]}
+			}]}}}`), query)
+		},
+	})
+
+	issues, err := client.GetRepositoryIssues("owner", "repo", "open")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 1 || len(issues[0].Assignees) != 1 || issues[0].Assignees[0].Login != "octocat" {
+		t.Fatalf("Expected the assignee, got %+v", issues)
+	}
+	if len(issues[0].Labels) != 1 || issues[0].Labels[0].Name != "bug" {
+		t.Errorf("Expected the label, got %+v", issues[0].Labels)
+	}
+}
+
 // ============================================================================
 // GetProjectItems Pagination Tests
 // ============================================================================
@@ -1825,3 +1923,503 @@ func TestGetProjectItems_Pagination_WithFilter(t *testing.T) {
 		t.Errorf("Expected second item 'Match 2', got '%s'", items[1].Issue.Title)
 	}
 }
+
+func TestGetProjectDetails_NilClient(t *testing.T) {
+	client := &Client{gql: nil}
+
+	_, err := client.GetProjectDetails("proj-id")
+	if err == nil {
+		t.Fatal("Expected error when gql is nil")
+	}
+	if !strings.Contains(err.Error(), "GraphQL client not initialized") {
+		t.Errorf("Expected 'GraphQL client not initialized' error, got: %v", err)
+	}
+}
+
+func TestGetProjectViews(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if name != "GetProjectViews" {
+				t.Errorf("Unexpected query %s", name)
+			}
+			return json.Unmarshal([]byte(`{"node": {"projectV2": {"views": {"nodes": [{
+				"name": "Board", "number": 1, "layout": "BOARD_LAYOUT", "filter": "label:bug",
+				"fields": {"nodes": [
+					{"typeName": "ProjectV2Field", "projectV2Field": {"name": "Title"}},
+					{"typeName": "ProjectV2SingleSelectField", "projectV2SingleSelectField": {"name": "Priority"}}
+				]},
+				"verticalGroupByFields": {"nodes": [
+					{"typeName": "ProjectV2SingleSelectField", "projectV2SingleSelectField": {"name": "Status"}}
+				]},
+				"sortByFields": {"nodes": [
+					{"direction": "DESC", "field": {"typeName": "ProjectV2IterationField", "projectV2IterationField": {"name": "Sprint"}}}
+				]}
+			}]}}}}`), query)
+		},
+	})
+
+	views, err := client.GetProjectViews("proj-id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []ProjectView{{
+		Name:    "Board",
+		Number:  1,
+		Layout:  "BOARD_LAYOUT",
+		Filter:  "label:bug",
+		Fields:  []string{"Title", "Priority"},
+		GroupBy: []string{"Status"},
+		SortBy:  []ProjectViewSort{{Field: "Sprint", Descending: true}},
+	}}
+	if !reflect.DeepEqual(views, want) {
+		t.Errorf("Expected %+v, got %+v", want, views)
+	}
+}
+
+func TestGetProjectViews_NilClient(t *testing.T) {
+	client := &Client{gql: nil}
+
+	if _, err := client.GetProjectViews("proj-id"); err == nil {
+		t.Fatal("Expected error when gql is nil")
+	}
+}
+
+func TestMatchRepository(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"acme/api", "acme/api", true},
+		{"Acme/API", "acme/api", true},
+		{"acme/api", "acme/web", false},
+		{"acme", "acme/web", true},
+		{"acme/*", "acme/web", true},
+		{"acme", "other/web", false},
+		{"acme/api-*", "acme/api-gateway", true},
+		{"acme/api-*", "acme/web-api", false},
+		{"*/docs", "other/docs", true},
+		{"acme/[", "acme/api", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchRepository(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchRepository(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProjectItemsFilter_Matches(t *testing.T) {
+	item := func(owner, name string) ProjectItem {
+		return ProjectItem{Issue: &Issue{Repository: Repository{Owner: owner, Name: name}}}
+	}
+	filter := &ProjectItemsFilter{Repositories: []string{"acme/api", "tools"}}
+
+	if !filter.Matches(item("acme", "api")) || !filter.Matches(item("tools", "cli")) {
+		t.Error("Expected items in the filter's repositories to match")
+	}
+	if filter.Matches(item("acme", "web")) {
+		t.Error("Expected acme/web not to match")
+	}
+	if !filter.Matches(item("", "")) || !filter.Matches(ProjectItem{}) {
+		t.Error("Expected items without a known repository to match")
+	}
+
+	var none *ProjectItemsFilter
+	if !none.Matches(item("acme", "web")) || !(&ProjectItemsFilter{}).Matches(item("acme", "web")) {
+		t.Error("Expected an empty filter to match everything")
+	}
+}
+
+func TestProjectItemsFilter_Query(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *ProjectItemsFilter
+		want   string
+	}{
+		{"nil", nil, ""},
+		{"repositories only", &ProjectItemsFilter{Repositories: []string{"acme/api"}}, ""},
+		{
+			"fields",
+			&ProjectItemsFilter{FieldValues: map[string]string{"Status": "In progress", "Priority": "P1"}},
+			`is:issue priority:P1 status:"In progress"`,
+		},
+		{
+			"field with spaces",
+			&ProjectItemsFilter{FieldValues: map[string]string{"Story Points": "3"}},
+			"is:issue story-points:3",
+		},
+		{
+			"assignee, label, and exact repositories",
+			&ProjectItemsFilter{Assignee: "octocat", Label: "good first issue", Repositories: []string{"acme/api", "acme/web"}},
+			`is:issue assignee:octocat label:"good first issue" repo:acme/api,acme/web`,
+		},
+		{
+			"wildcard repositories are left to the client",
+			&ProjectItemsFilter{Label: "bug", Repositories: []string{"acme/api", "acme/web-*"}},
+			"is:issue label:bug",
+		},
+		{
+			"search is left to the client",
+			&ProjectItemsFilter{Label: "bug", Search: "login"},
+			"is:issue label:bug",
+		},
+		{"search only", &ProjectItemsFilter{Search: "login"}, ""},
+		{
+			"several assignees",
+			&ProjectItemsFilter{Assignee: "alice, bob"},
+			"is:issue assignee:alice,bob",
+		},
+		{
+			"board filter string",
+			&ProjectItemsFilter{Label: "bug", Filter: ` status:Todo -assignee:octocat `, Repositories: []string{"acme/api"}},
+			"is:issue label:bug status:Todo -assignee:octocat repo:acme/api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Query(); got != tt.want {
+				t.Errorf("Query() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProjectItems_SendsItemQuery(t *testing.T) {
+	var queries []interface{}
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if name == "GetProjectItems" {
+				queries = append(queries, variables["query"])
+			}
+			return nil
+		},
+	})
+	client.caps = &capabilities{ItemQuery: true}
+	client.capsProbed = true
+
+	if _, err := client.GetProjectItems("proj-id", &ProjectItemsFilter{Label: "bug"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(queries) != 1 || queries[0] != graphql.String("is:issue label:bug") {
+		t.Errorf("Expected the filter to be sent as an items query, got %v", queries)
+	}
+}
+
+func TestGetProjectItems_FallsBackWithoutItemQuery(t *testing.T) {
+	var queries []interface{}
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if name != "GetProjectItems" {
+				return nil
+			}
+			queries = append(queries, variables["query"])
+			if _, ok := variables["query"]; ok {
+				return errors.New("Field 'items' doesn't accept argument 'query'")
+			}
+			return nil
+		},
+	})
+	client.caps = &capabilities{ItemQuery: true}
+	client.capsProbed = true
+
+	if _, err := client.GetProjectItems("proj-id", &ProjectItemsFilter{Label: "bug"}); err != nil {
+		t.Fatalf("Expected the unfiltered items, got error: %v", err)
+	}
+	if len(queries) != 2 || queries[1] != nil {
+		t.Errorf("Expected a retry without the query, got %v", queries)
+	}
+	if client.Supports(FeatureItemQuery) {
+		t.Error("Expected item queries to be marked unsupported")
+	}
+
+	// Later calls skip the query
+	queries = nil
+	if _, err := client.GetProjectItems("proj-id", &ProjectItemsFilter{Label: "bug"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(queries) != 1 || queries[0] != nil {
+		t.Errorf("Expected a single unfiltered query, got %v", queries)
+	}
+}
+
+func TestGetProjectItems_IncludesRequestedDetails(t *testing.T) {
+	var variables map[string]interface{}
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, vars map[string]interface{}) error {
+			if name != "GetProjectItems" {
+				return nil
+			}
+			variables = vars
+			return json.Unmarshal([]byte(`{"node": {"projectV2": {"items": {"nodes": [{
+				"id": "item-1",
+				"content": {"typeName": "Issue", "issue": {
+					"id": "issue-1", "number": 7, "title": "Login page", "state": "CLOSED",
+					"repository": {"nameWithOwner": "acme/api"},
+					"labels": {"nodes": [{"name": "bug", "color": "d73a4a"}]},
+					"milestone": {"title": "v1.0"},
+					"author": {"login": "octocat"},
+					"createdAt": "2026-01-02T03:04:05Z",
+					"closedAt": "2026-02-03T04:05:06Z",
+					"comments": {"totalCount": 3},
+					"body": "Details"
+				}}
+			}]}}}}`), query)
+		},
+	})
+
+	items, err := client.GetProjectItems("proj-id", &ProjectItemsFilter{Include: []string{DetailLabels, DetailDates}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := map[string]bool{"withLabels": true, "withDates": true, "withMilestone": false, "withAuthor": false, "withComments": false, "withBody": false}
+	for name, include := range want {
+		if variables[name] != graphql.Boolean(include) {
+			t.Errorf("Expected %s to be %v, got %v", name, include, variables[name])
+		}
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("Expected 1 item, got %d", len(items))
+	}
+	issue := items[0].Issue
+	if len(issue.Labels) != 1 || issue.Labels[0].Name != "bug" {
+		t.Errorf("Expected the bug label, got %v", issue.Labels)
+	}
+	if issue.Milestone == nil || issue.Milestone.Title != "v1.0" {
+		t.Errorf("Expected milestone v1.0, got %v", issue.Milestone)
+	}
+	if issue.Author.Login != "octocat" || issue.Comments != 3 || issue.Body != "Details" {
+		t.Errorf("Unexpected details %+v", issue)
+	}
+	if issue.CreatedAt != "2026-01-02T03:04:05Z" || issue.ClosedAt != "2026-02-03T04:05:06Z" || issue.UpdatedAt != "" {
+		t.Errorf("Unexpected dates %q, %q, %q", issue.CreatedAt, issue.UpdatedAt, issue.ClosedAt)
+	}
+}
+
+func TestGetProjectItems_FetchesFilteredDetailsWithoutItemQuery(t *testing.T) {
+	var variables map[string]interface{}
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, vars map[string]interface{}) error {
+			if name == "GetProjectItems" {
+				variables = vars
+			}
+			return nil
+		},
+	})
+	client.caps = &capabilities{}
+	client.capsProbed = true
+
+	if _, err := client.GetProjectItems("proj-id", &ProjectItemsFilter{Label: "bug", Search: "login"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if variables["withLabels"] != graphql.Boolean(true) || variables["withBody"] != graphql.Boolean(true) {
+		t.Errorf("Expected labels and body to be fetched for filtering, got %v", variables)
+	}
+
+	// The host filters by label itself when it can, but search is matched
+	// against the body by the caller
+	client.caps = &capabilities{ItemQuery: true}
+	if _, err := client.GetProjectItems("proj-id", &ProjectItemsFilter{Label: "bug", Search: "login"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if variables["withLabels"] != graphql.Boolean(false) || variables["withBody"] != graphql.Boolean(true) {
+		t.Errorf("Expected the body without labels, got %v", variables)
+	}
+}
+
+func TestSupports_ProbesItemQuery(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			v := reflect.ValueOf(query).Elem()
+			setNames(v.FieldByName("Issue").FieldByName("Fields"), []string{"title"})
+			fields := v.FieldByName("ProjectV2").FieldByName("Fields")
+			values := reflect.MakeSlice(fields.Type(), 1, 1)
+			values.Index(0).FieldByName("Name").SetString("items")
+			setNames(values.Index(0).FieldByName("Args"), []string{"first", "after", "query"})
+			fields.Set(values)
+			return nil
+		},
+	})
+
+	if !client.Supports(FeatureItemQuery) {
+		t.Error("Expected item queries to be supported")
+	}
+}
+
+func TestGetViewerLogin_CachesViewer(t *testing.T) {
+	calls := 0
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			calls++
+			return json.Unmarshal([]byte(`{"viewer": {"login": "octocat"}}`), query)
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		login, err := client.GetViewerLogin()
+		if err != nil || login != "octocat" {
+			t.Fatalf("Expected octocat, got %q, %v", login, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected one query, got %d", calls)
+	}
+}
+
+func TestGetTeamMembers_Pages(t *testing.T) {
+	var cursors []interface{}
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			cursors = append(cursors, variables["cursor"])
+			if variables["team"] != graphql.String("backend") || variables["org"] != graphql.String("acme") {
+				t.Errorf("Unexpected variables %v", variables)
+			}
+			if len(cursors) == 1 {
+				return json.Unmarshal([]byte(`{"organization": {"team": {"id": "T1", "members": {
+					"nodes": [{"login": "alice"}], "pageInfo": {"hasNextPage": true, "endCursor": "c1"}}}}}`), query)
+			}
+			return json.Unmarshal([]byte(`{"organization": {"team": {"id": "T1", "members": {
+				"nodes": [{"login": "bob"}]}}}}`), query)
+		},
+	})
+
+	members, err := client.GetTeamMembers("acme", "backend")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(members, []string{"alice", "bob"}) {
+		t.Errorf("Expected alice and bob, got %v", members)
+	}
+	if len(cursors) != 2 || cursors[1] != graphql.String("c1") {
+		t.Errorf("Expected the second page to start at c1, got %v", cursors)
+	}
+}
+
+func TestGetTeamMembers_NotFound(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{})
+
+	if _, err := client.GetTeamMembers("acme", "ghosts"); err == nil || !strings.Contains(err.Error(), "team acme/ghosts not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestGetRepositoryMetadata(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if variables["owner"] != graphql.String("acme") || variables["repo"] != graphql.String("api") {
+				t.Errorf("Unexpected variables %v", variables)
+			}
+			return json.Unmarshal([]byte(`{"repository": {"id": "R1",
+				"labels": {"nodes": [{"name": "bug", "color": "d73a4a"}, {"name": "docs", "color": "0075ca"}]},
+				"assignableUsers": {"nodes": [{"login": "alice"}]},
+				"milestones": {"nodes": [{"title": "v1.0"}]}}}`), query)
+		},
+	})
+
+	metadata, err := client.GetRepositoryMetadata("acme", "api")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := &RepositoryMetadata{
+		Labels:          []Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Color: "0075ca"}},
+		AssignableUsers: []Actor{{Login: "alice"}},
+		Milestones:      []Milestone{{Title: "v1.0"}},
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("Expected %+v, got %+v", want, metadata)
+	}
+}
+
+func TestGetRepositoryMetadata_NotFound(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{})
+
+	if _, err := client.GetRepositoryMetadata("acme", "gone"); err == nil || !strings.Contains(err.Error(), "repository acme/gone not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestGetIssueTemplates(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if variables["expression"] != graphql.String("HEAD:.github/ISSUE_TEMPLATE") {
+				t.Errorf("Unexpected expression %v", variables["expression"])
+			}
+			return json.Unmarshal([]byte(`{"repository": {"id": "R1", "object": {"tree": {"entries": [
+				{"name": "feature.md", "type": "blob", "object": {"blob": {"text": "feature"}}},
+				{"name": "bug.yml", "type": "blob", "object": {"blob": {"text": "bug"}}},
+				{"name": "logo.png", "type": "blob", "object": {"blob": {"isBinary": true}}},
+				{"name": "drafts", "type": "tree", "object": {}}
+			]}}}}`), query)
+		},
+	})
+
+	files, err := client.GetIssueTemplates("acme", "api")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []RepositoryFile{{Name: "bug.yml", Text: "bug"}, {Name: "feature.md", Text: "feature"}}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("Expected %+v, got %+v", want, files)
+	}
+}
+
+func TestGetIssueTemplates_NoDirectory(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			return json.Unmarshal([]byte(`{"repository": {"id": "R1", "object": null}}`), query)
+		},
+	})
+
+	files, err := client.GetIssueTemplates("acme", "api")
+	if err != nil || len(files) != 0 {
+		t.Errorf("Expected no templates, got %v, %v", files, err)
+	}
+}
+
+func TestGetUserID_CachesLogins(t *testing.T) {
+	calls := 0
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			calls++
+			if variables["login"] == graphql.String("ghost") {
+				return nil
+			}
+			return json.Unmarshal([]byte(`{"user": {"id": "U1"}}`), query)
+		},
+	})
+
+	for _, login := range []string{"octocat", "Octocat"} {
+		if id, err := client.GetUserID(login); err != nil || id != "U1" {
+			t.Fatalf("Expected U1, got %q, %v", id, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected one query, got %d", calls)
+	}
+
+	if _, err := client.GetUserID("ghost"); !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), `user "ghost"`) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestGetUserID_NotFoundOnlyForMissingUsers(t *testing.T) {
+	client := NewClientWithGraphQL(&mockGraphQLClient{
+		queryFunc: func(name string, query interface{}, variables map[string]interface{}) error {
+			if variables["login"] == graphql.String("ghost") {
+				return fmt.Errorf("Could not resolve to a User with the login of 'ghost'.")
+			}
+			return fmt.Errorf("API rate limit exceeded for user ID 1")
+		},
+	})
+
+	if _, err := client.GetUserID("ghost"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := client.GetUserID("octocat"); err == nil || errors.Is(err, ErrNotFound) || !IsRateLimited(err) {
+		t.Errorf("Expected the rate limit error, got %v", err)
+	}
+}
@@ -10,6 +10,30 @@ type Project struct {
 	Closed bool
 }
 
+// ProjectDetails represents the descriptive settings of a project
+type ProjectDetails struct {
+	ShortDescription string
+	Readme           string
+	Public           bool
+}
+
+// ProjectView represents a saved view (a tab) of a GitHub project
+type ProjectView struct {
+	Name    string
+	Number  int
+	Layout  string   // TABLE_LAYOUT, BOARD_LAYOUT, or ROADMAP_LAYOUT
+	Filter  string   // Filter string, as typed on the board
+	Fields  []string // Visible fields, in order
+	GroupBy []string // Fields grouping the rows, or the columns of a board
+	SortBy  []ProjectViewSort
+}
+
+// ProjectViewSort is a field a project view sorts by
+type ProjectViewSort struct {
+	Field      string
+	Descending bool
+}
+
 // ProjectOwner represents the owner of a project
 type ProjectOwner struct {
 	Type  string // "User" or "Organization"
@@ -18,17 +42,34 @@ type ProjectOwner struct {
 
 // ProjectField represents a field in a GitHub project
 type ProjectField struct {
-	ID       string
-	Name     string
-	DataType string
-	Options  []FieldOption // For SINGLE_SELECT fields
+	ID        string
+	Name      string
+	DataType  string
+	Options   []FieldOption    // For SINGLE_SELECT fields
+	Iteration *IterationConfig // For ITERATION fields
+}
+
+// IterationConfig represents the schedule of an iteration field
+type IterationConfig struct {
+	Duration   int // Default iteration length in days
+	StartDay   int // Day of the week iterations start (1 = Monday, 7 = Sunday)
+	Iterations []Iteration
+}
+
+// Iteration represents a single iteration of an iteration field
+type Iteration struct {
+	ID        string
+	Title     string
+	StartDate string
+	Duration  int
 }
 
 // FieldOption represents an option for a single-select field
 type FieldOption struct {
-	ID    string
-	Name  string
-	Color string
+	ID          string
+	Name        string
+	Color       string
+	Description string
 }
 
 // Issue represents a GitHub issue
@@ -44,6 +85,11 @@ type Issue struct {
 	Assignees  []Actor
 	Labels     []Label
 	Milestone  *Milestone
+	Type       string // Issue type name, such as "Bug"; empty when unset
+	CreatedAt  string
+	UpdatedAt  string
+	ClosedAt   string // Empty while the issue is open
+	Comments   int    // Number of comments
 }
 
 // Repository represents a GitHub repository
@@ -68,6 +114,20 @@ type Milestone struct {
 	Title string
 }
 
+// RepositoryFile is a text file read from a repository
+type RepositoryFile struct {
+	Name string
+	Text string
+}
+
+// RepositoryMetadata holds the labels, assignable users, and open
+// milestones an issue in a repository can be given
+type RepositoryMetadata struct {
+	Labels          []Label
+	AssignableUsers []Actor
+	Milestones      []Milestone
+}
+
 // ProjectItem represents an issue or PR within a project
 type ProjectItem struct {
 	ID          string
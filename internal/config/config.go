@@ -1,22 +1,37 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
-	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the .gh-pmu.yml configuration file
 type Config struct {
-	Project      Project           `yaml:"project"`
-	Repositories []string          `yaml:"repositories"`
-	Defaults     Defaults          `yaml:"defaults,omitempty"`
-	Fields       map[string]Field  `yaml:"fields,omitempty"`
-	Triage       map[string]Triage `yaml:"triage,omitempty"`
-	Metadata     *Metadata         `yaml:"metadata,omitempty"`
+	Host         string             `yaml:"host,omitempty"`
+	Project      Project            `yaml:"project"`
+	Repositories []string           `yaml:"repositories"`
+	Defaults     Defaults           `yaml:"defaults,omitempty"`
+	Fields       map[string]Field   `yaml:"fields,omitempty"`
+	Triage       map[string]Triage  `yaml:"triage,omitempty"`
+	Views        map[string]View    `yaml:"views,omitempty"`
+	Metadata     *Metadata          `yaml:"metadata,omitempty"`
+	Projects     map[string]Profile `yaml:"projects,omitempty"`
+
+	path      string                // file the config was loaded from (the nearest file when layered)
+	node      *yaml.Node            // parsed document, used for error positions and by Save
+	indent    int                   // indentation of the file, kept by Save
+	files     map[*yaml.Node]string // file each node came from when layered
+	paths     []string              // files the config was layered from
+	profile   string                // selected project profile, see ForProject
+	overrides *Overrides            // environment and flag overrides, see ApplyOverrides
+
+	overrideOrigins map[string][]Origin // key -> values set by overrides
 }
 
 // Project contains GitHub project configuration
@@ -26,11 +41,23 @@ type Project struct {
 	Owner  string `yaml:"owner"`
 }
 
-// Defaults contains default values for new issues
+// Profile is a named project with its own field aliases and metadata,
+// selected with the global --project flag
+type Profile struct {
+	Project  `yaml:",inline"`
+	Defaults *Defaults        `yaml:"defaults,omitempty"`
+	Fields   map[string]Field `yaml:"fields,omitempty"`
+	Metadata *Metadata        `yaml:"metadata,omitempty"`
+}
+
+// Defaults contains default values for new issues, and the statuses set
+// when issues are closed and reopened
 type Defaults struct {
-	Priority string   `yaml:"priority,omitempty"`
-	Status   string   `yaml:"status,omitempty"`
-	Labels   []string `yaml:"labels,omitempty"`
+	Priority       string   `yaml:"priority,omitempty"`
+	Status         string   `yaml:"status,omitempty"`
+	Labels         []string `yaml:"labels,omitempty"`
+	ClosedStatus   string   `yaml:"closed_status,omitempty"`
+	ReopenedStatus string   `yaml:"reopened_status,omitempty"`
 }
 
 // Field maps field aliases to GitHub project field names and values
@@ -49,6 +76,7 @@ type Triage struct {
 // TriageApply contains fields to apply during triage
 type TriageApply struct {
 	Labels []string          `yaml:"labels,omitempty"`
+	Type   string            `yaml:"type,omitempty"` // issue type name, such as Bug
 	Fields map[string]string `yaml:"fields,omitempty"`
 }
 
@@ -58,6 +86,33 @@ type TriageInteractive struct {
 	Estimate bool `yaml:"estimate,omitempty"`
 }
 
+// View is a named list query, run with list --view and saved with
+// view-save. Views imported from the project also keep the board's filter
+// string and layout.
+type View struct {
+	Filter       string   `yaml:"filter,omitempty"` // project filter, as typed on the board (e.g. "status:Todo label:bug")
+	Status       string   `yaml:"status,omitempty"`
+	Priority     string   `yaml:"priority,omitempty"`
+	Assignee     string   `yaml:"assignee,omitempty"`
+	Label        string   `yaml:"label,omitempty"`
+	Type         string   `yaml:"type,omitempty"`
+	Search       string   `yaml:"search,omitempty"`
+	Repos        []string `yaml:"repos,omitempty"`
+	AllRepos     bool     `yaml:"all_repos,omitempty"`
+	HasSubIssues bool     `yaml:"has_sub_issues,omitempty"`
+	Columns      []string `yaml:"columns,omitempty"`
+	Format       string   `yaml:"format,omitempty"`
+	Sort         string   `yaml:"sort,omitempty"`
+	GroupBy      string   `yaml:"group_by,omitempty"`
+	Limit        int      `yaml:"limit,omitempty"`
+	Layout       string   `yaml:"layout,omitempty"` // board, table, or roadmap, for imported views
+}
+
+// ViewNames returns the names of the configured views, sorted
+func (c *Config) ViewNames() []string {
+	return sortedKeys(c.Views)
+}
+
 // Metadata contains cached project metadata from GitHub API
 type Metadata struct {
 	Project ProjectMetadata `yaml:"project,omitempty"`
@@ -86,25 +141,148 @@ type OptionMetadata struct {
 // ConfigFileName is the default configuration file name
 const ConfigFileName = ".gh-pmu.yml"
 
-// Load reads and parses a configuration file from the given path
+// Load reads and parses a configuration file from the given path.
+// Unknown keys, invalid repositories, and unparseable triage queries are
+// reported as a *ValidationError with file:line positions.
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	cfg := Config{path: path, node: &node, indent: detectIndent(data)}
+
+	var problems []Problem
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		problems = decodeProblems(path, err)
+		for i := range problems {
+			if col := columnAt(&node, problems[i].Line); col > 0 {
+				problems[i].Column = col
+			}
+		}
+	}
+
+	problems = append(problems, cfg.checkStructure()...)
+	if len(problems) > 0 {
+		sortProblems(problems)
+		return nil, &ValidationError{Problems: problems}
+	}
+
 	return &cfg, nil
 }
 
-// LoadFromDirectory finds and loads the config file from the given directory
-func LoadFromDirectory(dir string) (*Config, error) {
-	path := filepath.Join(dir, ConfigFileName)
-	return Load(path)
+// Save writes the configuration to the given path. A configuration loaded
+// from a single file is written back through its parsed document, so that
+// the file's comments, key order, quoting, and indentation are kept and only
+// the changed values are rewritten.
+func (c *Config) Save(path string) error {
+	var data []byte
+	if c.node != nil && c.files == nil && c.node.Kind == yaml.DocumentNode && len(c.node.Content) == 1 {
+		var updated yaml.Node
+		if err := updated.Encode(c); err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		mergeNode(c.node.Content[0], &updated)
+
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(c.indent)
+		if err := enc.Encode(c.node); err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		if err := enc.Close(); err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		data = buf.Bytes()
+	} else {
+		var err error
+		if data, err = yaml.Marshal(c); err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// mergeNode updates dst, a node of a parsed file, to the value of src while
+// keeping dst's comments, and the order and style of what is unchanged.
+// Mapping keys missing from src are removed and new ones appended.
+func mergeNode(dst, src *yaml.Node) {
+	switch {
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+		var content []*yaml.Node
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			if value := mappingValue(src, dst.Content[i].Value); value != nil {
+				mergeNode(dst.Content[i+1], value)
+				content = append(content, dst.Content[i], dst.Content[i+1])
+			}
+		}
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			if mappingValue(dst, src.Content[i].Value) == nil {
+				content = append(content, src.Content[i], src.Content[i+1])
+			}
+		}
+		dst.Content = content
+	case dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode:
+		if len(dst.Content) > len(src.Content) {
+			dst.Content = dst.Content[:len(src.Content)]
+		}
+		for i, item := range src.Content {
+			if i < len(dst.Content) {
+				mergeNode(dst.Content[i], item)
+			} else {
+				dst.Content = append(dst.Content, item)
+			}
+		}
+	case dst.Kind == yaml.ScalarNode && src.Kind == yaml.ScalarNode:
+		if dst.Value != src.Value {
+			dst.Value, dst.Tag, dst.Style = src.Value, src.Tag, src.Style
+		}
+	default:
+		dst.Kind, dst.Tag, dst.Value, dst.Style = src.Kind, src.Tag, src.Value, src.Style
+		dst.Content, dst.Alias, dst.Anchor = src.Content, nil, ""
+	}
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// detectIndent returns the indentation of the least indented nested line in
+// a YAML file, or 4 (the YAML encoder's default) when nothing is nested
+func detectIndent(data []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		n := len(line) - len(trimmed)
+		if n == 0 || trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if indent == 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent == 0 {
+		return 4
+	}
+	return indent
 }
 
 // Validate checks that required configuration fields are present
@@ -124,6 +302,66 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ProjectNames returns the names of the configured project profiles, sorted
+func (c *Config) ProjectNames() []string {
+	return sortedKeys(c.Projects)
+}
+
+// ForProject returns the configuration for the named project profile: the
+// profile's project, defaults, and metadata replace the top-level ones, and
+// its field aliases are merged over the top-level aliases.
+//
+// An empty name selects the top-level project, or the only profile when no
+// top-level project is configured.
+func (c *Config) ForProject(name string) (*Config, error) {
+	if name == "" {
+		if c.Project.Owner != "" || c.Project.Number != 0 || len(c.Projects) == 0 {
+			return c, nil
+		}
+		if len(c.Projects) > 1 {
+			return nil, fmt.Errorf("no default project configured; use --project with one of: %s", strings.Join(c.ProjectNames(), ", "))
+		}
+		name = c.ProjectNames()[0]
+	}
+
+	profile, ok := c.Projects[name]
+	if !ok {
+		if name == c.Project.Name && name != "" {
+			return c, nil
+		}
+		if len(c.Projects) == 0 {
+			return nil, fmt.Errorf("unknown project %q: no projects configured", name)
+		}
+		return nil, fmt.Errorf("unknown project %q: expected one of %s", name, strings.Join(c.ProjectNames(), ", "))
+	}
+
+	selected := *c
+	selected.Project = profile.Project
+	if selected.Project.Name == "" {
+		selected.Project.Name = name
+	}
+	if profile.Defaults != nil {
+		selected.Defaults = *profile.Defaults
+	}
+	selected.Metadata = profile.Metadata
+	selected.Fields = make(map[string]Field, len(c.Fields)+len(profile.Fields))
+	for key, field := range c.Fields {
+		selected.Fields[key] = field
+	}
+	for key, field := range profile.Fields {
+		selected.Fields[key] = field
+	}
+	selected.profile = name
+	selected.applyProjectOverrides()
+	return &selected, nil
+}
+
+// ProfileName returns the name of the selected project profile, or an empty
+// string for the top-level project
+func (c *Config) ProfileName() string {
+	return c.profile
+}
+
 // ResolveFieldValue maps an alias to its actual GitHub field value.
 // If no alias is found, returns the original value unchanged.
 func (c *Config) ResolveFieldValue(fieldKey, alias string) string {
@@ -139,6 +377,32 @@ func (c *Config) ResolveFieldValue(fieldKey, alias string) string {
 	return alias
 }
 
+// ClosedStatus returns the status set on issues when they are closed:
+// defaults.closed_status, or else the "done" status alias, or Done
+func (c *Config) ClosedStatus() string {
+	if c.Defaults.ClosedStatus != "" {
+		return c.ResolveFieldValue("status", c.Defaults.ClosedStatus)
+	}
+	if done := c.ResolveFieldValue("status", "done"); done != "done" {
+		return done
+	}
+	return "Done"
+}
+
+// ReopenedStatus returns the status set on issues when they are reopened:
+// defaults.reopened_status, or else the status of new issues. It is empty
+// when neither is configured.
+func (c *Config) ReopenedStatus() string {
+	status := c.Defaults.ReopenedStatus
+	if status == "" {
+		status = c.Defaults.Status
+	}
+	if status == "" {
+		return ""
+	}
+	return c.ResolveFieldValue("status", status)
+}
+
 // GetFieldName returns the actual GitHub field name for a given key.
 // If no mapping exists, returns the original key unchanged.
 func (c *Config) GetFieldName(fieldKey string) string {
@@ -156,16 +420,12 @@ func (c *Config) GetFieldName(fieldKey string) string {
 
 // ApplyEnvOverrides applies environment variable overrides to the config.
 // Supported environment variables:
-//   - GH_PM_PROJECT_OWNER: overrides project.owner
-//   - GH_PM_PROJECT_NUMBER: overrides project.number
+//   - GH_PMU_OWNER (or the deprecated GH_PM_PROJECT_OWNER): overrides project.owner
+//   - GH_PMU_PROJECT_NUMBER (or the deprecated GH_PM_PROJECT_NUMBER): overrides project.number
+//   - GH_PMU_REPO: overrides repositories (comma-separated)
+//
+// Invalid values are ignored; LoadWithOverrides reports them instead.
 func (c *Config) ApplyEnvOverrides() {
-	if owner := os.Getenv("GH_PM_PROJECT_OWNER"); owner != "" {
-		c.Project.Owner = owner
-	}
-
-	if numberStr := os.Getenv("GH_PM_PROJECT_NUMBER"); numberStr != "" {
-		if number, err := strconv.Atoi(numberStr); err == nil {
-			c.Project.Number = number
-		}
-	}
+	o, _ := EnvOverrides()
+	c.ApplyOverrides(o)
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -238,6 +239,28 @@ func TestGetFieldName_WithMapping_ReturnsActualName(t *testing.T) {
 	}
 }
 
+func TestClosedAndReopenedStatus(t *testing.T) {
+	status := map[string]Field{"status": {Field: "Status", Values: map[string]string{"done": "Shipped", "review": "In Review", "todo": "Todo"}}}
+
+	tests := []struct {
+		name             string
+		cfg              *Config
+		closed, reopened string
+	}{
+		{"configured", &Config{Fields: status, Defaults: Defaults{Status: "todo", ClosedStatus: "review", ReopenedStatus: "Backlog"}}, "In Review", "Backlog"},
+		{"done alias and new issue status", &Config{Fields: status, Defaults: Defaults{Status: "todo"}}, "Shipped", "Todo"},
+		{"nothing configured", &Config{}, "Done", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.ClosedStatus(); got != tt.closed {
+			t.Errorf("%s: ClosedStatus() = %q, want %q", tt.name, got, tt.closed)
+		}
+		if got := tt.cfg.ReopenedStatus(); got != tt.reopened {
+			t.Errorf("%s: ReopenedStatus() = %q, want %q", tt.name, got, tt.reopened)
+		}
+	}
+}
+
 func TestGetFieldName_NoMapping_ReturnsOriginal(t *testing.T) {
 	// ARRANGE: Config with no field mapping
 	cfg := &Config{
@@ -378,3 +401,200 @@ func TestApplyEnvOverrides_NoEnvVars_Unchanged(t *testing.T) {
 		t.Errorf("Expected project number 13, got %d", cfg.Project.Number)
 	}
 }
+
+func TestSave_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	cfg := &Config{
+		Project:      Project{Owner: "acme", Number: 7},
+		Repositories: []string{"acme/api"},
+		Fields: map[string]Field{
+			"status": {Field: "Status", Values: map[string]string{"todo": "To Do"}},
+		},
+	}
+
+	if err := cfg.Save(path); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if loaded.Project.Number != 7 || loaded.Project.Owner != "acme" {
+		t.Errorf("Unexpected project: %+v", loaded.Project)
+	}
+	if got := loaded.ResolveFieldValue("status", "todo"); got != "To Do" {
+		t.Errorf("Expected alias to resolve to 'To Do', got %q", got)
+	}
+}
+
+const profilesConfig = `project:
+  owner: acme
+  number: 1
+repositories:
+  - acme/api
+fields:
+  status:
+    field: Status
+    values:
+      todo: Todo
+      done: Done
+projects:
+  roadmap:
+    owner: acme-org
+    number: 7
+    defaults:
+      status: later
+    fields:
+      status:
+        field: Status
+        values:
+          later: Later
+          now: Now
+    metadata:
+      project:
+        id: PVT_roadmap
+`
+
+func TestForProject_SelectsProfile(t *testing.T) {
+	cfg, err := Load(writeTestConfig(t, profilesConfig))
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+
+	roadmap, err := cfg.ForProject("roadmap")
+	if err != nil {
+		t.Fatalf("ForProject error: %v", err)
+	}
+
+	if roadmap.Project.Owner != "acme-org" || roadmap.Project.Number != 7 {
+		t.Errorf("Expected acme-org #7, got %s #%d", roadmap.Project.Owner, roadmap.Project.Number)
+	}
+	if roadmap.ProfileName() != "roadmap" {
+		t.Errorf("Expected profile name 'roadmap', got %q", roadmap.ProfileName())
+	}
+	if got := roadmap.ResolveFieldValue("status", "now"); got != "Now" {
+		t.Errorf("Expected profile alias 'now' -> 'Now', got %q", got)
+	}
+	if roadmap.Defaults.Status != "later" {
+		t.Errorf("Expected profile default status 'later', got %q", roadmap.Defaults.Status)
+	}
+	if roadmap.Metadata == nil || roadmap.Metadata.Project.ID != "PVT_roadmap" {
+		t.Errorf("Expected profile metadata, got %+v", roadmap.Metadata)
+	}
+	if len(roadmap.Repositories) != 1 {
+		t.Errorf("Expected repositories to be shared, got %v", roadmap.Repositories)
+	}
+
+	// The top-level configuration is unchanged
+	if cfg.Project.Owner != "acme" || cfg.ResolveFieldValue("status", "todo") != "Todo" {
+		t.Errorf("ForProject modified the top-level configuration")
+	}
+}
+
+func TestForProject_EmptyNameSelectsDefault(t *testing.T) {
+	cfg, err := Load(writeTestConfig(t, profilesConfig))
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+
+	selected, err := cfg.ForProject("")
+	if err != nil {
+		t.Fatalf("ForProject error: %v", err)
+	}
+	if selected != cfg {
+		t.Errorf("Expected the top-level project to be selected")
+	}
+}
+
+func TestForProject_OnlyProfileIsDefault(t *testing.T) {
+	cfg := &Config{Projects: map[string]Profile{
+		"team": {Project: Project{Owner: "acme", Number: 3}},
+	}}
+
+	selected, err := cfg.ForProject("")
+	if err != nil {
+		t.Fatalf("ForProject error: %v", err)
+	}
+	if selected.Project.Number != 3 || selected.Project.Name != "team" {
+		t.Errorf("Expected the only profile, got %+v", selected.Project)
+	}
+}
+
+func TestForProject_Errors(t *testing.T) {
+	cfg := &Config{Projects: map[string]Profile{
+		"team":    {Project: Project{Owner: "acme", Number: 3}},
+		"roadmap": {Project: Project{Owner: "acme", Number: 7}},
+	}}
+
+	if _, err := cfg.ForProject(""); err == nil || !strings.Contains(err.Error(), "roadmap, team") {
+		t.Errorf("Expected an error listing the projects, got %v", err)
+	}
+	if _, err := cfg.ForProject("ops"); err == nil || !strings.Contains(err.Error(), `unknown project "ops"`) {
+		t.Errorf("Expected unknown project error, got %v", err)
+	}
+}
+
+func TestSave_KeepsCommentsAndOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	content := `# Board for the API team
+repositories:
+  - acme/api # the main repo
+project:
+  number: 7
+  owner: "acme"
+
+fields:
+  # Aliases for the Status field
+  status:
+    field: Status
+    values:
+      todo: "To Do"
+views:
+  mine:
+    assignee: "@me"
+  stale:
+    status: todo
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	cfg.Fields["status"].Values["done"] = "Done"
+	delete(cfg.Views, "stale")
+	cfg.Views["bugs"] = View{Label: "bug"}
+	if err := cfg.Save(path); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Board for the API team
+repositories:
+  - acme/api # the main repo
+project:
+  number: 7
+  owner: "acme"
+fields:
+  # Aliases for the Status field
+  status:
+    field: Status
+    values:
+      todo: "To Do"
+      done: Done
+views:
+  mine:
+    assignee: "@me"
+  bugs:
+    label: bug
+`
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, data)
+	}
+}
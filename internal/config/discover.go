@@ -0,0 +1,259 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GlobalConfigPath returns the path of the user-global configuration file:
+// $XDG_CONFIG_HOME/gh-pmu/config.yml, or ~/.config/gh-pmu/config.yml.
+// Returns an empty string if the home directory cannot be determined.
+func GlobalConfigPath() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "gh-pmu", "config.yml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh-pmu", "config.yml")
+}
+
+// FindGitRoot returns the nearest directory at or above dir that contains
+// a .git entry, or an empty string if dir is not inside a git repository.
+func FindGitRoot(dir string) string {
+	dir = filepath.Clean(dir)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// projectConfigPaths returns the .gh-pmu.yml files from the git root down to
+// dir, outermost first. Outside a git repository only dir is searched.
+func projectConfigPaths(dir string) []string {
+	dir = filepath.Clean(dir)
+	root := FindGitRoot(dir)
+	if root == "" {
+		root = dir
+	}
+
+	var dirs []string
+	for d := dir; ; d = filepath.Dir(d) {
+		dirs = append([]string{d}, dirs...)
+		if d == root || filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	var paths []string
+	for _, d := range dirs {
+		path := filepath.Join(d, ConfigFileName)
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// ConfigPaths returns the configuration files that apply to dir, in the
+// order they are layered: the user-global config, the repository config at
+// the git root, then any directory configs between the git root and dir.
+func ConfigPaths(dir string) []string {
+	var paths []string
+	if global := GlobalConfigPath(); global != "" {
+		if _, err := os.Stat(global); err == nil {
+			paths = append(paths, global)
+		}
+	}
+	return append(paths, projectConfigPaths(dir)...)
+}
+
+// FindConfigFile returns the .gh-pmu.yml nearest to dir, searching up to
+// the git root. This is the file commands update.
+func FindConfigFile(dir string) (string, error) {
+	paths := projectConfigPaths(dir)
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no %s found in %s or its parent directories: %w", ConfigFileName, dir, os.ErrNotExist)
+	}
+	return paths[len(paths)-1], nil
+}
+
+// LoadFromDirectory loads the layered configuration for dir: the user-global
+// config, the repository and directory .gh-pmu.yml files found up to the git
+// root, then GH_PMU_* environment overrides. Without a .gh-pmu.yml, the
+// environment must name the project owner and number.
+func LoadFromDirectory(dir string) (*Config, error) {
+	o, err := EnvOverrides()
+	if err != nil {
+		return nil, err
+	}
+	return LoadWithOverrides(dir, o)
+}
+
+// LoadLayered loads and merges configuration files; later files take
+// precedence. Mappings such as defaults and field aliases are merged key by
+// key, while lists, triage rules, views, and metadata are replaced as a whole.
+func LoadLayered(paths []string) (*Config, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no configuration files to load")
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	files := make(map[*yaml.Node]string)
+
+	for _, path := range paths {
+		layer, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(layer.node.Content) == 0 {
+			continue // empty file
+		}
+		root := layer.node.Content[0]
+		recordFiles(root, path, files)
+		mergeNodes(merged, root, "")
+	}
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{merged}}
+
+	cfg := Config{
+		path:  paths[len(paths)-1],
+		paths: paths,
+		node:  doc,
+		files: files,
+	}
+	if err := merged.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to merge config files: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// recordFiles maps every node below node to the file it came from
+func recordFiles(node *yaml.Node, path string, files map[*yaml.Node]string) {
+	files[node] = path
+	for _, child := range node.Content {
+		recordFiles(child, path, files)
+	}
+}
+
+// replacedWhole reports whether the value at a key path replaces the lower
+// layer's value instead of being merged into it
+func replacedWhole(path string) bool {
+	return isMetadataKey(path) || strings.HasPrefix(path, "triage.") || strings.HasPrefix(path, "views.")
+}
+
+// isMetadataKey reports whether a key path holds cached project metadata:
+// metadata, or projects.<name>.metadata
+func isMetadataKey(path string) bool {
+	if path == "metadata" {
+		return true
+	}
+	parts := strings.Split(path, ".")
+	return len(parts) == 3 && parts[0] == "projects" && parts[2] == "metadata"
+}
+
+// mergeNodes merges the mapping src into the mapping dst
+func mergeNodes(dst, src *yaml.Node, path string) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		keyPath := joinKey(path, key.Value)
+
+		existing := -1
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == key.Value {
+				existing = j
+				break
+			}
+		}
+
+		switch {
+		case existing < 0:
+			dst.Content = append(dst.Content, key, value)
+		case value.Kind == yaml.MappingNode && dst.Content[existing+1].Kind == yaml.MappingNode && !replacedWhole(keyPath):
+			mergeNodes(dst.Content[existing+1], value, keyPath)
+		default:
+			dst.Content[existing] = key
+			dst.Content[existing+1] = value
+		}
+	}
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Origin describes where an effective configuration value came from
+type Origin struct {
+	Key    string // dotted key path, e.g. "fields.status.values.todo"
+	Value  string
+	Source string // "file:line", "env NAME", or "flag --name"
+}
+
+// Origins returns every effective scalar value with the file and line (or
+// environment variable or flag) it came from. Cached metadata, including that of
+// named projects, is omitted.
+func (c *Config) Origins() []Origin {
+	var origins []Origin
+	if c.node != nil && len(c.node.Content) > 0 {
+		c.collectOrigins(c.node.Content[0], "", &origins)
+	}
+
+	for _, key := range sortedKeys(c.overrideOrigins) {
+		kept := origins[:0]
+		for _, o := range origins {
+			if o.Key != key && !strings.HasPrefix(o.Key, key+"[") {
+				kept = append(kept, o)
+			}
+		}
+		origins = append(kept, c.overrideOrigins[key]...)
+	}
+
+	return origins
+}
+
+func (c *Config) collectOrigins(node *yaml.Node, path string, origins *[]Origin) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyPath := joinKey(path, node.Content[i].Value)
+			if isMetadataKey(keyPath) {
+				continue
+			}
+			c.collectOrigins(node.Content[i+1], keyPath, origins)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			c.collectOrigins(item, path+"["+strconv.Itoa(i)+"]", origins)
+		}
+	case yaml.ScalarNode:
+		*origins = append(*origins, Origin{
+			Key:    path,
+			Value:  node.Value,
+			Source: fmt.Sprintf("%s:%d", c.fileOf(node), node.Line),
+		})
+	}
+}
+
+// fileOf returns the file a node was loaded from
+func (c *Config) fileOf(node *yaml.Node) string {
+	if file, ok := c.files[node]; ok {
+		return file
+	}
+	return c.path
+}
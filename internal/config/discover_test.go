@@ -0,0 +1,232 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupLayeredConfig creates a git repository with a root config, a
+// subdirectory config, and a global config, and returns the subdirectory
+func setupLayeredConfig(t *testing.T) (root, sub string) {
+	t.Helper()
+
+	base := t.TempDir()
+	root = filepath.Join(base, "repo")
+	sub = filepath.Join(root, "services", "api")
+	global := filepath.Join(base, "xdg")
+
+	for _, dir := range []string{filepath.Join(root, ".git"), sub, filepath.Join(global, "gh-pmu")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("XDG_CONFIG_HOME", global)
+
+	files := map[string]string{
+		filepath.Join(global, "gh-pmu", "config.yml"): `defaults:
+  labels:
+    - from-global
+fields:
+  priority:
+    field: Priority
+    values:
+      hi: High
+`,
+		filepath.Join(root, ConfigFileName): `project:
+  owner: acme
+  number: 3
+repositories:
+  - acme/api
+fields:
+  status:
+    field: Status
+    values:
+      todo: Todo
+triage:
+  stale:
+    query: "is:open"
+    apply:
+      labels:
+        - stale
+`,
+		filepath.Join(sub, ConfigFileName): `defaults:
+  status: todo
+fields:
+  status:
+    values:
+      wip: In Progress
+triage:
+  stale:
+    query: "is:open -label:keep"
+`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root, sub
+}
+
+func TestConfigPaths_LayerOrder(t *testing.T) {
+	root, sub := setupLayeredConfig(t)
+
+	paths := ConfigPaths(sub)
+
+	if len(paths) != 3 {
+		t.Fatalf("Expected 3 config files, got %v", paths)
+	}
+	if !strings.HasSuffix(paths[0], filepath.Join("gh-pmu", "config.yml")) {
+		t.Errorf("Expected global config first, got %s", paths[0])
+	}
+	if paths[1] != filepath.Join(root, ConfigFileName) || paths[2] != filepath.Join(sub, ConfigFileName) {
+		t.Errorf("Expected repo then directory config, got %v", paths[1:])
+	}
+}
+
+func TestLoadFromDirectory_MergesLayers(t *testing.T) {
+	_, sub := setupLayeredConfig(t)
+
+	cfg, err := LoadFromDirectory(sub)
+	if err != nil {
+		t.Fatalf("LoadFromDirectory error: %v", err)
+	}
+
+	if cfg.Project.Owner != "acme" || cfg.Project.Number != 3 {
+		t.Errorf("Expected project from repo config, got %+v", cfg.Project)
+	}
+	if cfg.Defaults.Status != "todo" || len(cfg.Defaults.Labels) != 1 || cfg.Defaults.Labels[0] != "from-global" {
+		t.Errorf("Expected defaults merged from global and directory, got %+v", cfg.Defaults)
+	}
+	if cfg.ResolveFieldValue("status", "todo") != "Todo" || cfg.ResolveFieldValue("status", "wip") != "In Progress" {
+		t.Errorf("Expected status aliases merged, got %+v", cfg.Fields["status"])
+	}
+	if cfg.GetFieldName("status") != "Status" {
+		t.Errorf("Expected field name kept from repo config, got %q", cfg.GetFieldName("status"))
+	}
+	if cfg.ResolveFieldValue("priority", "hi") != "High" {
+		t.Error("Expected global priority aliases")
+	}
+
+	// Triage rules are replaced as a whole
+	stale := cfg.Triage["stale"]
+	if stale.Query != "is:open -label:keep" || len(stale.Apply.Labels) != 0 {
+		t.Errorf("Expected directory triage rule to replace repo rule, got %+v", stale)
+	}
+}
+
+func TestLoadFromDirectory_FromSubdirectoryWithoutOwnConfig(t *testing.T) {
+	root, _ := setupLayeredConfig(t)
+	nested := filepath.Join(root, "docs", "guides")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFromDirectory(nested)
+	if err != nil {
+		t.Fatalf("LoadFromDirectory error: %v", err)
+	}
+	if cfg.Project.Number != 3 {
+		t.Errorf("Expected repo config to be found from a subdirectory, got %+v", cfg.Project)
+	}
+
+	path, err := FindConfigFile(nested)
+	if err != nil || path != filepath.Join(root, ConfigFileName) {
+		t.Errorf("FindConfigFile = %q, %v; want repo config", path, err)
+	}
+}
+
+func TestLoadFromDirectory_StopsAtGitRoot(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(base, "xdg"))
+
+	// A config above the git root must not be picked up
+	if err := os.WriteFile(filepath.Join(base, ConfigFileName), []byte("project:\n  owner: outer\n  number: 1\nrepositories:\n  - outer/repo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(base, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadFromDirectory(repo)
+	if err == nil {
+		t.Fatal("Expected error when no config exists inside the repository")
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not-exist error, got %v", err)
+	}
+}
+
+func TestOrigins_ReportsFileAndEnv(t *testing.T) {
+	root, sub := setupLayeredConfig(t)
+	t.Setenv("GH_PM_PROJECT_NUMBER", "9")
+
+	cfg, err := LoadFromDirectory(sub)
+	if err != nil {
+		t.Fatalf("LoadFromDirectory error: %v", err)
+	}
+
+	origins := make(map[string]Origin)
+	for _, o := range cfg.Origins() {
+		origins[o.Key] = o
+	}
+
+	if got := origins["project.owner"].Source; got != filepath.Join(root, ConfigFileName)+":2" {
+		t.Errorf("project.owner origin = %q", got)
+	}
+	if got := origins["fields.status.values.wip"].Source; got != filepath.Join(sub, ConfigFileName)+":6" {
+		t.Errorf("fields.status.values.wip origin = %q", got)
+	}
+	if o := origins["project.number"]; o.Value != "9" || o.Source != "env GH_PM_PROJECT_NUMBER" {
+		t.Errorf("project.number origin = %+v", o)
+	}
+}
+
+func TestCheck_LayeredProblemsNameTheirFile(t *testing.T) {
+	_, sub := setupLayeredConfig(t)
+	if err := os.WriteFile(filepath.Join(sub, ConfigFileName), []byte("defaults:\n  status: bogus\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFromDirectory(sub)
+	if err != nil {
+		t.Fatalf("LoadFromDirectory error: %v", err)
+	}
+
+	problems := cfg.Check()
+	if len(problems) != 1 || problems[0].File != filepath.Join(sub, ConfigFileName) || problems[0].Line != 2 {
+		t.Errorf("Expected problem in directory config on line 2, got %v", problems)
+	}
+}
+
+func TestLoadLayered_ReplacesProfileMetadata(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yml")
+	over := filepath.Join(dir, "over.yml")
+	for path, content := range map[string]string{
+		base: "projects:\n  roadmap:\n    owner: acme\n    number: 7\n    metadata:\n      fields:\n        - name: Old\n          id: F0\n          data_type: TEXT\n",
+		over: "projects:\n  roadmap:\n    metadata:\n      fields:\n        - name: New\n          id: F1\n          data_type: TEXT\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := LoadLayered([]string{base, over})
+	if err != nil {
+		t.Fatalf("LoadLayered error: %v", err)
+	}
+
+	roadmap := cfg.Projects["roadmap"]
+	if roadmap.Owner != "acme" || roadmap.Number != 7 {
+		t.Errorf("Expected profile settings to merge, got %+v", roadmap.Project)
+	}
+	if len(roadmap.Metadata.Fields) != 1 || roadmap.Metadata.Fields[0].Name != "New" {
+		t.Errorf("Expected profile metadata to be replaced, got %+v", roadmap.Metadata.Fields)
+	}
+}
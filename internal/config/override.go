@@ -0,0 +1,261 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables that override configuration files
+const (
+	EnvConfig        = "GH_PMU_CONFIG"         // configuration file to use instead of discovery
+	EnvOwner         = "GH_PMU_OWNER"          // project.owner
+	EnvProjectNumber = "GH_PMU_PROJECT_NUMBER" // project.number
+	EnvRepo          = "GH_PMU_REPO"           // repositories, comma-separated
+	EnvProject       = "GH_PMU_PROJECT"        // named project(s), comma-separated
+	EnvHost          = "GH_HOST"               // host, shared with gh
+
+	// Deprecated names, honored when the GH_PMU_ variables are not set
+	legacyEnvOwner         = "GH_PM_PROJECT_OWNER"
+	legacyEnvProjectNumber = "GH_PM_PROJECT_NUMBER"
+)
+
+// Overrides are settings given in the environment or on the command line.
+// They take precedence over every configuration file.
+type Overrides struct {
+	ConfigPath    string
+	Host          string
+	Owner         string
+	ProjectNumber int
+	Repositories  []string
+
+	// Sources names where each override came from, keyed by config key
+	// ("config", "host", "project.owner", "project.number", "repositories"),
+	// e.g. "env GH_PMU_OWNER" or "flag --owner"
+	Sources map[string]string
+}
+
+// EnvOverrides reads overrides from GH_PMU_* environment variables. An invalid
+// GH_PMU_PROJECT_NUMBER is reported as an error along with the other overrides.
+func EnvOverrides() (Overrides, error) {
+	var o Overrides
+	var err error
+
+	if path := os.Getenv(EnvConfig); path != "" {
+		o.ConfigPath = path
+		o.setSource("config", "env "+EnvConfig)
+	}
+
+	if host := os.Getenv(EnvHost); host != "" {
+		o.Host = host
+		o.setSource("host", "env "+EnvHost)
+	}
+
+	if owner := os.Getenv(EnvOwner); owner != "" {
+		o.Owner = owner
+		o.setSource("project.owner", "env "+EnvOwner)
+	} else if owner := os.Getenv(legacyEnvOwner); owner != "" {
+		o.Owner = owner
+		o.setSource("project.owner", "env "+legacyEnvOwner)
+	}
+
+	if numberStr := os.Getenv(EnvProjectNumber); numberStr != "" {
+		number, convErr := strconv.Atoi(numberStr)
+		if convErr != nil || number <= 0 {
+			err = fmt.Errorf("invalid %s %q: expected a project number", EnvProjectNumber, numberStr)
+		} else {
+			o.ProjectNumber = number
+			o.setSource("project.number", "env "+EnvProjectNumber)
+		}
+	} else if numberStr := os.Getenv(legacyEnvProjectNumber); numberStr != "" {
+		// The deprecated variable has always ignored invalid values
+		if number, convErr := strconv.Atoi(numberStr); convErr == nil {
+			o.ProjectNumber = number
+			o.setSource("project.number", "env "+legacyEnvProjectNumber)
+		}
+	}
+
+	if repos := SplitList(os.Getenv(EnvRepo)); len(repos) > 0 {
+		o.Repositories = repos
+		o.setSource("repositories", "env "+EnvRepo)
+	}
+
+	return o, err
+}
+
+// SplitList splits a comma-separated environment value, dropping empty items
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Merge returns o with the settings present in other taking precedence
+func (o Overrides) Merge(other Overrides) Overrides {
+	merged := o
+	merged.Sources = make(map[string]string)
+	for key, source := range o.Sources {
+		merged.Sources[key] = source
+	}
+	for key, source := range other.Sources {
+		merged.Sources[key] = source
+	}
+
+	if other.ConfigPath != "" {
+		merged.ConfigPath = other.ConfigPath
+	}
+	if other.Host != "" {
+		merged.Host = other.Host
+	}
+	if other.Owner != "" {
+		merged.Owner = other.Owner
+	}
+	if other.ProjectNumber != 0 {
+		merged.ProjectNumber = other.ProjectNumber
+	}
+	if len(other.Repositories) > 0 {
+		merged.Repositories = other.Repositories
+	}
+	return merged
+}
+
+// hasProject reports whether the overrides fully identify a project
+func (o Overrides) hasProject() bool {
+	return o.Owner != "" && o.ProjectNumber != 0
+}
+
+func (o *Overrides) setSource(key, source string) {
+	if o.Sources == nil {
+		o.Sources = make(map[string]string)
+	}
+	o.Sources[key] = source
+}
+
+// LoadWithOverrides loads the layered configuration for dir and applies
+// overrides on top. With o.ConfigPath set, that file is used instead of the
+// .gh-pmu.yml files found up to the git root. When no configuration file
+// exists, the overrides must name a project owner and number.
+func LoadWithOverrides(dir string, o Overrides) (*Config, error) {
+	for _, repo := range o.Repositories {
+		parts := strings.Split(repo, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid repository format %q in %s: expected owner/repo", repo, o.source("repositories"))
+		}
+	}
+
+	var paths []string
+	if global := GlobalConfigPath(); global != "" {
+		if _, err := os.Stat(global); err == nil {
+			paths = append(paths, global)
+		}
+	}
+
+	var projectPaths []string
+	if o.ConfigPath != "" {
+		if _, err := os.Stat(o.ConfigPath); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		projectPaths = []string{o.ConfigPath}
+	} else {
+		projectPaths = projectConfigPaths(dir)
+	}
+	paths = append(paths, projectPaths...)
+
+	var cfg *Config
+	switch {
+	case len(projectPaths) > 0:
+		var err error
+		if cfg, err = LoadLayered(paths); err != nil {
+			return nil, err
+		}
+	case o.hasProject():
+		// No .gh-pmu.yml: run from the overrides (and any global config)
+		cfg = &Config{}
+		if len(paths) > 0 {
+			var err error
+			if cfg, err = LoadLayered(paths); err != nil {
+				return nil, err
+			}
+			cfg.path = ""
+		}
+	default:
+		_, err := FindConfigFile(dir)
+		return nil, fmt.Errorf("failed to read config file: %w (or set %s and %s)", err, EnvOwner, EnvProjectNumber)
+	}
+
+	cfg.paths = paths
+	cfg.ApplyOverrides(o)
+	return cfg, nil
+}
+
+// ApplyOverrides sets the host, project, and repositories from overrides.
+// The project overrides also apply to named projects selected with ForProject.
+func (c *Config) ApplyOverrides(o Overrides) {
+	c.overrides = &o
+	c.applyProjectOverrides()
+
+	if o.Host != "" {
+		c.Host = o.Host
+		c.setOverrideOrigins("host", Origin{Key: "host", Value: o.Host, Source: o.source("host")})
+	}
+
+	if len(o.Repositories) > 0 {
+		c.Repositories = o.Repositories
+		origins := make([]Origin, len(o.Repositories))
+		for i, repo := range o.Repositories {
+			origins[i] = Origin{Key: fmt.Sprintf("repositories[%d]", i), Value: repo, Source: o.source("repositories")}
+		}
+		c.setOverrideOrigins("repositories", origins...)
+	}
+}
+
+// applyProjectOverrides applies the stored owner and number overrides
+func (c *Config) applyProjectOverrides() {
+	o := c.overrides
+	if o == nil {
+		return
+	}
+	if o.Owner != "" {
+		c.Project.Owner = o.Owner
+		c.setOverrideOrigins("project.owner", Origin{Key: "project.owner", Value: o.Owner, Source: o.source("project.owner")})
+	}
+	if o.ProjectNumber != 0 {
+		c.Project.Number = o.ProjectNumber
+		c.setOverrideOrigins("project.number", Origin{Key: "project.number", Value: strconv.Itoa(o.ProjectNumber), Source: o.source("project.number")})
+	}
+}
+
+// setOverrideOrigins records the values an override set for a key. They
+// replace every file value of that key in Origins.
+func (c *Config) setOverrideOrigins(key string, origins ...Origin) {
+	updated := make(map[string][]Origin, len(c.overrideOrigins)+1)
+	for k, v := range c.overrideOrigins {
+		updated[k] = v
+	}
+	updated[key] = origins
+	c.overrideOrigins = updated
+}
+
+func (o Overrides) source(key string) string {
+	if source, ok := o.Sources[key]; ok {
+		return source
+	}
+	return "override"
+}
+
+// Paths returns the configuration files the configuration was loaded from,
+// in layer order
+func (c *Config) Paths() []string {
+	return c.paths
+}
+
+// Path returns the file commands update: the nearest .gh-pmu.yml, or the
+// file given with --config. Empty when running without a configuration file.
+func (c *Config) Path() string {
+	return c.path
+}
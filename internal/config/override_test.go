@@ -0,0 +1,209 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// isolateConfig runs a test without a user-global config or GH_PMU_* variables
+func isolateConfig(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for _, env := range []string{EnvConfig, EnvHost, EnvOwner, EnvProjectNumber, EnvRepo, EnvProject, legacyEnvOwner, legacyEnvProjectNumber} {
+		t.Setenv(env, "")
+	}
+}
+
+func TestEnvOverrides_ReadsVariables(t *testing.T) {
+	isolateConfig(t)
+	t.Setenv(EnvOwner, "acme")
+	t.Setenv(EnvProjectNumber, "7")
+	t.Setenv(EnvRepo, "acme/api, acme/web")
+	t.Setenv(EnvConfig, "ci.yml")
+
+	o, err := EnvOverrides()
+	if err != nil {
+		t.Fatalf("EnvOverrides error: %v", err)
+	}
+
+	if o.Owner != "acme" || o.ProjectNumber != 7 || o.ConfigPath != "ci.yml" {
+		t.Errorf("Unexpected overrides: %+v", o)
+	}
+	if len(o.Repositories) != 2 || o.Repositories[1] != "acme/web" {
+		t.Errorf("Expected two repositories, got %v", o.Repositories)
+	}
+	if o.Sources["project.owner"] != "env GH_PMU_OWNER" {
+		t.Errorf("Expected owner source 'env GH_PMU_OWNER', got %q", o.Sources["project.owner"])
+	}
+}
+
+func TestEnvOverrides_NewVariablesWinOverLegacy(t *testing.T) {
+	isolateConfig(t)
+	t.Setenv(legacyEnvOwner, "old")
+	t.Setenv(EnvOwner, "new")
+
+	o, err := EnvOverrides()
+	if err != nil {
+		t.Fatalf("EnvOverrides error: %v", err)
+	}
+	if o.Owner != "new" {
+		t.Errorf("Expected GH_PMU_OWNER to win, got %q", o.Owner)
+	}
+}
+
+func TestEnvOverrides_InvalidNumber(t *testing.T) {
+	isolateConfig(t)
+	t.Setenv(EnvProjectNumber, "seven")
+
+	if _, err := EnvOverrides(); err == nil || !strings.Contains(err.Error(), EnvProjectNumber) {
+		t.Errorf("Expected error naming %s, got %v", EnvProjectNumber, err)
+	}
+}
+
+func TestOverridesMerge_LaterWins(t *testing.T) {
+	env := Overrides{Owner: "env-owner", ProjectNumber: 3, Sources: map[string]string{"project.owner": "env GH_PMU_OWNER", "project.number": "env GH_PMU_PROJECT_NUMBER"}}
+	flags := Overrides{Owner: "flag-owner", Sources: map[string]string{"project.owner": "flag --owner"}}
+
+	merged := env.Merge(flags)
+
+	if merged.Owner != "flag-owner" || merged.ProjectNumber != 3 {
+		t.Errorf("Unexpected merge result: %+v", merged)
+	}
+	if merged.Sources["project.owner"] != "flag --owner" || merged.Sources["project.number"] != "env GH_PMU_PROJECT_NUMBER" {
+		t.Errorf("Unexpected sources: %v", merged.Sources)
+	}
+}
+
+func TestLoadWithOverrides_WithoutConfigFile(t *testing.T) {
+	isolateConfig(t)
+
+	cfg, err := LoadWithOverrides(t.TempDir(), Overrides{Owner: "acme", ProjectNumber: 7, Repositories: []string{"acme/api"}})
+	if err != nil {
+		t.Fatalf("LoadWithOverrides error: %v", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a usable configuration, got %v", err)
+	}
+	if cfg.Path() != "" {
+		t.Errorf("Expected no config path, got %q", cfg.Path())
+	}
+}
+
+func TestLoadWithOverrides_HostFromEnvironment(t *testing.T) {
+	isolateConfig(t)
+	dir := t.TempDir()
+	content := "host: ghe.example.com\nproject:\n  owner: acme\n  number: 1\nrepositories:\n  - acme/api\n"
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadWithOverrides(dir, Overrides{})
+	if err != nil {
+		t.Fatalf("LoadWithOverrides error: %v", err)
+	}
+	if cfg.Host != "ghe.example.com" {
+		t.Errorf("Expected host from the config file, got %q", cfg.Host)
+	}
+
+	t.Setenv(EnvHost, "ghe.internal")
+	o, err := EnvOverrides()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err = LoadWithOverrides(dir, o); err != nil {
+		t.Fatalf("LoadWithOverrides error: %v", err)
+	}
+	if cfg.Host != "ghe.internal" {
+		t.Errorf("Expected GH_HOST to win, got %q", cfg.Host)
+	}
+	var source string
+	for _, origin := range cfg.Origins() {
+		if origin.Key == "host" {
+			source = origin.Source
+		}
+	}
+	if source != "env GH_HOST" {
+		t.Errorf("Expected host origin 'env GH_HOST', got %q", source)
+	}
+}
+
+func TestLoadWithOverrides_WithoutConfigFileNeedsProject(t *testing.T) {
+	isolateConfig(t)
+
+	_, err := LoadWithOverrides(t.TempDir(), Overrides{Owner: "acme"})
+	if err == nil || !strings.Contains(err.Error(), EnvProjectNumber) {
+		t.Errorf("Expected an error mentioning %s, got %v", EnvProjectNumber, err)
+	}
+}
+
+func TestLoadWithOverrides_ConfigPathReplacesDiscovery(t *testing.T) {
+	isolateConfig(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte("project:\n  owner: discovered\n  number: 1\nrepositories:\n  - acme/api\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	explicit := filepath.Join(t.TempDir(), "ci.yml")
+	if err := os.WriteFile(explicit, []byte("project:\n  owner: explicit\n  number: 2\nrepositories:\n  - acme/api\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadWithOverrides(dir, Overrides{ConfigPath: explicit})
+	if err != nil {
+		t.Fatalf("LoadWithOverrides error: %v", err)
+	}
+
+	if cfg.Project.Owner != "explicit" || cfg.Path() != explicit {
+		t.Errorf("Expected the explicit config, got owner %q from %q", cfg.Project.Owner, cfg.Path())
+	}
+}
+
+func TestLoadWithOverrides_InvalidRepository(t *testing.T) {
+	isolateConfig(t)
+
+	_, err := LoadWithOverrides(t.TempDir(), Overrides{
+		Owner: "acme", ProjectNumber: 1, Repositories: []string{"api"},
+		Sources: map[string]string{"repositories": "flag --repo"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `invalid repository format "api" in flag --repo`) {
+		t.Errorf("Expected invalid repository error, got %v", err)
+	}
+}
+
+func TestApplyOverrides_OriginsAndProfiles(t *testing.T) {
+	isolateConfig(t)
+	cfg, err := LoadLayered([]string{writeTestConfig(t, profilesConfig)})
+	if err != nil {
+		t.Fatalf("LoadLayered error: %v", err)
+	}
+
+	cfg.ApplyOverrides(Overrides{
+		ProjectNumber: 42,
+		Repositories:  []string{"acme/web"},
+		Sources:       map[string]string{"project.number": "flag --project-number", "repositories": "env GH_PMU_REPO"},
+	})
+
+	sources := make(map[string]string)
+	for _, o := range cfg.Origins() {
+		sources[o.Key+"="+o.Value] = o.Source
+	}
+	if sources["project.number=42"] != "flag --project-number" {
+		t.Errorf("Expected project.number from flag, got %v", sources)
+	}
+	if sources["repositories[0]=acme/web"] != "env GH_PMU_REPO" {
+		t.Errorf("Expected repositories from env, got %v", sources)
+	}
+	if _, ok := sources["repositories[0]=acme/api"]; ok {
+		t.Errorf("Expected the file repositories to be replaced, got %v", sources)
+	}
+
+	roadmap, err := cfg.ForProject("roadmap")
+	if err != nil {
+		t.Fatalf("ForProject error: %v", err)
+	}
+	if roadmap.Project.Number != 42 || roadmap.Project.Owner != "acme-org" {
+		t.Errorf("Expected overrides to apply to the selected project, got %+v", roadmap.Project)
+	}
+}
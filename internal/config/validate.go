@@ -0,0 +1,442 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a configuration error at a position in the config file
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// String formats the problem as "file:line:column: message"
+func (p Problem) String() string {
+	var pos []string
+	if p.File != "" {
+		pos = append(pos, p.File)
+	}
+	if p.Line > 0 {
+		pos = append(pos, fmt.Sprintf("%d:%d", p.Line, p.Column))
+	}
+	if len(pos) == 0 {
+		return p.Message
+	}
+	return strings.Join(pos, ":") + ": " + p.Message
+}
+
+// ValidationError reports one or more configuration problems
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = p.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// triageQualifiers are the search qualifiers accepted in triage queries
+var triageQualifiers = map[string]bool{
+	"is": true, "label": true, "no": true, "has": true, "assignee": true,
+	"author": true, "mentions": true, "milestone": true, "state": true,
+	"reason": true, "type": true, "created": true, "updated": true,
+	"closed": true, "comments": true, "in": true, "repo": true, "org": true,
+	"user": true, "sort": true, "project": true, "linked": true,
+}
+
+// ValidateTriageQuery checks that a triage query is made of well-formed
+// search terms: free text, "quoted text", or [-]qualifier:value.
+func ValidateTriageQuery(query string) error {
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("query is empty")
+	}
+
+	terms, err := splitQuery(query)
+	if err != nil {
+		return err
+	}
+
+	for _, term := range terms {
+		idx := strings.Index(term, ":")
+		if idx < 0 || strings.HasPrefix(term, "\"") {
+			continue // free text
+		}
+		qualifier := strings.TrimPrefix(term[:idx], "-")
+		value := term[idx+1:]
+		if !triageQualifiers[strings.ToLower(qualifier)] {
+			return fmt.Errorf("unknown qualifier %q in %q", qualifier, term)
+		}
+		if value == "" || value == "\"\"" {
+			return fmt.Errorf("qualifier %q has no value", qualifier)
+		}
+	}
+
+	return nil
+}
+
+// splitQuery splits a query into whitespace-separated terms, keeping quoted text together
+func splitQuery(query string) ([]string, error) {
+	var terms []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms, nil
+}
+
+// Check validates the configuration and returns every problem found,
+// including references to fields and options missing from the cached
+// project metadata. Load only rejects structural problems.
+func (c *Config) Check() []Problem {
+	problems := c.checkStructure()
+	problems = append(problems, c.checkRequired()...)
+	problems = append(problems, c.checkReferences()...)
+	sortProblems(problems)
+	return problems
+}
+
+// problem creates a problem located at node (which may be nil)
+func (c *Config) problem(node *yaml.Node, format string, args ...interface{}) Problem {
+	p := Problem{File: c.path, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		p.File = c.fileOf(node)
+		p.Line, p.Column = node.Line, node.Column
+	}
+	return p
+}
+
+// nodeAt returns the node at a path of mapping keys and sequence indexes
+// below the document root, or the deepest node found along the way
+func (c *Config) nodeAt(path ...interface{}) *yaml.Node {
+	if c.node == nil || len(c.node.Content) == 0 {
+		return nil
+	}
+	node := c.node.Content[0]
+	for _, step := range path {
+		var next *yaml.Node
+		switch s := step.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == s {
+						next = node.Content[i+1]
+						break
+					}
+				}
+			}
+		case int:
+			if node.Kind == yaml.SequenceNode && s < len(node.Content) {
+				next = node.Content[s]
+			}
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
+
+// checkRequired reports missing required settings
+func (c *Config) checkRequired() []Problem {
+	var problems []Problem
+	// The top-level project is optional when named projects are configured
+	if len(c.Projects) == 0 || c.Project.Owner != "" || c.Project.Number != 0 {
+		if c.Project.Owner == "" {
+			problems = append(problems, c.problem(c.nodeAt("project"), "project.owner is required"))
+		}
+		if c.Project.Number == 0 {
+			problems = append(problems, c.problem(c.nodeAt("project"), "project.number is required"))
+		}
+	}
+	for _, name := range sortedKeys(c.Projects) {
+		profile := c.Projects[name]
+		if profile.Owner == "" {
+			problems = append(problems, c.problem(c.nodeAt("projects", name), "projects.%s.owner is required", name))
+		}
+		if profile.Number == 0 {
+			problems = append(problems, c.problem(c.nodeAt("projects", name), "projects.%s.number is required", name))
+		}
+	}
+	if len(c.Repositories) == 0 {
+		problems = append(problems, c.problem(c.nodeAt("repositories"), "at least one repository is required"))
+	}
+	return problems
+}
+
+// checkStructure reports problems that make the configuration unusable
+func (c *Config) checkStructure() []Problem {
+	var problems []Problem
+
+	if c.Host != "" && strings.ContainsAny(c.Host, ":/ ") {
+		problems = append(problems, c.problem(c.nodeAt("host"), "invalid host %q: expected a hostname like github.example.com", c.Host))
+	}
+
+	for i, repo := range c.Repositories {
+		parts := strings.Split(repo, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			problems = append(problems, c.problem(c.nodeAt("repositories", i), "invalid repository format %q: expected owner/repo", repo))
+		}
+	}
+
+	for _, name := range sortedKeys(c.Triage) {
+		if err := ValidateTriageQuery(c.Triage[name].Query); err != nil {
+			problems = append(problems, c.problem(c.nodeAt("triage", name, "query"), "triage rule %q: invalid query: %v", name, err))
+		}
+	}
+
+	for _, name := range sortedKeys(c.Views) {
+		view := c.Views[name]
+		if _, err := splitQuery(view.Filter); err != nil {
+			problems = append(problems, c.problem(c.nodeAt("views", name, "filter"), "view %q: invalid filter: %v", name, err))
+		}
+		if view.Limit < 0 {
+			problems = append(problems, c.problem(c.nodeAt("views", name, "limit"), "view %q: limit must not be negative", name))
+		}
+		if view.AllRepos && len(view.Repos) > 0 {
+			problems = append(problems, c.problem(c.nodeAt("views", name, "all_repos"), "view %q: cannot use repos with all_repos", name))
+		}
+	}
+
+	return problems
+}
+
+// checkReferences reports aliases, defaults, views, and triage actions that
+// refer to fields or options that do not exist
+func (c *Config) checkReferences() []Problem {
+	problems := c.checkAliases(c.Fields, c.Metadata, "fields")
+	for _, name := range sortedKeys(c.Projects) {
+		profile := c.Projects[name]
+		problems = append(problems, c.checkAliases(profile.Fields, profile.Metadata, "projects", name, "fields")...)
+	}
+
+	if c.Defaults.Status != "" {
+		if p := c.checkValue("status", c.Defaults.Status, c.nodeAt("defaults", "status"), "defaults.status"); p != nil {
+			problems = append(problems, *p)
+		}
+	}
+	if c.Defaults.Priority != "" {
+		if p := c.checkValue("priority", c.Defaults.Priority, c.nodeAt("defaults", "priority"), "defaults.priority"); p != nil {
+			problems = append(problems, *p)
+		}
+	}
+	for _, d := range []struct{ key, value string }{
+		{"closed_status", c.Defaults.ClosedStatus},
+		{"reopened_status", c.Defaults.ReopenedStatus},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if p := c.checkValue("status", d.value, c.nodeAt("defaults", d.key), "defaults."+d.key); p != nil {
+			problems = append(problems, *p)
+		}
+	}
+
+	for _, name := range sortedKeys(c.Views) {
+		view := c.Views[name]
+		if view.Status != "" {
+			if p := c.checkValue("status", view.Status, c.nodeAt("views", name, "status"), fmt.Sprintf("views.%s.status", name)); p != nil {
+				problems = append(problems, *p)
+			}
+		}
+		if view.Priority != "" {
+			if p := c.checkValue("priority", view.Priority, c.nodeAt("views", name, "priority"), fmt.Sprintf("views.%s.priority", name)); p != nil {
+				problems = append(problems, *p)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(c.Triage) {
+		fields := c.Triage[name].Apply.Fields
+		for _, key := range sortedKeys(fields) {
+			node := c.nodeAt("triage", name, "apply", "fields", key)
+			if p := c.checkValue(key, fields[key], node, fmt.Sprintf("triage.%s.apply.fields.%s", name, key)); p != nil {
+				problems = append(problems, *p)
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkAliases reports field aliases that are incomplete or refer to fields
+// and options missing from metadata. path is the key path of the fields map.
+func (c *Config) checkAliases(fields map[string]Field, metadata *Metadata, path ...string) []Problem {
+	var problems []Problem
+	setting := strings.Join(path, ".")
+	at := func(keys ...string) *yaml.Node {
+		steps := make([]interface{}, 0, len(path)+len(keys))
+		for _, p := range append(append([]string{}, path...), keys...) {
+			steps = append(steps, p)
+		}
+		return c.nodeAt(steps...)
+	}
+
+	for _, key := range sortedKeys(fields) {
+		group := fields[key]
+		if group.Field == "" {
+			problems = append(problems, c.problem(at(key), "%s.%s: field name is required", setting, key))
+			continue
+		}
+
+		meta, ok := metadata.field(group.Field)
+		if !ok {
+			if metadata != nil && len(metadata.Fields) > 0 {
+				problems = append(problems, c.problem(at(key, "field"), "%s.%s: field %q not found in project metadata", setting, key, group.Field))
+			}
+			continue
+		}
+		if meta.DataType != "SINGLE_SELECT" {
+			continue
+		}
+
+		for _, alias := range sortedKeys(group.Values) {
+			value := group.Values[alias]
+			if !meta.hasOption(value) {
+				problems = append(problems, c.problem(at(key, "values", alias),
+					"%s.%s.values.%s: %q is not an option of field %q", setting, key, alias, value, meta.Name))
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkValue checks that value (an alias or an option name) is valid for the field key
+func (c *Config) checkValue(fieldKey, value string, node *yaml.Node, setting string) *Problem {
+	resolved := c.ResolveFieldValue(fieldKey, value)
+	fieldName := c.GetFieldName(fieldKey)
+
+	if meta, ok := c.fieldMetadata(fieldName); ok {
+		if meta.DataType == "SINGLE_SELECT" && !meta.hasOption(resolved) {
+			p := c.problem(node, "%s: unknown value %q for field %q", setting, value, meta.Name)
+			return &p
+		}
+		return nil
+	}
+
+	// Without metadata, the value must at least be a known alias or aliased value
+	if group, ok := c.Fields[fieldKey]; ok && len(group.Values) > 0 {
+		if _, isAlias := group.Values[value]; isAlias {
+			return nil
+		}
+		for _, v := range group.Values {
+			if v == value {
+				return nil
+			}
+		}
+		p := c.problem(node, "%s: unknown value %q (not an alias in fields.%s)", setting, value, fieldKey)
+		return &p
+	}
+
+	return nil
+}
+
+// fieldMetadata returns the cached metadata for a field by name
+func (c *Config) fieldMetadata(name string) (FieldMetadata, bool) {
+	return c.Metadata.field(name)
+}
+
+// field returns the metadata for a field by name; m may be nil
+func (m *Metadata) field(name string) (FieldMetadata, bool) {
+	if m == nil {
+		return FieldMetadata{}, false
+	}
+	for _, f := range m.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return FieldMetadata{}, false
+}
+
+func (f FieldMetadata) hasOption(name string) bool {
+	for _, opt := range f.Options {
+		if opt.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeProblems converts yaml decoding errors ("line 3: field foo not found in
+// type config.Config") into problems
+func decodeProblems(path string, err error) []Problem {
+	typeErr, ok := err.(*yaml.TypeError)
+	if !ok {
+		return []Problem{{File: path, Message: err.Error()}}
+	}
+
+	var problems []Problem
+	for _, msg := range typeErr.Errors {
+		p := Problem{File: path, Message: msg}
+		var line int
+		if _, scanErr := fmt.Sscanf(msg, "line %d:", &line); scanErr == nil {
+			p.Line, p.Column = line, 1
+			p.Message = strings.TrimSpace(msg[strings.Index(msg, ":")+1:])
+		}
+		p.Message = strings.Replace(p.Message, "in type config.", "in ", 1)
+		problems = append(problems, p)
+	}
+	return problems
+}
+
+// columnAt returns the column of the first node that starts on line, or 0
+func columnAt(node *yaml.Node, line int) int {
+	if node.Line == line && node.Kind == yaml.ScalarNode {
+		return node.Column
+	}
+	for _, child := range node.Content {
+		if col := columnAt(child, line); col > 0 {
+			return col
+		}
+	}
+	return 0
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+}
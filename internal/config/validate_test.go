@@ -0,0 +1,319 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_UnknownKey_ReportsPosition(t *testing.T) {
+	path := writeTestConfig(t, `project:
+  owner: acme
+  number: 1
+  nmae: typo
+repositories:
+  - acme/api
+`)
+
+	_, err := Load(path)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Problems) != 1 {
+		t.Fatalf("Expected 1 problem, got %v", validationErr.Problems)
+	}
+	want := path + ":4:3: field nmae not found"
+	if !strings.HasPrefix(validationErr.Problems[0].String(), want) {
+		t.Errorf("Expected %q, got %q", want, validationErr.Problems[0].String())
+	}
+}
+
+func TestLoad_InvalidRepositoryAndQuery(t *testing.T) {
+	path := writeTestConfig(t, `project:
+  owner: acme
+  number: 1
+repositories:
+  - acme
+triage:
+  broken:
+    query: "is:open \"unterminated"
+`)
+
+	_, err := Load(path)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", validationErr.Problems)
+	}
+	if validationErr.Problems[0].Line != 5 || !strings.Contains(validationErr.Problems[0].Message, "invalid repository format") {
+		t.Errorf("Unexpected repository problem: %v", validationErr.Problems[0])
+	}
+	if validationErr.Problems[1].Line != 8 || !strings.Contains(validationErr.Problems[1].Message, "unterminated quote") {
+		t.Errorf("Unexpected query problem: %v", validationErr.Problems[1])
+	}
+}
+
+func TestLoad_InvalidHost(t *testing.T) {
+	path := writeTestConfig(t, `host: https://ghe.example.com
+project:
+  owner: acme
+  number: 1
+repositories:
+  - acme/api
+`)
+
+	_, err := Load(path)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Problems) != 1 || validationErr.Problems[0].Line != 1 || !strings.Contains(validationErr.Problems[0].Message, "invalid host") {
+		t.Errorf("Unexpected problems: %v", validationErr.Problems)
+	}
+}
+
+func TestCheck_ReferencesAgainstMetadata(t *testing.T) {
+	path := writeTestConfig(t, `project:
+  owner: acme
+  number: 1
+repositories:
+  - acme/api
+defaults:
+  status: bogus
+  priority: p1
+fields:
+  status:
+    field: Status
+    values:
+      todo: Todo
+      wip: WIP
+  priority:
+    field: Priority
+    values:
+      p1: P1
+  size:
+    field: Size
+triage:
+  tracked:
+    query: "is:issue is:open -label:pm-tracked"
+    apply:
+      fields:
+        status: todo
+        priority: p9
+metadata:
+  fields:
+    - name: Status
+      id: f1
+      data_type: SINGLE_SELECT
+      options:
+        - name: Todo
+          id: o1
+    - name: Priority
+      id: f2
+      data_type: SINGLE_SELECT
+      options:
+        - name: P1
+          id: o2
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+
+	problems := cfg.Check()
+
+	want := []string{
+		`7:11: defaults.status: unknown value "bogus"`,
+		`14:12: fields.status.values.wip: "WIP" is not an option`,
+		`20:12: fields.size: field "Size" not found in project metadata`,
+		`27:19: triage.tracked.apply.fields.priority: unknown value "p9"`,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %d: %v", len(want), len(problems), problems)
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(problems[i].String(), path+":"+w) {
+			t.Errorf("problem %d = %q, want prefix %q", i, problems[i].String(), w)
+		}
+	}
+}
+
+func TestCheck_WithoutMetadataUsesAliases(t *testing.T) {
+	cfg := &Config{
+		Project:      Project{Owner: "acme", Number: 1},
+		Repositories: []string{"acme/api"},
+		Defaults:     Defaults{Status: "backlog", Priority: "P2", ClosedStatus: "Todo", ReopenedStatus: "reopened"},
+		Fields: map[string]Field{
+			"status":   {Field: "Status", Values: map[string]string{"todo": "Todo"}},
+			"priority": {Field: "Priority", Values: map[string]string{"p2": "P2"}},
+		},
+	}
+
+	problems := cfg.Check()
+	if len(problems) != 2 || !strings.Contains(problems[0].Message, `defaults.status: unknown value "backlog"`) ||
+		!strings.Contains(problems[1].Message, `defaults.reopened_status: unknown value "reopened"`) {
+		t.Errorf("Expected the unknown status and reopened status defaults, got %v", problems)
+	}
+}
+
+func TestCheck_ValidConfigFile(t *testing.T) {
+	cfg, err := Load(filepath.Join("..", "..", "testdata", "config", "valid.gh-pmu.yml"))
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if problems := cfg.Check(); len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateTriageQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{"is:issue is:open -label:pm-tracked", false},
+		{`label:"help wanted" crash`, false},
+		{"", true},
+		{"lable:bug", true},
+		{"label:", true},
+		{`label:"oops`, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateTriageQuery(tt.query)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateTriageQuery(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheck_ProjectProfiles(t *testing.T) {
+	cfg, err := Load(writeTestConfig(t, `repositories:
+  - acme/api
+projects:
+  roadmap:
+    owner: acme
+    fields:
+      status:
+        field: Status
+        values:
+          later: Someday
+    metadata:
+      fields:
+        - name: Status
+          id: F1
+          data_type: SINGLE_SELECT
+          options:
+            - name: Later
+              id: O1
+`))
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+
+	var messages []string
+	for _, p := range cfg.Check() {
+		messages = append(messages, p.Message)
+	}
+	got := strings.Join(messages, "\n")
+
+	if strings.Contains(got, "project.owner is required") {
+		t.Errorf("Top-level project should be optional with named projects:\n%s", got)
+	}
+	for _, want := range []string{
+		"projects.roadmap.number is required",
+		`projects.roadmap.fields.status.values.later: "Someday" is not an option of field "Status"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected problem %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestCheck_Views(t *testing.T) {
+	path := writeTestConfig(t, `project:
+  owner: acme
+  number: 1
+repositories:
+  - acme/api
+fields:
+  status:
+    field: Status
+    values:
+      wip: In progress
+views:
+  my-wip:
+    status: wip
+    assignee: octocat
+    columns: [number, title, priority]
+  stale:
+    status: blocked
+    limit: 5
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if got := cfg.ViewNames(); len(got) != 2 || got[0] != "my-wip" || got[1] != "stale" {
+		t.Errorf("Unexpected view names %v", got)
+	}
+	if view := cfg.Views["my-wip"]; view.Status != "wip" || len(view.Columns) != 3 {
+		t.Errorf("Unexpected view %+v", view)
+	}
+
+	problems := cfg.Check()
+	if len(problems) != 1 || !strings.HasPrefix(problems[0].String(), path+`:17:13: views.stale.status: unknown value "blocked"`) {
+		t.Errorf("Expected the unknown status, got %v", problems)
+	}
+}
+
+func TestLoad_InvalidView(t *testing.T) {
+	path := writeTestConfig(t, `project:
+  owner: acme
+  number: 1
+repositories:
+  - acme/api
+views:
+  broken:
+    filter: "status:\"In progress"
+    limit: -1
+    repos: [acme/api]
+    all_repos: true
+    colums: [number]
+`)
+
+	_, err := Load(path)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	want := []string{"invalid filter: unterminated quote", "limit must not be negative", "cannot use repos with all_repos", "field colums not found"}
+	if len(validationErr.Problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %v", len(want), validationErr.Problems)
+	}
+	for _, w := range want {
+		if !strings.Contains(validationErr.Error(), w) {
+			t.Errorf("Expected a problem containing %q, got %v", w, validationErr.Problems)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+// Package issueform parses GitHub issue templates and renders issue bodies
+// from them.
+//
+// Two kinds of template live in a repository's .github/ISSUE_TEMPLATE
+// directory: issue forms, YAML files whose body is a list of markdown,
+// input, textarea, dropdown, and checkboxes elements, and Markdown templates,
+// whose YAML front matter holds the name, title, labels, and assignees and
+// whose content is the issue body. Answers to a form's elements are rendered
+// into the body the same way GitHub renders a submitted form.
+package issueform
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Element types of issue forms
+const (
+	TypeMarkdown   = "markdown"
+	TypeInput      = "input"
+	TypeTextarea   = "textarea"
+	TypeDropdown   = "dropdown"
+	TypeCheckboxes = "checkboxes"
+)
+
+// NoResponse is rendered for form elements left empty
+const NoResponse = "_No response_"
+
+// Template is an issue form or Markdown issue template
+type Template struct {
+	// File is the template's file name, such as bug_report.yml
+	File        string     `yaml:"-"`
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description"`
+	About       string     `yaml:"about"`
+	Title       string     `yaml:"title"`
+	Labels      stringList `yaml:"labels"`
+	Assignees   stringList `yaml:"assignees"`
+	Type        string     `yaml:"type"`
+	Body        []Element  `yaml:"body"`
+
+	// Markdown is the body of a Markdown template; empty for forms
+	Markdown string `yaml:"-"`
+}
+
+// Element is one element of an issue form's body
+type Element struct {
+	Type        string      `yaml:"type"`
+	ID          string      `yaml:"id"`
+	Attributes  Attributes  `yaml:"attributes"`
+	Validations Validations `yaml:"validations"`
+}
+
+// Attributes configure a form element
+type Attributes struct {
+	Label       string   `yaml:"label"`
+	Description string   `yaml:"description"`
+	Placeholder string   `yaml:"placeholder"`
+	Value       string   `yaml:"value"`
+	Render      string   `yaml:"render"`
+	Multiple    bool     `yaml:"multiple"`
+	Options     []Option `yaml:"options"`
+	Default     *int     `yaml:"default"`
+}
+
+// Validations hold a form element's validation rules
+type Validations struct {
+	Required bool `yaml:"required"`
+}
+
+// Option is a dropdown option, written as a string, or a checkbox, written
+// as a mapping with a label
+type Option struct {
+	Label    string `yaml:"label"`
+	Required bool   `yaml:"required"`
+}
+
+// UnmarshalYAML accepts an option as a plain string or a mapping
+func (o *Option) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.Label = node.Value
+		return nil
+	}
+	type plain Option
+	return node.Decode((*plain)(o))
+}
+
+// stringList is a list written either as a YAML sequence or as a
+// comma-separated string, as labels and assignees are in templates
+type stringList []string
+
+// UnmarshalYAML accepts a sequence or a comma-separated string
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	var items []string
+	if node.Kind == yaml.ScalarNode {
+		items = strings.Split(node.Value, ",")
+	} else if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = nil
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// IsTemplateFile reports whether a file in .github/ISSUE_TEMPLATE is an issue
+// template, rather than the template chooser's config.yml
+func IsTemplateFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yml", ".yaml":
+		base := strings.ToLower(strings.TrimSuffix(name, path.Ext(name)))
+		return base != "config"
+	case ".md":
+		return true
+	}
+	return false
+}
+
+// Parse parses the issue template in file, a form if its name ends in .yml
+// or .yaml and a Markdown template otherwise
+func Parse(file string, data []byte) (*Template, error) {
+	var t *Template
+	var err error
+	switch strings.ToLower(path.Ext(file)) {
+	case ".yml", ".yaml":
+		t, err = parseForm(data)
+	default:
+		t, err = parseMarkdown(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	t.File = file
+	return t, nil
+}
+
+func parseForm(data []byte) (*Template, error) {
+	t := &Template{}
+	if err := yaml.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("invalid issue form: %w", err)
+	}
+	if t.Name == "" {
+		return nil, fmt.Errorf("issue form has no name")
+	}
+	if len(t.Body) == 0 {
+		return nil, fmt.Errorf("issue form %q has no body", t.Name)
+	}
+
+	keys := make(map[string]bool)
+	for i, e := range t.Body {
+		switch e.Type {
+		case TypeMarkdown:
+			continue
+		case TypeInput, TypeTextarea:
+		case TypeDropdown, TypeCheckboxes:
+			if len(e.Attributes.Options) == 0 {
+				return nil, fmt.Errorf("body[%d]: %s %q has no options", i, e.Type, e.Attributes.Label)
+			}
+		default:
+			return nil, fmt.Errorf("body[%d]: unknown element type %q", i, e.Type)
+		}
+		if e.Attributes.Label == "" {
+			return nil, fmt.Errorf("body[%d]: %s has no label", i, e.Type)
+		}
+		if d := e.Attributes.Default; d != nil && (*d < 0 || *d >= len(e.Attributes.Options)) {
+			return nil, fmt.Errorf("body[%d]: default %d is not an option of %q", i, *d, e.Attributes.Label)
+		}
+		key := strings.ToLower(e.Key())
+		if keys[key] {
+			return nil, fmt.Errorf("body[%d]: duplicate id %q", i, e.Key())
+		}
+		keys[key] = true
+	}
+	return t, nil
+}
+
+func parseMarkdown(data []byte) (*Template, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	t := &Template{}
+
+	if rest, ok := strings.CutPrefix(text, "---\n"); ok {
+		frontMatter, body, found := strings.Cut(rest, "\n---")
+		if !found {
+			return nil, fmt.Errorf("front matter is not closed with ---")
+		}
+		if err := yaml.Unmarshal([]byte(frontMatter), t); err != nil {
+			return nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+		_, body, _ = strings.Cut(body, "\n")
+		text = body
+	}
+	t.Body = nil
+	t.Markdown = strings.TrimLeft(text, "\n")
+	return t, nil
+}
+
+// IsForm reports whether the template is an issue form
+func (t *Template) IsForm() bool {
+	return t.Markdown == "" && len(t.Body) > 0
+}
+
+// DisplayName returns the template's name, or its file name without the
+// extension when it has none
+func (t *Template) DisplayName() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return strings.TrimSuffix(t.File, path.Ext(t.File))
+}
+
+// Matches reports whether name refers to the template, by its file name
+// with or without the extension, or by its name
+func (t *Template) Matches(name string) bool {
+	return strings.EqualFold(name, t.File) ||
+		strings.EqualFold(name, strings.TrimSuffix(t.File, path.Ext(t.File))) ||
+		(t.Name != "" && strings.EqualFold(name, t.Name))
+}
+
+// Inputs returns the form elements that take answers, leaving out markdown
+func (t *Template) Inputs() []Element {
+	var inputs []Element
+	for _, e := range t.Body {
+		if e.Type != TypeMarkdown {
+			inputs = append(inputs, e)
+		}
+	}
+	return inputs
+}
+
+// Input returns the form element whose id or label is key
+func (t *Template) Input(key string) (Element, bool) {
+	for _, e := range t.Inputs() {
+		if strings.EqualFold(e.ID, key) || strings.EqualFold(e.Attributes.Label, key) {
+			return e, true
+		}
+	}
+	return Element{}, false
+}
+
+// Key returns the element's id, or its label when it has none
+func (e Element) Key() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.Attributes.Label
+}
+
+// OptionLabels returns the labels of a dropdown's or checkboxes' options
+func (e Element) OptionLabels() []string {
+	labels := make([]string, len(e.Attributes.Options))
+	for i, o := range e.Attributes.Options {
+		labels[i] = o.Label
+	}
+	return labels
+}
+
+// Defaults returns the answer the element starts with: its value, or its
+// default dropdown option
+func (e Element) Defaults() []string {
+	switch e.Type {
+	case TypeInput, TypeTextarea:
+		if e.Attributes.Value != "" {
+			return []string{e.Attributes.Value}
+		}
+	case TypeDropdown:
+		if d := e.Attributes.Default; d != nil {
+			return []string{e.Attributes.Options[*d].Label}
+		}
+	}
+	return nil
+}
+
+// ParseAnswer converts a typed answer to the element's values: the text of
+// an input or textarea, or the options of a dropdown or checkboxes, given by
+// label or number and separated by commas
+func (e Element) ParseAnswer(answer string) ([]string, error) {
+	switch e.Type {
+	case TypeInput, TypeTextarea:
+		if answer == "" {
+			return nil, nil
+		}
+		return []string{answer}, nil
+	}
+
+	var values []string
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		option, ok := e.option(part)
+		if !ok {
+			return nil, fmt.Errorf("%q is not an option of %q: expected one of %s", part, e.Attributes.Label, strings.Join(e.OptionLabels(), ", "))
+		}
+		if !contains(values, option) {
+			values = append(values, option)
+		}
+	}
+	if e.Type == TypeDropdown && !e.Attributes.Multiple && len(values) > 1 {
+		return nil, fmt.Errorf("%q takes one option", e.Attributes.Label)
+	}
+	return values, nil
+}
+
+// option returns the option labelled or numbered (from 1) by s
+func (e Element) option(s string) (string, bool) {
+	for _, o := range e.Attributes.Options {
+		if strings.EqualFold(o.Label, s) {
+			return o.Label, true
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= len(e.Attributes.Options) {
+		return e.Attributes.Options[n-1].Label, true
+	}
+	return "", false
+}
+
+// Check reports an error when values do not satisfy the element's
+// validations: required elements need a value, and required checkboxes must
+// be checked
+func (e Element) Check(values []string) error {
+	if e.Type == TypeCheckboxes {
+		for _, o := range e.Attributes.Options {
+			if o.Required && !contains(values, o.Label) {
+				return fmt.Errorf("%q must be checked in %q", o.Label, e.Attributes.Label)
+			}
+		}
+		return nil
+	}
+	if e.Validations.Required && len(values) == 0 {
+		return fmt.Errorf("%q is required", e.Attributes.Label)
+	}
+	return nil
+}
+
+// Render renders the issue body from answers keyed by element key, as
+// GitHub does for a submitted form: a "### Label" heading per element
+// followed by its value, or _No response_ when it has none. Markdown
+// templates render their content unchanged.
+func (t *Template) Render(answers map[string][]string) string {
+	if !t.IsForm() {
+		return t.Markdown
+	}
+
+	var sections []string
+	for _, e := range t.Inputs() {
+		values := answers[e.Key()]
+		sections = append(sections, "### "+e.Attributes.Label, renderValue(e, values))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+func renderValue(e Element, values []string) string {
+	switch e.Type {
+	case TypeCheckboxes:
+		lines := make([]string, len(e.Attributes.Options))
+		for i, o := range e.Attributes.Options {
+			mark := " "
+			if contains(values, o.Label) {
+				mark = "X"
+			}
+			lines[i] = fmt.Sprintf("- [%s] %s", mark, o.Label)
+		}
+		return strings.Join(lines, "\n")
+	case TypeDropdown:
+		if len(values) == 0 {
+			return NoResponse
+		}
+		return strings.Join(values, ", ")
+	}
+
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return NoResponse
+	}
+	if e.Type == TypeTextarea && e.Attributes.Render != "" {
+		return "```" + e.Attributes.Render + "\n" + values[0] + "\n```"
+	}
+	return values[0]
+}
+
+// IssueTitle applies the template's title prefix to title, unless title
+// already starts with it
+func (t *Template) IssueTitle(title string) string {
+	if t.Title == "" || strings.HasPrefix(title, t.Title) {
+		return title
+	}
+	return t.Title + title
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
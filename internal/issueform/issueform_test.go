@@ -0,0 +1,222 @@
+package issueform
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const bugForm = `name: Bug Report
+description: File a bug report
+title: "[Bug]: "
+labels: ["bug", "triage"]
+assignees:
+  - octocat
+type: Bug
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time to fill out this bug report!
+  - type: input
+    id: contact
+    attributes:
+      label: Contact Details
+      placeholder: ex. email@example.com
+  - type: textarea
+    id: what-happened
+    attributes:
+      label: What happened?
+      value: "A bug happened!"
+    validations:
+      required: true
+  - type: dropdown
+    id: version
+    attributes:
+      label: Version
+      options:
+        - 1.0.2 (Default)
+        - 1.0.3 (Edge)
+      default: 0
+  - type: dropdown
+    id: browsers
+    attributes:
+      label: What browsers are you seeing the problem on?
+      multiple: true
+      options: [Firefox, Chrome, Safari]
+  - type: textarea
+    id: logs
+    attributes:
+      label: Relevant log output
+      render: shell
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Code of Conduct
+      options:
+        - label: I agree to follow this project's Code of Conduct
+          required: true
+        - label: I searched for existing issues
+`
+
+func parseBugForm(t *testing.T) *Template {
+	t.Helper()
+	form, err := Parse("bug_report.yml", []byte(bugForm))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	return form
+}
+
+func TestParse_Form(t *testing.T) {
+	form := parseBugForm(t)
+
+	if !form.IsForm() || form.Name != "Bug Report" || form.Title != "[Bug]: " || form.Type != "Bug" {
+		t.Errorf("Unexpected form: %+v", form)
+	}
+	if !reflect.DeepEqual([]string(form.Labels), []string{"bug", "triage"}) || !reflect.DeepEqual([]string(form.Assignees), []string{"octocat"}) {
+		t.Errorf("Unexpected labels or assignees: %v, %v", form.Labels, form.Assignees)
+	}
+	if len(form.Inputs()) != 6 {
+		t.Errorf("Expected 6 inputs, got %d", len(form.Inputs()))
+	}
+
+	terms, ok := form.Input("Code of Conduct")
+	if !ok || terms.ID != "terms" || !terms.Attributes.Options[0].Required || terms.Attributes.Options[1].Required {
+		t.Errorf("Unexpected checkboxes: %+v", terms)
+	}
+	version, _ := form.Input("version")
+	if !reflect.DeepEqual(version.Defaults(), []string{"1.0.2 (Default)"}) {
+		t.Errorf("Expected the default option, got %v", version.Defaults())
+	}
+}
+
+func TestParse_InvalidForms(t *testing.T) {
+	tests := map[string]string{
+		"no name":         "body:\n  - type: input\n    attributes: {label: A}\n",
+		"no body":         "name: Empty\n",
+		"unknown type":    "name: X\nbody:\n  - type: slider\n    attributes: {label: A}\n",
+		"no label":        "name: X\nbody:\n  - type: input\n",
+		"no options":      "name: X\nbody:\n  - type: dropdown\n    attributes: {label: A}\n",
+		"duplicate id":    "name: X\nbody:\n  - type: input\n    id: a\n    attributes: {label: A}\n  - type: input\n    id: a\n    attributes: {label: B}\n",
+		"invalid default": "name: X\nbody:\n  - type: dropdown\n    attributes: {label: A, options: [x], default: 1}\n",
+	}
+	for name, data := range tests {
+		if _, err := Parse("form.yml", []byte(data)); err == nil || !strings.HasPrefix(err.Error(), "form.yml: ") {
+			t.Errorf("%s: expected an error naming the file, got %v", name, err)
+		}
+	}
+}
+
+func TestParse_Markdown(t *testing.T) {
+	data := "---\r\nname: Feature request\r\nabout: Suggest an idea\r\ntitle: '[FEATURE] '\r\nlabels: enhancement, needs-triage\r\nassignees: ''\r\n---\r\n\r\n**Is your feature request related to a problem?**\r\n"
+
+	tmpl, err := Parse("feature_request.md", []byte(data))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if tmpl.IsForm() || tmpl.Name != "Feature request" || tmpl.Title != "[FEATURE] " {
+		t.Errorf("Unexpected template: %+v", tmpl)
+	}
+	if !reflect.DeepEqual([]string(tmpl.Labels), []string{"enhancement", "needs-triage"}) || len(tmpl.Assignees) != 0 {
+		t.Errorf("Unexpected labels or assignees: %v, %v", tmpl.Labels, tmpl.Assignees)
+	}
+	if got := tmpl.Render(nil); got != "**Is your feature request related to a problem?**\n" {
+		t.Errorf("Unexpected body %q", got)
+	}
+
+	if _, err := Parse("broken.md", []byte("---\nname: x\n")); err == nil {
+		t.Error("Expected an error for unclosed front matter")
+	}
+}
+
+func TestRender_AsGitHub(t *testing.T) {
+	form := parseBugForm(t)
+
+	got := form.Render(map[string][]string{
+		"what-happened": {"It crashed"},
+		"version":       {"1.0.3 (Edge)"},
+		"browsers":      {"Firefox", "Safari"},
+		"logs":          {"panic: oops"},
+		"terms":         {"I agree to follow this project's Code of Conduct"},
+	})
+
+	want := "### Contact Details\n\n_No response_\n\n" +
+		"### What happened?\n\nIt crashed\n\n" +
+		"### Version\n\n1.0.3 (Edge)\n\n" +
+		"### What browsers are you seeing the problem on?\n\nFirefox, Safari\n\n" +
+		"### Relevant log output\n\n```shell\npanic: oops\n```\n\n" +
+		"### Code of Conduct\n\n- [X] I agree to follow this project's Code of Conduct\n- [ ] I searched for existing issues"
+	if got != want {
+		t.Errorf("Render mismatch\ngot:\n%s\n\nwant:\n%s", got, want)
+	}
+}
+
+func TestElement_ParseAnswer(t *testing.T) {
+	form := parseBugForm(t)
+	browsers, _ := form.Input("browsers")
+	version, _ := form.Input("version")
+	terms, _ := form.Input("terms")
+
+	if got, err := browsers.ParseAnswer("chrome, 1,Chrome"); err != nil || !reflect.DeepEqual(got, []string{"Chrome", "Firefox"}) {
+		t.Errorf("Expected Chrome and Firefox, got %v, %v", got, err)
+	}
+	if _, err := browsers.ParseAnswer("Edge"); err == nil || !strings.Contains(err.Error(), "expected one of Firefox, Chrome, Safari") {
+		t.Errorf("Expected an unknown option error, got %v", err)
+	}
+	if _, err := version.ParseAnswer("1,2"); err == nil || !strings.Contains(err.Error(), "takes one option") {
+		t.Errorf("Expected a single option error, got %v", err)
+	}
+	if got, _ := terms.ParseAnswer("2"); !reflect.DeepEqual(got, []string{"I searched for existing issues"}) {
+		t.Errorf("Expected the second checkbox, got %v", got)
+	}
+}
+
+func TestElement_Check(t *testing.T) {
+	form := parseBugForm(t)
+	happened, _ := form.Input("what-happened")
+	terms, _ := form.Input("terms")
+	contact, _ := form.Input("contact")
+
+	if err := happened.Check(nil); err == nil || !strings.Contains(err.Error(), "is required") {
+		t.Errorf("Expected a required error, got %v", err)
+	}
+	if err := contact.Check(nil); err != nil {
+		t.Errorf("Expected optional input to pass, got %v", err)
+	}
+	if err := terms.Check([]string{"I searched for existing issues"}); err == nil || !strings.Contains(err.Error(), "must be checked") {
+		t.Errorf("Expected a required checkbox error, got %v", err)
+	}
+}
+
+func TestTemplate_MatchesAndTitle(t *testing.T) {
+	form := parseBugForm(t)
+
+	for _, name := range []string{"bug_report", "bug_report.yml", "bug report", "Bug Report"} {
+		if !form.Matches(name) {
+			t.Errorf("Expected %q to match", name)
+		}
+	}
+	if form.Matches("feature") {
+		t.Error("Expected feature not to match")
+	}
+	if got := form.IssueTitle("Crash on save"); got != "[Bug]: Crash on save" {
+		t.Errorf("Unexpected title %q", got)
+	}
+	if got := form.IssueTitle("[Bug]: Crash on save"); got != "[Bug]: Crash on save" {
+		t.Errorf("Expected the prefix once, got %q", got)
+	}
+}
+
+func TestIsTemplateFile(t *testing.T) {
+	for name, want := range map[string]bool{
+		"bug_report.yml": true,
+		"feature.yaml":   true,
+		"question.md":    true,
+		"config.yml":     false,
+		"README.txt":     false,
+	} {
+		if got := IsTemplateFile(name); got != want {
+			t.Errorf("IsTemplateFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	texttemplate "text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// funcMap contains the helper functions available in template placeholders
+var funcMap = texttemplate.FuncMap{
+	// default returns def when value is empty: {{.ProjectName | default "Board"}}
+	"default": func(def string, value interface{}) string {
+		if s, ok := value.(string); ok && s != "" {
+			return s
+		}
+		if value != nil {
+			if s := fmt.Sprint(value); s != "" {
+				return s
+			}
+		}
+		return def
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// RenderString renders the placeholders in a single string value.
+// Variables that are not set render as empty strings.
+func RenderString(text string, vars map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	t, err := texttemplate.New("value").Funcs(funcMap).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	if vars == nil {
+		vars = map[string]string{}
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, vars); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// renderNode renders every string scalar below node in place
+func renderNode(node *yaml.Node, vars map[string]string) error {
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag != "!!str" {
+			return nil
+		}
+		rendered, err := RenderString(node.Value, vars)
+		if err != nil {
+			return fmt.Errorf("line %d: failed to render %q: %w", node.Line, node.Value, err)
+		}
+		node.Value = rendered
+		return nil
+	}
+
+	for _, child := range node.Content {
+		if err := renderNode(child, vars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+// Package template parses and renders gh-pmu project templates.
+//
+// Templates are YAML files (see the templates/ directory) describing a
+// GitHub Project: its title and readme, custom fields and options, views,
+// workflows, draft issues, and the field aliases used by .gh-pmu.yml.
+// String values may contain Go template placeholders such as
+// {{.ProjectName | default "Kanban Board"}}, which are rendered before
+// the document is decoded.
+package template
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/scooter-indie/gh-pmu/templates"
+	"gopkg.in/yaml.v3"
+)
+
+// Field types supported in templates
+const (
+	FieldTypeSingleSelect = "single_select"
+	FieldTypeText         = "text"
+	FieldTypeNumber       = "number"
+	FieldTypeDate         = "date"
+	FieldTypeIteration    = "iteration"
+)
+
+// Template represents a project template file
+type Template struct {
+	SchemaVersion string           `yaml:"schema_version,omitempty"`
+	Template      Info             `yaml:"template"`
+	Project       Project          `yaml:"project"`
+	Fields        []Field          `yaml:"fields"`
+	Views         []View           `yaml:"views,omitempty"`
+	Workflows     []Workflow       `yaml:"workflows,omitempty"`
+	DraftIssues   []DraftIssue     `yaml:"draft_issues,omitempty"`
+	Aliases       map[string]Alias `yaml:"aliases,omitempty"`
+	Repositories  []string         `yaml:"repositories,omitempty"`
+}
+
+// Info contains descriptive metadata about the template itself
+type Info struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Version     string   `yaml:"version,omitempty"`
+	Author      string   `yaml:"author,omitempty"`
+	License     string   `yaml:"license,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+}
+
+// Project contains the settings for the project to create
+type Project struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	Visibility  string `yaml:"visibility,omitempty"`
+	Readme      string `yaml:"readme,omitempty"`
+}
+
+// Field defines a custom project field
+type Field struct {
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type"`
+	Description string   `yaml:"description,omitempty"`
+	Options     []Option `yaml:"options,omitempty"`
+	Duration    int      `yaml:"duration,omitempty"`  // iteration fields only, in days
+	StartDay    string   `yaml:"start_day,omitempty"` // iteration fields only, e.g. "monday"
+}
+
+// Option defines an option of a single-select field
+type Option struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// View defines a project view
+type View struct {
+	Name          string       `yaml:"name"`
+	Type          string       `yaml:"type"`
+	Default       bool         `yaml:"default,omitempty"`
+	Description   string       `yaml:"description,omitempty"`
+	Filter        string       `yaml:"filter,omitempty"`
+	GroupBy       string       `yaml:"group_by,omitempty"`
+	SortBy        string       `yaml:"sort_by,omitempty"`
+	SortDirection string       `yaml:"sort_direction,omitempty"`
+	Sort          []ViewSort   `yaml:"sort,omitempty"`
+	DateField     string       `yaml:"date_field,omitempty"`
+	Columns       []ViewColumn `yaml:"columns,omitempty"`
+	CardFields    []string     `yaml:"card_fields,omitempty"`
+}
+
+// ViewSort defines a sort key of a view
+type ViewSort struct {
+	Field     string `yaml:"field"`
+	Direction string `yaml:"direction,omitempty"`
+}
+
+// ViewColumn defines a visible column of a view.
+// In YAML it is either a plain field name or a {field, width} mapping.
+type ViewColumn struct {
+	Field string `yaml:"field"`
+	Width int    `yaml:"width,omitempty"`
+}
+
+// UnmarshalYAML accepts both the scalar and the mapping form of a column
+func (c *ViewColumn) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		c.Field = node.Value
+		return nil
+	}
+	type plain ViewColumn
+	return node.Decode((*plain)(c))
+}
+
+// MarshalYAML writes columns without a width in the scalar form
+func (c ViewColumn) MarshalYAML() (interface{}, error) {
+	if c.Width == 0 {
+		return c.Field, nil
+	}
+	type plain ViewColumn
+	return plain(c), nil
+}
+
+// Workflow defines a project automation.
+// Workflows are informational: GitHub does not expose an API to create them.
+type Workflow struct {
+	Name       string                   `yaml:"name"`
+	Enabled    *bool                    `yaml:"enabled,omitempty"`
+	Trigger    WorkflowTrigger          `yaml:"trigger"`
+	Conditions []map[string]interface{} `yaml:"conditions,omitempty"`
+	Action     map[string]interface{}   `yaml:"action,omitempty"`
+	Actions    []map[string]interface{} `yaml:"actions,omitempty"`
+}
+
+// WorkflowTrigger defines what starts a workflow.
+// In YAML it is either a plain trigger type or a mapping.
+type WorkflowTrigger struct {
+	Type         string            `yaml:"type"`
+	Repositories []string          `yaml:"repositories,omitempty"`
+	Conditions   map[string]string `yaml:"conditions,omitempty"`
+}
+
+// UnmarshalYAML accepts both the scalar and the mapping form of a trigger
+func (t *WorkflowTrigger) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		t.Type = node.Value
+		return nil
+	}
+	type plain WorkflowTrigger
+	return node.Decode((*plain)(t))
+}
+
+// Alias defines shorthand values for a field, written to .gh-pmu.yml.
+// In YAML it is either a flat alias-to-value mapping, keyed by a name that
+// matches the field, or a {field, values} mapping naming the field explicitly.
+type Alias struct {
+	Field  string            `yaml:"field,omitempty"`
+	Values map[string]string `yaml:"values"`
+}
+
+// UnmarshalYAML accepts both the flat and the {field, values} form of an alias group
+func (a *Alias) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "values" && node.Content[i+1].Kind == yaml.MappingNode {
+				type plain Alias
+				return node.Decode((*plain)(a))
+			}
+		}
+	}
+	return node.Decode(&a.Values)
+}
+
+// MarshalYAML writes alias groups without an explicit field in the flat form
+func (a Alias) MarshalYAML() (interface{}, error) {
+	if a.Field == "" {
+		return a.Values, nil
+	}
+	type plain Alias
+	return plain(a), nil
+}
+
+// DraftIssue defines a draft issue to seed the project with
+type DraftIssue struct {
+	Title  string            `yaml:"title"`
+	Body   string            `yaml:"body,omitempty"`
+	Labels []string          `yaml:"labels,omitempty"`
+	Fields map[string]string `yaml:"fields,omitempty"`
+}
+
+// DataType returns the GitHub ProjectV2 data type for the field (e.g. SINGLE_SELECT)
+func (f Field) DataType() string {
+	return strings.ToUpper(f.Type)
+}
+
+// ReadSource reads a template from a file path or, if no such file exists,
+// from the built-in templates by name (e.g. "scrum" or "builtin:scrum").
+func ReadSource(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "builtin:") {
+		if data, err := os.ReadFile(source); err == nil {
+			return data, nil
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read template %s: %w", source, err)
+		}
+	}
+
+	return templates.Read(source)
+}
+
+// Parse renders the placeholders in a template document using vars
+// and decodes the result.
+func Parse(data []byte, vars map[string]string) (*Template, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if err := renderNode(&doc, vars); err != nil {
+		return nil, err
+	}
+
+	var tmpl Template
+	if err := doc.Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("failed to decode template: %w", err)
+	}
+
+	return &tmpl, nil
+}
+
+// Marshal encodes a template as YAML
+func Marshal(tmpl *Template) ([]byte, error) {
+	data, err := yaml.Marshal(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal template: %w", err)
+	}
+	return data, nil
+}
+
+// FindField returns the template field with the given name (case-insensitive)
+func (t *Template) FindField(name string) *Field {
+	for i := range t.Fields {
+		if strings.EqualFold(t.Fields[i].Name, name) {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+// AliasFieldName returns the name of the field that an alias group refers to.
+// An explicit field in the alias group wins; otherwise the group's key
+// ("status", "element") matches a field name case-insensitively, with
+// underscores for spaces, or as a prefix.
+// Returns an empty string if no field matches.
+func (t *Template) AliasFieldName(key string) string {
+	if a, ok := t.Aliases[key]; ok && a.Field != "" {
+		return a.Field
+	}
+
+	if f := t.FindField(key); f != nil {
+		return f.Name
+	}
+
+	normalized := strings.ToLower(strings.ReplaceAll(key, "_", " "))
+	for _, f := range t.Fields {
+		if strings.ToLower(f.Name) == normalized {
+			return f.Name
+		}
+	}
+	for _, f := range t.Fields {
+		if strings.HasPrefix(strings.ToLower(f.Name), normalized) {
+			return f.Name
+		}
+	}
+
+	return ""
+}
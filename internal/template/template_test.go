@@ -0,0 +1,191 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/templates"
+)
+
+func TestParse_AllBuiltinTemplates(t *testing.T) {
+	vars := map[string]string{"ProjectName": "Test", "Repository": "acme/api"}
+
+	for _, name := range templates.Names() {
+		t.Run(name, func(t *testing.T) {
+			data, err := ReadSource(name)
+			if err != nil {
+				t.Fatalf("ReadSource(%q) error: %v", name, err)
+			}
+			tmpl, err := Parse(data, vars)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", name, err)
+			}
+			if tmpl.Project.Title == "" {
+				t.Error("Expected project title to be set")
+			}
+			if len(tmpl.Fields) == 0 {
+				t.Error("Expected template to define fields")
+			}
+		})
+	}
+}
+
+func TestParse_RendersPlaceholders(t *testing.T) {
+	data := []byte(`project:
+  title: "{{.ProjectName | default \"Kanban Board\"}}"
+  readme: |
+    # {{.ProjectName | default "Kanban Board"}}
+repositories:
+  - "{{.Repository}}"
+`)
+
+	tmpl, err := Parse(data, map[string]string{"ProjectName": "Team Board", "Repository": "acme/api"})
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	if tmpl.Project.Title != "Team Board" {
+		t.Errorf("Expected title 'Team Board', got %q", tmpl.Project.Title)
+	}
+	if !strings.HasPrefix(tmpl.Project.Readme, "# Team Board") {
+		t.Errorf("Expected rendered readme, got %q", tmpl.Project.Readme)
+	}
+	if len(tmpl.Repositories) != 1 || tmpl.Repositories[0] != "acme/api" {
+		t.Errorf("Expected repositories [acme/api], got %v", tmpl.Repositories)
+	}
+}
+
+func TestParse_DefaultUsedWhenVariableMissing(t *testing.T) {
+	data := []byte(`project:
+  title: "{{.ProjectName | default \"Kanban Board\"}}"
+`)
+
+	tmpl, err := Parse(data, nil)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if tmpl.Project.Title != "Kanban Board" {
+		t.Errorf("Expected default title 'Kanban Board', got %q", tmpl.Project.Title)
+	}
+}
+
+func TestParse_InvalidPlaceholder_ReturnsLine(t *testing.T) {
+	data := []byte("project:\n  title: \"{{.ProjectName\"\n")
+
+	_, err := Parse(data, nil)
+	if err == nil {
+		t.Fatal("Expected error for unterminated placeholder")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error to mention line 2, got: %v", err)
+	}
+}
+
+func TestParse_ViewColumnForms(t *testing.T) {
+	data := []byte(`views:
+  - name: Table
+    type: table
+    columns:
+      - Title
+      - field: Status
+        width: 120
+workflows:
+  - name: Auto-close
+    trigger: item_closed
+`)
+
+	tmpl, err := Parse(data, nil)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	cols := tmpl.Views[0].Columns
+	if len(cols) != 2 || cols[0].Field != "Title" || cols[1].Field != "Status" || cols[1].Width != 120 {
+		t.Errorf("Unexpected columns: %+v", cols)
+	}
+	if tmpl.Workflows[0].Trigger.Type != "item_closed" {
+		t.Errorf("Expected trigger type 'item_closed', got %q", tmpl.Workflows[0].Trigger.Type)
+	}
+}
+
+func TestReadSource_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("project:\n  title: Custom\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ReadSource(path)
+	if err != nil {
+		t.Fatalf("ReadSource error: %v", err)
+	}
+	if !strings.Contains(string(data), "Custom") {
+		t.Errorf("Expected file contents, got %q", data)
+	}
+}
+
+func TestReadSource_UnknownTemplate(t *testing.T) {
+	_, err := ReadSource("does-not-exist")
+	if err == nil {
+		t.Fatal("Expected error for unknown template")
+	}
+	if !strings.Contains(err.Error(), "kanban") {
+		t.Errorf("Expected error to list available templates, got: %v", err)
+	}
+}
+
+func TestAliasFieldName(t *testing.T) {
+	tmpl := &Template{Fields: []Field{
+		{Name: "Status"},
+		{Name: "Story Points"},
+		{Name: "Element Type"},
+	}}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"status", "Status"},
+		{"story_points", "Story Points"},
+		{"element", "Element Type"},
+		{"unknown", ""},
+	}
+
+	for _, tt := range tests {
+		if got := tmpl.AliasFieldName(tt.key); got != tt.want {
+			t.Errorf("AliasFieldName(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestParse_AliasForms(t *testing.T) {
+	data := []byte(`fields:
+  - name: Status
+    type: single_select
+  - name: Type
+    type: single_select
+aliases:
+  status:
+    todo: "To Do"
+  kind:
+    field: Type
+    values:
+      bug: "Bug"
+`)
+
+	tmpl, err := Parse(data, nil)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+
+	if tmpl.Aliases["status"].Values["todo"] != "To Do" {
+		t.Errorf("Expected flat alias to decode, got %+v", tmpl.Aliases["status"])
+	}
+	if got := tmpl.AliasFieldName("kind"); got != "Type" {
+		t.Errorf("Expected explicit alias field 'Type', got %q", got)
+	}
+	if tmpl.Aliases["kind"].Values["bug"] != "Bug" {
+		t.Errorf("Expected explicit alias values to decode, got %+v", tmpl.Aliases["kind"])
+	}
+}
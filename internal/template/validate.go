@@ -0,0 +1,505 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SupportedSchemaVersions lists the template schema versions this release understands
+var SupportedSchemaVersions = []string{"1.0"}
+
+// BuiltinVariables are the placeholder variables always available when a
+// template is rendered by project create
+var BuiltinVariables = []string{"ProjectName", "Repository", "Owner", "User", "Date"}
+
+// BuiltinFields are the fields GitHub creates for every project.
+// Views may reference them without declaring them.
+var BuiltinFields = []string{
+	"Title", "Assignees", "Status", "Labels", "Linked pull requests",
+	"Milestone", "Repository", "Reviewers", "Parent issue",
+	"Sub-issues progress", "Tracks", "Tracked by",
+}
+
+// SortKeys are the item attributes GitHub can sort a view by besides its fields
+var SortKeys = []string{"created", "updated", "closed", "comments", "reactions"}
+
+// OptionColors are the colors GitHub accepts for single-select options
+var OptionColors = []string{"GRAY", "BLUE", "GREEN", "YELLOW", "ORANGE", "RED", "PINK", "PURPLE"}
+
+// ViewTypes are the supported view layouts
+var ViewTypes = []string{"table", "board", "roadmap"}
+
+// Problem is a validation error at a position in a template file
+type Problem struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// String formats the problem as "line:column: message"
+func (p Problem) String() string {
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+// validator collects problems while walking a template document
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(node *yaml.Node, format string, args ...interface{}) {
+	p := Problem{Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		p.Line, p.Column = node.Line, node.Column
+	}
+	v.problems = append(v.problems, p)
+}
+
+// Validate checks a template document and returns the problems found,
+// sorted by position. vars lists the variables that placeholders may use in
+// addition to BuiltinVariables. An error is returned only when the document
+// is not valid YAML.
+func Validate(data []byte, vars []string) ([]Problem, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	v := &validator{}
+
+	known := make(map[string]bool)
+	for _, name := range append(append([]string{}, BuiltinVariables...), vars...) {
+		known[name] = true
+	}
+	v.checkPlaceholders(&doc, known)
+
+	// Render with placeholder values so typed decoding sees realistic strings
+	sample := make(map[string]string)
+	for name := range known {
+		sample[name] = name
+	}
+	_ = renderNode(&doc, sample)
+
+	if len(doc.Content) == 0 {
+		v.add(nil, "template is empty")
+		return v.problems, nil
+	}
+	root := doc.Content[0]
+
+	var t Template
+	if err := root.Decode(&t); err != nil {
+		v.addDecodeError(root, err)
+		sortProblems(v.problems)
+		return v.problems, nil
+	}
+
+	v.checkSchemaVersion(root, &t)
+	v.checkProject(root, &t)
+	v.checkFields(root, &t)
+	v.checkViews(root, &t)
+	v.checkAliases(root, &t)
+	v.checkDraftIssues(root, &t)
+
+	sortProblems(v.problems)
+	return v.problems, nil
+}
+
+// addDecodeError converts yaml type errors ("line 3: cannot unmarshal ...") into problems
+func (v *validator) addDecodeError(root *yaml.Node, err error) {
+	typeErr, ok := err.(*yaml.TypeError)
+	if !ok {
+		v.add(root, "%v", err)
+		return
+	}
+	for _, msg := range typeErr.Errors {
+		var line int
+		if _, scanErr := fmt.Sscanf(msg, "line %d:", &line); scanErr == nil {
+			msg = strings.TrimSpace(msg[strings.Index(msg, ":")+1:])
+		}
+		v.problems = append(v.problems, Problem{Line: line, Column: 1, Message: msg})
+	}
+}
+
+// checkPlaceholders reports malformed placeholders and undefined variables
+func (v *validator) checkPlaceholders(node *yaml.Node, known map[string]bool) {
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag != "!!str" || !strings.Contains(node.Value, "{{") {
+			return
+		}
+		t, err := texttemplate.New("value").Funcs(funcMap).Parse(node.Value)
+		if err != nil {
+			v.add(node, "invalid placeholder: %v", err)
+			return
+		}
+		for _, name := range templateVariables(t.Tree.Root) {
+			if !known[name] {
+				v.add(node, "undefined template variable %q", name)
+			}
+		}
+		return
+	}
+
+	for _, child := range node.Content {
+		v.checkPlaceholders(child, known)
+	}
+}
+
+// templateVariables returns the top-level variable names ({{.Name}}) used in a parse tree
+func templateVariables(node parse.Node) []string {
+	var names []string
+	var walk func(n parse.Node)
+	walk = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				walk(c)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Cmds {
+				walk(c)
+			}
+		case *parse.CommandNode:
+			for _, a := range n.Args {
+				walk(a)
+			}
+		case *parse.FieldNode:
+			names = append(names, n.Ident[0])
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(node)
+	return names
+}
+
+func (v *validator) checkSchemaVersion(root *yaml.Node, t *Template) {
+	if t.SchemaVersion == "" {
+		return
+	}
+	if !containsFold(SupportedSchemaVersions, t.SchemaVersion) {
+		v.add(mappingValue(root, "schema_version"), "unsupported schema_version %q (supported: %s)",
+			t.SchemaVersion, strings.Join(SupportedSchemaVersions, ", "))
+	}
+}
+
+func (v *validator) checkProject(root *yaml.Node, t *Template) {
+	node := mappingValue(root, "project")
+	if node == nil {
+		v.add(root, "missing required section \"project\"")
+		return
+	}
+	if strings.TrimSpace(t.Project.Title) == "" {
+		v.add(node, "project.title is required")
+	}
+	if t.Project.Visibility != "" && t.Project.Visibility != "public" && t.Project.Visibility != "private" {
+		v.add(mappingValue(node, "visibility"), "invalid visibility %q (expected public or private)", t.Project.Visibility)
+	}
+}
+
+func (v *validator) checkFields(root *yaml.Node, t *Template) {
+	fieldsNode := mappingValue(root, "fields")
+	seen := make(map[string]bool)
+
+	for i, f := range t.Fields {
+		node := sequenceItem(fieldsNode, i)
+
+		if strings.TrimSpace(f.Name) == "" {
+			v.add(node, "field name is required")
+		} else {
+			key := strings.ToLower(f.Name)
+			if seen[key] {
+				v.add(mappingValue(node, "name"), "duplicate field %q", f.Name)
+			}
+			seen[key] = true
+		}
+
+		switch f.Type {
+		case FieldTypeSingleSelect:
+			if len(f.Options) == 0 {
+				v.add(node, "single_select field %q must define options", f.Name)
+			}
+		case FieldTypeText, FieldTypeNumber, FieldTypeDate, FieldTypeIteration:
+			if len(f.Options) > 0 {
+				v.add(mappingValue(node, "options"), "options are only allowed on single_select fields (%q is %s)", f.Name, f.Type)
+			}
+		default:
+			v.add(valueOrNode(node, "type"), "field %q has invalid type %q (expected single_select, text, number, date, or iteration)", f.Name, f.Type)
+		}
+
+		if f.Type != FieldTypeIteration {
+			if f.Duration != 0 {
+				v.add(mappingValue(node, "duration"), "duration is only allowed on iteration fields")
+			}
+			if f.StartDay != "" {
+				v.add(mappingValue(node, "start_day"), "start_day is only allowed on iteration fields")
+			}
+		} else {
+			if f.Duration < 0 {
+				v.add(mappingValue(node, "duration"), "duration must be a positive number of days")
+			}
+			if f.StartDay != "" && !isWeekday(f.StartDay) {
+				v.add(mappingValue(node, "start_day"), "invalid start_day %q (expected a day of the week)", f.StartDay)
+			}
+		}
+
+		v.checkOptions(mappingValue(node, "options"), f)
+	}
+}
+
+func (v *validator) checkOptions(optionsNode *yaml.Node, f Field) {
+	seen := make(map[string]bool)
+	for i, opt := range f.Options {
+		node := sequenceItem(optionsNode, i)
+
+		if strings.TrimSpace(opt.Name) == "" {
+			v.add(node, "option name is required in field %q", f.Name)
+			continue
+		}
+		key := strings.ToLower(opt.Name)
+		if seen[key] {
+			v.add(mappingValue(node, "name"), "duplicate option %q in field %q", opt.Name, f.Name)
+		}
+		seen[key] = true
+
+		if opt.Color != "" && !containsFold(OptionColors, opt.Color) {
+			v.add(mappingValue(node, "color"), "invalid color %q for option %q (expected one of %s)",
+				opt.Color, opt.Name, strings.Join(OptionColors, ", "))
+		}
+	}
+}
+
+func (v *validator) checkViews(root *yaml.Node, t *Template) {
+	viewsNode := mappingValue(root, "views")
+	defaults := 0
+
+	for i, view := range t.Views {
+		node := sequenceItem(viewsNode, i)
+
+		if strings.TrimSpace(view.Name) == "" {
+			v.add(node, "view name is required")
+		}
+		if !containsFold(ViewTypes, view.Type) {
+			v.add(valueOrNode(node, "type"), "view %q has invalid type %q (expected table, board, or roadmap)", view.Name, view.Type)
+		}
+		if view.Default {
+			defaults++
+			if defaults > 1 {
+				v.add(mappingValue(node, "default"), "more than one view is marked as default")
+			}
+		}
+
+		v.checkFieldRef(t, mappingValue(node, "group_by"), view.GroupBy, view.Name)
+		v.checkSortRef(t, mappingValue(node, "sort_by"), view.SortBy, view.Name)
+		v.checkFieldRef(t, mappingValue(node, "date_field"), view.DateField, view.Name)
+
+		sortNode := mappingValue(node, "sort")
+		for j, s := range view.Sort {
+			v.checkSortRef(t, valueOrNode(sequenceItem(sortNode, j), "field"), s.Field, view.Name)
+		}
+
+		cardsNode := mappingValue(node, "card_fields")
+		for j, name := range view.CardFields {
+			v.checkFieldRef(t, sequenceItem(cardsNode, j), name, view.Name)
+		}
+
+		columnsNode := mappingValue(node, "columns")
+		groupField := t.FindField(view.GroupBy)
+		for j, col := range view.Columns {
+			colNode := valueOrNode(sequenceItem(columnsNode, j), "field")
+			// Board columns may also name the options of the group_by field
+			if strings.EqualFold(view.Type, "board") && groupField != nil && hasOption(groupField, col.Field) {
+				continue
+			}
+			v.checkFieldRef(t, colNode, col.Field, view.Name)
+		}
+	}
+}
+
+// checkFieldRef reports a view reference to a field that is neither declared nor built in
+func (v *validator) checkFieldRef(t *Template, node *yaml.Node, name, viewName string) {
+	if name == "" || t.FindField(name) != nil || containsFold(BuiltinFields, name) {
+		return
+	}
+	v.add(node, "view %q references undefined field %q", viewName, name)
+}
+
+// checkSortRef is checkFieldRef for sort keys, which may also be built-in sort keys
+func (v *validator) checkSortRef(t *Template, node *yaml.Node, name, viewName string) {
+	if containsFold(SortKeys, name) {
+		return
+	}
+	v.checkFieldRef(t, node, name, viewName)
+}
+
+func (v *validator) checkAliases(root *yaml.Node, t *Template) {
+	aliasesNode := mappingValue(root, "aliases")
+
+	keys := make([]string, 0, len(t.Aliases))
+	for k := range t.Aliases {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		alias := t.Aliases[key]
+		keyNode := mappingKey(aliasesNode, key)
+		groupNode := mappingValue(aliasesNode, key)
+
+		fieldName := t.AliasFieldName(key)
+		f := t.FindField(fieldName)
+		if f == nil {
+			if alias.Field != "" {
+				v.add(valueOrNode(groupNode, "field"), "alias group %q references undefined field %q", key, alias.Field)
+			} else {
+				v.add(keyNode, "alias group %q does not match any field", key)
+			}
+			continue
+		}
+
+		if f.Type != FieldTypeSingleSelect {
+			continue
+		}
+
+		valuesNode := groupNode
+		if alias.Field != "" || mappingValue(groupNode, "values") != nil {
+			valuesNode = mappingValue(groupNode, "values")
+		}
+
+		aliasKeys := make([]string, 0, len(alias.Values))
+		for k := range alias.Values {
+			aliasKeys = append(aliasKeys, k)
+		}
+		sort.Strings(aliasKeys)
+
+		for _, k := range aliasKeys {
+			if !hasOption(f, alias.Values[k]) {
+				v.add(mappingValue(valuesNode, k), "alias %s.%s maps to %q, which is not an option of field %q", key, k, alias.Values[k], f.Name)
+			}
+		}
+	}
+}
+
+func (v *validator) checkDraftIssues(root *yaml.Node, t *Template) {
+	draftsNode := mappingValue(root, "draft_issues")
+
+	for i, draft := range t.DraftIssues {
+		node := sequenceItem(draftsNode, i)
+		if strings.TrimSpace(draft.Title) == "" {
+			v.add(node, "draft issue title is required")
+		}
+
+		fieldsNode := mappingValue(node, "fields")
+		names := make([]string, 0, len(draft.Fields))
+		for name := range draft.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			f := t.FindField(name)
+			if f == nil {
+				v.add(mappingKey(fieldsNode, name), "draft issue %q sets undefined field %q", draft.Title, name)
+				continue
+			}
+			if f.Type == FieldTypeSingleSelect && !hasOption(f, draft.Fields[name]) {
+				v.add(mappingValue(fieldsNode, name), "draft issue %q: %q is not an option of field %q", draft.Title, draft.Fields[name], f.Name)
+			}
+		}
+	}
+}
+
+// mappingKey returns the key node for key in a mapping node, or nil
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// valueOrNode returns the value node for key, falling back to node itself
+// (for scalar shorthand forms and missing keys)
+func valueOrNode(node *yaml.Node, key string) *yaml.Node {
+	if value := mappingValue(node, key); value != nil {
+		return value
+	}
+	return node
+}
+
+// sequenceItem returns the i-th item of a sequence node, or nil
+func sequenceItem(node *yaml.Node, i int) *yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+		return nil
+	}
+	return node.Content[i]
+}
+
+func hasOption(f *Field, name string) bool {
+	for _, opt := range f.Options {
+		if opt.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWeekday(day string) bool {
+	return containsFold([]string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}, day)
+}
+
+func sortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+}
@@ -0,0 +1,142 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scooter-indie/gh-pmu/templates"
+)
+
+func TestValidate_BuiltinTemplatesAreValid(t *testing.T) {
+	for _, name := range templates.Names() {
+		t.Run(name, func(t *testing.T) {
+			data, err := templates.Read(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			problems, err := Validate(data, nil)
+			if err != nil {
+				t.Fatalf("Validate error: %v", err)
+			}
+			for _, p := range problems {
+				t.Errorf("unexpected problem: %s", p)
+			}
+		})
+	}
+}
+
+func TestValidate_ReportsProblemsWithPositions(t *testing.T) {
+	data := []byte(`schema_version: "2.0"
+project:
+  title: "{{.ProjectName}} for {{.Team}}"
+fields:
+  - name: Status
+    type: single_select
+    options:
+      - name: Todo
+        color: MAUVE
+      - name: todo
+  - name: Size
+    type: enum
+  - name: Notes
+    type: text
+    options:
+      - name: x
+views:
+  - name: Board
+    type: board
+    group_by: Missing
+aliases:
+  status:
+    done: Done
+  unknown:
+    a: b
+`)
+
+	problems, err := Validate(data, nil)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	want := []string{
+		`1:17: unsupported schema_version "2.0"`,
+		`3:10: undefined template variable "Team"`,
+		`9:16: invalid color "MAUVE"`,
+		`10:15: duplicate option "todo" in field "Status"`,
+		`12:11: field "Size" has invalid type "enum"`,
+		`16:7: options are only allowed on single_select fields`,
+		`20:15: view "Board" references undefined field "Missing"`,
+		`23:11: alias status.done maps to "Done"`,
+		`24:3: alias group "unknown" does not match any field`,
+	}
+
+	if len(problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %d:\n%v", len(want), len(problems), problems)
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(problems[i].String(), w) {
+			t.Errorf("problem %d = %q, want prefix %q", i, problems[i].String(), w)
+		}
+	}
+}
+
+func TestValidate_CustomVariablesAllowed(t *testing.T) {
+	data := []byte(`project:
+  title: "{{.Team}} board"
+fields: []
+`)
+
+	problems, err := Validate(data, []string{"Team"})
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+}
+
+func TestValidate_BuiltinSortKeys(t *testing.T) {
+	data := []byte(`project:
+  title: Board
+fields: []
+views:
+  - name: Recent
+    type: table
+    sort_by: updated
+    sort:
+      - field: Created
+      - field: Missing
+`)
+
+	problems, err := Validate(data, nil)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0].String(), `undefined field "Missing"`) {
+		t.Errorf("Expected only the undefined sort field, got %v", problems)
+	}
+}
+
+func TestValidate_TypeErrorsHaveLines(t *testing.T) {
+	data := []byte(`project:
+  title: Board
+fields:
+  - name: Sprint
+    type: iteration
+    duration: two weeks
+`)
+
+	problems, err := Validate(data, nil)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Line != 6 {
+		t.Errorf("Expected one problem on line 6, got %v", problems)
+	}
+}
+
+func TestValidate_InvalidYAML(t *testing.T) {
+	if _, err := Validate([]byte("project: [unclosed"), nil); err == nil {
+		t.Error("Expected error for invalid YAML")
+	}
+}
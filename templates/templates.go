@@ -0,0 +1,46 @@
+// Package templates embeds the built-in project templates shipped with gh-pmu.
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// FS holds the bundled template YAML files
+//
+//go:embed *.yml
+var FS embed.FS
+
+// Names returns the names of all built-in templates (file names without extension)
+func Names() []string {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".yml") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".yml"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Read returns the contents of a built-in template by name.
+// Accepts "scrum", "scrum.yml", or "builtin:scrum".
+func Read(name string) ([]byte, error) {
+	name = strings.TrimPrefix(name, "builtin:")
+	name = strings.TrimSuffix(name, ".yml")
+
+	data, err := FS.ReadFile(name + ".yml")
+	if err != nil {
+		return nil, fmt.Errorf("built-in template %q not found (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return data, nil
+}